@@ -12,21 +12,34 @@ import (
 	bookingv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/booking/v1"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/bill"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/domain"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/repository/store"
 	"github.com/utilitywarehouse/energy-smart-booking/internal/auth"
 	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
 	"github.com/utilitywarehouse/energy-smart-booking/internal/repository/gateway"
 	"github.com/utilitywarehouse/energy-smart-booking/internal/repository/helpers"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/validation"
 	"github.com/utilitywarehouse/uwos-go/telemetry/tracing"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/genproto/googleapis/type/dayofweek"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const resourceID = "booking-api-server"
 
+// The reasons, in the ErrorInfo details of the FailedPrecondition errors, of the reschedules
+// rejected by the reschedule policy.
+const (
+	errorInfoDomain                = "energy-smart-booking"
+	ReasonRescheduleNoticeTooShort = "RESCHEDULE_NOTICE_TOO_SHORT"
+	ReasonRescheduleLimitReached   = "RESCHEDULE_LIMIT_REACHED"
+)
+
 var (
 	ErrUserUnauthorised = errors.New("user does not have required access")
 )
@@ -34,10 +47,14 @@ var (
 type BookingDomain interface {
 	GetCustomerContactDetails(ctx context.Context, accountID string) (models.Account, error)
 	GetAccountAddressByAccountID(ctx context.Context, accountID string) (models.AccountAddress, error)
+	GetBookableOccupancies(ctx context.Context, accountID string) ([]domain.BookableOccupancy, error)
 	GetCustomerBookings(ctx context.Context, accountID string) ([]*bookingv1.Booking, error)
 	CreateBooking(ctx context.Context, params domain.CreateBookingParams) (domain.CreateBookingResponse, error)
 	GetAvailableSlots(ctx context.Context, params domain.GetAvailableSlotsParams) (domain.GetAvailableSlotsResponse, error)
 	RescheduleBooking(ctx context.Context, params domain.RescheduleBookingParams) (domain.RescheduleBookingResponse, error)
+	HoldSlot(ctx context.Context, params domain.HoldSlotParams) (domain.HoldSlotResponse, error)
+	CancelBooking(ctx context.Context, params domain.CancelBookingParams) (domain.CancelBookingResponse, error)
+	UpdateBookingContactDetails(ctx context.Context, params domain.UpdateBookingContactDetailsParams) (domain.UpdateBookingContactDetailsResponse, error)
 
 	// POS Journey
 	CreateBookingPointOfSale(ctx context.Context, params domain.CreatePOSBookingParams) (domain.CreateBookingPointOfSaleResponse, error)
@@ -61,6 +78,16 @@ type Auth interface {
 	Authorize(ctx context.Context, params *auth.PolicyParams) (bool, error)
 }
 
+type IdempotencyStore interface {
+	Reserve(ctx context.Context, accountID, operation, key, requestHash string) (string, error)
+	Complete(ctx context.Context, accountID, operation, key, bookingID string) error
+	Release(ctx context.Context, accountID, operation, key string) error
+}
+
+type Transactor interface {
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type BookingAPI struct {
 	bookingDomain            BookingDomain
 	smartMeterInterestDomain SmartMeterInterestDomain
@@ -69,6 +96,8 @@ type BookingAPI struct {
 	rescheduleCommsPublisher Publisher
 	commentCodePublisher     Publisher
 	auth                     Auth
+	idempotency              IdempotencyStore
+	transactor               Transactor
 	bookingv1.UnimplementedBookingAPIServer
 	useTracing bool
 }
@@ -100,6 +129,20 @@ func New(
 	}
 }
 
+// WithIdempotency makes the requests which create or reschedule bookings with an idempotency key
+// return the booking of the original request when they are retried.
+func (b *BookingAPI) WithIdempotency(idempotency IdempotencyStore) *BookingAPI {
+	b.idempotency = idempotency
+	return b
+}
+
+// WithTransactions writes the events of a request and completes its idempotency key in one transaction,
+// so that none of them are kept when one fails.
+func (b *BookingAPI) WithTransactions(transactor Transactor) *BookingAPI {
+	b.transactor = transactor
+	return b
+}
+
 func (b *BookingAPI) GetCustomerContactDetails(ctx context.Context, req *bookingv1.GetCustomerContactDetailsRequest) (_ *bookingv1.GetCustomerContactDetailsResponse, err error) {
 	var span trace.Span
 	if b.useTracing {
@@ -187,22 +230,7 @@ func (b *BookingAPI) GetCustomerSiteAddress(ctx context.Context, req *bookingv1.
 		}
 	}
 
-	siteAddress := &addressv1.Address{
-		Uprn: accountAddress.UPRN,
-		Paf: &addressv1.Address_PAF{
-			Organisation:            accountAddress.PAF.Organisation,
-			Department:              accountAddress.PAF.Department,
-			SubBuilding:             accountAddress.PAF.SubBuilding,
-			BuildingName:            accountAddress.PAF.BuildingName,
-			BuildingNumber:          accountAddress.PAF.BuildingNumber,
-			DependentThoroughfare:   accountAddress.PAF.DependentThoroughfare,
-			Thoroughfare:            accountAddress.PAF.Thoroughfare,
-			DoubleDependentLocality: accountAddress.PAF.DoubleDependentLocality,
-			DependentLocality:       accountAddress.PAF.DependentLocality,
-			PostTown:                accountAddress.PAF.PostTown,
-			Postcode:                accountAddress.PAF.Postcode,
-		},
-	}
+	siteAddress := mapAccountAddress(accountAddress)
 
 	if b.useTracing {
 		addressAttr := helpers.CreateSpanAttribute(siteAddress, "address", span)
@@ -214,6 +242,55 @@ func (b *BookingAPI) GetCustomerSiteAddress(ctx context.Context, req *bookingv1.
 	}, nil
 }
 
+func (b *BookingAPI) ListBookableOccupancies(ctx context.Context, req *bookingv1.ListBookableOccupanciesRequest) (_ *bookingv1.ListBookableOccupanciesResponse, err error) {
+	var span trace.Span
+	if b.useTracing {
+		ctx, span = tracing.Start(ctx, "BookingAPI.ListBookableOccupancies",
+			trace.WithAttributes(attribute.String("account.id", req.GetAccountId())),
+		)
+		defer func() {
+			tracing.RecordError(span, err)
+			span.End()
+		}()
+	}
+
+	err = b.validateCredentials(ctx, auth.GetAction, auth.AccountBookingResource, req.AccountId)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrUserUnauthorised):
+			return nil, status.Errorf(codes.PermissionDenied, "user does not have access to this action, %s", err)
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to validate credentials")
+		}
+	}
+
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	bookableOccupancies, err := b.bookingDomain.GetBookableOccupancies(ctx, req.GetAccountId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list bookable occupancies, %s", err)
+	}
+
+	occupancies := make([]*bookingv1.BookableOccupancy, 0, len(bookableOccupancies))
+	for _, occupancy := range bookableOccupancies {
+		occupancies = append(occupancies, &bookingv1.BookableOccupancy{
+			OccupancyId: occupancy.OccupancyID,
+			SiteAddress: mapAccountAddress(occupancy.SiteAddress),
+		})
+	}
+
+	if b.useTracing {
+		occupanciesAttr := helpers.CreateSpanAttribute(occupancies, "occupancies", span)
+		span.AddEvent("response", trace.WithAttributes(occupanciesAttr))
+	}
+
+	return &bookingv1.ListBookableOccupanciesResponse{
+		Occupancies: occupancies,
+	}, nil
+}
+
 func (b *BookingAPI) GetCustomerBookings(ctx context.Context, req *bookingv1.GetCustomerBookingsRequest) (_ *bookingv1.GetCustomerBookingsResponse, err error) {
 	var span trace.Span
 	if b.useTracing {
@@ -284,10 +361,17 @@ func (b *BookingAPI) GetAvailableSlots(ctx context.Context, req *bookingv1.GetAv
 		return nil, status.Error(codes.InvalidArgument, "no date To provided")
 	}
 
+	filter, err := mapSlotFilter(req.From, req.To, req.GetDaysOfWeek(), req.GetTimeWindow(), req.GetLimit())
+	if err != nil {
+		return nil, err
+	}
+
 	params := domain.GetAvailableSlotsParams{
-		AccountID: req.AccountId,
-		From:      req.From,
-		To:        req.To,
+		AccountID:   req.AccountId,
+		OccupancyID: req.GetOccupancyId(),
+		From:        req.From,
+		To:          req.To,
+		Filter:      filter,
 	}
 
 	availableSlotsResponse, err := b.bookingDomain.GetAvailableSlots(ctx, params)
@@ -297,22 +381,7 @@ func (b *BookingAPI) GetAvailableSlots(ctx context.Context, req *bookingv1.GetAv
 		}, mapError("failed to get available slots, %s", err)
 	}
 
-	bookingSlots := make([]*bookingv1.BookingSlot, len(availableSlotsResponse.Slots))
-
-	for index, slot := range availableSlotsResponse.Slots {
-
-		bookingSlot := bookingv1.BookingSlot{
-			Date: &date.Date{
-				Year:  int32(slot.Date.Year()),
-				Month: int32(slot.Date.Month()),
-				Day:   int32(slot.Date.Day()),
-			},
-			StartTime: int32(slot.StartTime),
-			EndTime:   int32(slot.EndTime),
-		}
-
-		bookingSlots[index] = &bookingSlot
-	}
+	bookingSlots := mapBookingSlots(availableSlotsResponse.Slots)
 
 	if b.useTracing {
 		bookingSlotsAttr := helpers.CreateSpanAttribute(bookingSlots, "bookingSlots", span)
@@ -321,6 +390,57 @@ func (b *BookingAPI) GetAvailableSlots(ctx context.Context, req *bookingv1.GetAv
 
 	return &bookingv1.GetAvailableSlotsResponse{
 		Slots: bookingSlots,
+		Days:  mapSlotDays(availableSlotsResponse.Days()),
+	}, nil
+}
+
+func (b *BookingAPI) HoldSlot(ctx context.Context, req *bookingv1.HoldSlotRequest) (_ *bookingv1.HoldSlotResponse, err error) {
+	if b.useTracing {
+		var span trace.Span
+		ctx, span = tracing.Start(ctx, "BookingAPI.HoldSlot",
+			trace.WithAttributes(attribute.String("account.id", req.GetAccountId())),
+		)
+		defer func() {
+			tracing.RecordError(span, err)
+			span.End()
+		}()
+	}
+
+	err = b.validateCredentials(ctx, auth.CreateAction, auth.AccountBookingResource, req.AccountId)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrUserUnauthorised):
+			return nil, status.Errorf(codes.PermissionDenied, "user does not have access to this action, %s", err)
+		default:
+			return nil, status.Error(codes.Internal, "failed to validate credentials")
+		}
+	}
+
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	if req.Slot == nil {
+		return nil, status.Error(codes.InvalidArgument, "no slot provided")
+	}
+
+	params := domain.HoldSlotParams{
+		AccountID:   req.AccountId,
+		OccupancyID: req.GetOccupancyId(),
+		Slot: models.BookingSlot{
+			Date:      time.Date(int(req.Slot.Date.Year), time.Month(req.Slot.Date.Month), int(req.Slot.Date.Day), 0, 0, 0, 0, time.UTC),
+			StartTime: int(req.Slot.StartTime),
+			EndTime:   int(req.Slot.EndTime),
+		},
+	}
+
+	holdSlotResponse, err := b.bookingDomain.HoldSlot(ctx, params)
+	if err != nil {
+		return nil, mapError("failed to hold slot, %s", err)
+	}
+
+	return &bookingv1.HoldSlotResponse{
+		ExpiresAt: timestamppb.New(holdSlotResponse.ExpiresAt),
 	}, nil
 }
 
@@ -367,7 +487,8 @@ func (b *BookingAPI) CreateBooking(ctx context.Context, req *bookingv1.CreateBoo
 	}
 
 	params := domain.CreateBookingParams{
-		AccountID: req.AccountId,
+		AccountID:   req.AccountId,
+		OccupancyID: req.GetOccupancyId(),
 		ContactDetails: models.AccountDetails{
 			Title:     req.GetContactDetails().Title,
 			FirstName: req.GetContactDetails().FirstName,
@@ -384,20 +505,39 @@ func (b *BookingAPI) CreateBooking(ctx context.Context, req *bookingv1.CreateBoo
 		Source:               models.PlatformSourceToBookingSource(req.Platform),
 	}
 
+	replayedBookingID, err := b.reserveIdempotencyKey(ctx, operationCreateBooking, req.AccountId, req)
+	if err != nil {
+		return nil, err
+	}
+	if replayedBookingID != "" {
+		return &bookingv1.CreateBookingResponse{
+			BookingId: replayedBookingID,
+		}, nil
+	}
+
 	createBookingResponse, err := b.bookingDomain.CreateBooking(ctx, params)
 	if err != nil {
+		b.releaseIdempotencyKey(ctx, operationCreateBooking, req.AccountId, req)
 		return &bookingv1.CreateBookingResponse{
 			BookingId: "",
 		}, mapError("failed to create booking, %s", err)
 	}
 
-	err = b.bookingPublisher.Sink(ctx, createBookingResponse.Event, time.Now())
+	bookingID := createBookingResponse.Event.(*bookingv1.BookingCreatedEvent).BookingId
+
+	// the idempotency key stays pending when the event cannot be written, so that the retries do not book again
+	err = b.inTx(ctx, func(ctx context.Context) error {
+		if err := b.bookingPublisher.Sink(ctx, createBookingResponse.Event, time.Now()); err != nil {
+			return status.Errorf(codes.Internal, "failed to sink create booking event of booking %s, %s", bookingID, err)
+		}
+		return b.completeIdempotencyKey(ctx, operationCreateBooking, req.AccountId, req, bookingID)
+	})
 	if err != nil {
-		slog.Error("failed to sink create booking event", "booking_event", createBookingResponse.Event)
+		return nil, err
 	}
 
 	return &bookingv1.CreateBookingResponse{
-		BookingId: createBookingResponse.Event.(*bookingv1.BookingCreatedEvent).BookingId,
+		BookingId: bookingID,
 	}, nil
 }
 
@@ -452,6 +592,7 @@ func (b *BookingAPI) RescheduleBooking(ctx context.Context, req *bookingv1.Resch
 	params := domain.RescheduleBookingParams{
 		AccountID:            req.AccountId,
 		BookingID:            req.BookingId,
+		OccupancyID:          req.GetOccupancyId(),
 		VulnerabilityDetails: req.VulnerabilityDetails,
 		ContactDetails: models.AccountDetails{
 			Title:     req.GetContactDetails().Title,
@@ -468,8 +609,19 @@ func (b *BookingAPI) RescheduleBooking(ctx context.Context, req *bookingv1.Resch
 		Source: models.PlatformSourceToBookingSource(req.Platform),
 	}
 
+	replayedBookingID, err := b.reserveIdempotencyKey(ctx, operationRescheduleBooking, req.AccountId, req)
+	if err != nil {
+		return nil, err
+	}
+	if replayedBookingID != "" {
+		return &bookingv1.RescheduleBookingResponse{
+			BookingId: replayedBookingID,
+		}, nil
+	}
+
 	rescheduleBookingResponse, err := b.bookingDomain.RescheduleBooking(ctx, params)
 	if err != nil {
+		b.releaseIdempotencyKey(ctx, operationRescheduleBooking, req.AccountId, req)
 		switch err {
 		case domain.ErrUnsuccessfulReschedule:
 			return &bookingv1.RescheduleBookingResponse{
@@ -482,20 +634,166 @@ func (b *BookingAPI) RescheduleBooking(ctx context.Context, req *bookingv1.Resch
 		}
 	}
 
-	err = b.bookingPublisher.Sink(ctx, rescheduleBookingResponse.BookingEvent, time.Now())
+	bookingID := rescheduleBookingResponse.BookingEvent.(*bookingv1.BookingRescheduledEvent).BookingId
+
+	// the events are written and the idempotency key completed together, the key stays pending when
+	// they cannot be so that the retries do not reschedule again
+	err = b.inTx(ctx, func(ctx context.Context) error {
+		if err := b.bookingPublisher.Sink(ctx, rescheduleBookingResponse.BookingEvent, time.Now()); err != nil {
+			return status.Errorf(codes.Internal, "failed to sink reschedule booking event of booking %s, %s", bookingID, err)
+		}
+
+		if rescheduleBookingResponse.CommsEvent != nil {
+			if err := b.rescheduleCommsPublisher.Sink(ctx, rescheduleBookingResponse.CommsEvent, time.Now()); err != nil {
+				return status.Errorf(codes.Internal, "failed to sink reschedule comms event of booking %s, %s", bookingID, err)
+			}
+		}
+
+		return b.completeIdempotencyKey(ctx, operationRescheduleBooking, req.AccountId, req, bookingID)
+	})
 	if err != nil {
-		slog.Error("failed to sink reschedule booking event", "booking_event", rescheduleBookingResponse.BookingEvent)
+		return nil, err
 	}
 
-	if rescheduleBookingResponse.CommsEvent != nil {
-		err = b.rescheduleCommsPublisher.Sink(ctx, rescheduleBookingResponse.CommsEvent, time.Now())
-		if err != nil {
-			slog.Error("failed to sink comms event", "comms_event", rescheduleBookingResponse.BookingEvent)
+	return &bookingv1.RescheduleBookingResponse{
+		BookingId: bookingID,
+	}, nil
+}
+
+func (b *BookingAPI) CancelBooking(ctx context.Context, req *bookingv1.CancelBookingRequest) (_ *bookingv1.CancelBookingResponse, err error) {
+	if b.useTracing {
+		var span trace.Span
+		ctx, span = tracing.Start(ctx, "BookingAPI.CancelBooking", trace.WithAttributes(
+			attribute.String("account.id", req.GetAccountId()),
+			attribute.String("booking.id", req.GetBookingId()),
+		),
+		)
+		defer func() {
+			tracing.RecordError(span, err)
+			span.End()
+		}()
+	}
+
+	err = b.validateCredentials(ctx, auth.UpdateAction, auth.AccountBookingResource, req.AccountId)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrUserUnauthorised):
+			return nil, status.Errorf(codes.PermissionDenied, "user does not have access to this action, %s", err)
+		default:
+			return nil, status.Error(codes.Internal, "failed to validate credentials")
 		}
 	}
 
-	return &bookingv1.RescheduleBookingResponse{
-		BookingId: rescheduleBookingResponse.BookingEvent.(*bookingv1.BookingRescheduledEvent).BookingId,
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	if req.BookingId == "" {
+		return nil, status.Error(codes.InvalidArgument, "no booking id provided")
+	}
+
+	if req.Platform == bookingv1.Platform_PLATFORM_UNKNOWN {
+		return nil, status.Error(codes.InvalidArgument, "platform unknown")
+	}
+
+	cancelBookingResponse, err := b.bookingDomain.CancelBooking(ctx, domain.CancelBookingParams{
+		AccountID: req.AccountId,
+		BookingID: req.BookingId,
+		Source:    models.PlatformSourceToBookingSource(req.Platform),
+		Reason:    req.Reason,
+	})
+	if err != nil {
+		switch err {
+		case domain.ErrUnsuccessfulCancellation:
+			return nil, status.Errorf(codes.Internal, "failed to cancel booking, %s", domain.ErrUnsuccessfulCancellation)
+		default:
+			return nil, mapError("failed to cancel booking, %s", err)
+		}
+	}
+
+	err = b.bookingPublisher.Sink(ctx, cancelBookingResponse.Event, time.Now())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to sink cancel booking event of booking %s, %s", req.BookingId, err)
+	}
+
+	return &bookingv1.CancelBookingResponse{
+		BookingId: req.BookingId,
+	}, nil
+}
+
+func (b *BookingAPI) UpdateBookingContactDetails(ctx context.Context, req *bookingv1.UpdateBookingContactDetailsRequest) (_ *bookingv1.UpdateBookingContactDetailsResponse, err error) {
+	if b.useTracing {
+		var span trace.Span
+		ctx, span = tracing.Start(ctx, "BookingAPI.UpdateBookingContactDetails", trace.WithAttributes(
+			attribute.String("account.id", req.GetAccountId()),
+			attribute.String("booking.id", req.GetBookingId()),
+		),
+		)
+		defer func() {
+			tracing.RecordError(span, err)
+			span.End()
+		}()
+	}
+
+	err = b.validateCredentials(ctx, auth.UpdateAction, auth.AccountBookingResource, req.AccountId)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrUserUnauthorised):
+			return nil, status.Errorf(codes.PermissionDenied, "user does not have access to this action, %s", err)
+		default:
+			return nil, status.Error(codes.Internal, "failed to validate credentials")
+		}
+	}
+
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	if req.BookingId == "" {
+		return nil, status.Error(codes.InvalidArgument, "no booking id provided")
+	}
+
+	if req.Platform == bookingv1.Platform_PLATFORM_UNKNOWN {
+		return nil, status.Error(codes.InvalidArgument, "platform unknown")
+	}
+
+	if req.ContactDetails == nil {
+		return nil, status.Error(codes.InvalidArgument, "no contact details provided")
+	}
+
+	if req.VulnerabilityDetails == nil {
+		return nil, status.Error(codes.InvalidArgument, "no vulnerability details provided")
+	}
+
+	updateResponse, err := b.bookingDomain.UpdateBookingContactDetails(ctx, domain.UpdateBookingContactDetailsParams{
+		AccountID: req.AccountId,
+		BookingID: req.BookingId,
+		Source:    models.PlatformSourceToBookingSource(req.Platform),
+		ContactDetails: models.AccountDetails{
+			Title:     req.GetContactDetails().Title,
+			FirstName: req.GetContactDetails().FirstName,
+			LastName:  req.GetContactDetails().LastName,
+			Email:     req.GetContactDetails().Email,
+			Mobile:    req.GetContactDetails().Phone,
+		},
+		VulnerabilityDetails: req.VulnerabilityDetails,
+	})
+	if err != nil {
+		switch err {
+		case domain.ErrUnsuccessfulContactDetailsUpdate:
+			return nil, status.Errorf(codes.Internal, "failed to update booking contact details, %s", domain.ErrUnsuccessfulContactDetailsUpdate)
+		default:
+			return nil, mapError("failed to update booking contact details, %s", err)
+		}
+	}
+
+	err = b.bookingPublisher.Sink(ctx, updateResponse.Event, time.Now())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to sink booking contact details updated event of booking %s, %s", req.BookingId, err)
+	}
+
+	return &bookingv1.UpdateBookingContactDetailsResponse{
+		BookingId: req.BookingId,
 	}, nil
 }
 
@@ -538,10 +836,16 @@ func (b *BookingAPI) GetAvailableSlotsPointOfSale(ctx context.Context, req *book
 		return nil, status.Error(codes.InvalidArgument, "no date To provided")
 	}
 
+	filter, err := mapSlotFilter(req.From, req.To, req.GetDaysOfWeek(), req.GetTimeWindow(), req.GetLimit())
+	if err != nil {
+		return nil, err
+	}
+
 	params := domain.GetPOSAvailableSlotsParams{
 		AccountNumber: req.AccountNumber,
 		From:          req.From,
 		To:            req.To,
+		Filter:        filter,
 	}
 
 	availableSlotsResponse, err := b.bookingDomain.GetAvailableSlotsPointOfSale(ctx, params)
@@ -551,22 +855,7 @@ func (b *BookingAPI) GetAvailableSlotsPointOfSale(ctx context.Context, req *book
 		}, mapError("failed to get available slots, %s", err)
 	}
 
-	bookingSlots := make([]*bookingv1.BookingSlot, len(availableSlotsResponse.Slots))
-
-	for index, slot := range availableSlotsResponse.Slots {
-
-		bookingSlot := bookingv1.BookingSlot{
-			Date: &date.Date{
-				Year:  int32(slot.Date.Year()),
-				Month: int32(slot.Date.Month()),
-				Day:   int32(slot.Date.Day()),
-			},
-			StartTime: int32(slot.StartTime),
-			EndTime:   int32(slot.EndTime),
-		}
-
-		bookingSlots[index] = &bookingSlot
-	}
+	bookingSlots := mapBookingSlots(availableSlotsResponse.Slots)
 
 	if b.useTracing {
 		bookingSlotsAttr := helpers.CreateSpanAttribute(bookingSlots, "bookingSlots", span)
@@ -575,6 +864,7 @@ func (b *BookingAPI) GetAvailableSlotsPointOfSale(ctx context.Context, req *book
 
 	return &bookingv1.GetAvailableSlotsPointOfSaleResponse{
 		Slots: bookingSlots,
+		Days:  mapSlotDays(availableSlotsResponse.Days()),
 	}, nil
 }
 
@@ -643,6 +933,16 @@ func (b *BookingAPI) CreateBookingPointOfSale(ctx context.Context, req *bookingv
 		Source:               models.PlatformSourceToBookingSource(req.Platform),
 	}
 
+	replayedBookingID, err := b.reserveIdempotencyKey(ctx, operationCreateBookingPointOfSale, accountID, req)
+	if err != nil {
+		return nil, err
+	}
+	if replayedBookingID != "" {
+		return &bookingv1.CreateBookingPointOfSaleResponse{
+			BookingId: replayedBookingID,
+		}, nil
+	}
+
 	createBookingResponse, err := b.bookingDomain.CreateBookingPointOfSale(ctx, params)
 	if err != nil {
 		switch err {
@@ -651,26 +951,37 @@ func (b *BookingAPI) CreateBookingPointOfSale(ctx context.Context, req *bookingv
 				"account_id", createBookingResponse.BookingEvent.(*bookingv1.BookingCreatedEvent).Details.AccountId,
 				"booking_id", createBookingResponse.BookingEvent.(*bookingv1.BookingCreatedEvent).BookingId)
 		default:
+			b.releaseIdempotencyKey(ctx, operationCreateBookingPointOfSale, accountID, req)
 			return &bookingv1.CreateBookingPointOfSaleResponse{
 				BookingId: "",
 			}, mapError("failed to create booking, %s", err)
 		}
 	}
 
-	if !errors.Is(err, domain.ErrMissingOccupancyInBooking) {
-		err = b.bookingPublisher.Sink(ctx, createBookingResponse.BookingEvent, time.Now())
-		if err != nil {
-			slog.Error("failed to sink create booking event", "error", err, "booking_event", createBookingResponse.BookingEvent)
+	bookingID := createBookingResponse.BookingEvent.(*bookingv1.BookingCreatedEvent).BookingId
+
+	// the events are written and the idempotency key completed together, the key stays pending when
+	// they cannot be so that the retries do not book again
+	missingOccupancy := errors.Is(err, domain.ErrMissingOccupancyInBooking)
+	err = b.inTx(ctx, func(ctx context.Context) error {
+		if !missingOccupancy {
+			if err := b.bookingPublisher.Sink(ctx, createBookingResponse.BookingEvent, time.Now()); err != nil {
+				return status.Errorf(codes.Internal, "failed to sink create booking event of booking %s, %s", bookingID, err)
+			}
 		}
-	}
 
-	err = b.commsPublisher.Sink(ctx, createBookingResponse.CommsEvent, time.Now())
+		if err := b.commsPublisher.Sink(ctx, createBookingResponse.CommsEvent, time.Now()); err != nil {
+			return status.Errorf(codes.Internal, "failed to sink point of sale booking confirmation event of booking %s, %s", bookingID, err)
+		}
+
+		return b.completeIdempotencyKey(ctx, operationCreateBookingPointOfSale, accountID, req, bookingID)
+	})
 	if err != nil {
-		slog.Error("failed to sink comm point of sale booking confirmation event", "error", err, "pos_confirmation_event", createBookingResponse.CommsEvent)
+		return nil, err
 	}
 
 	return &bookingv1.CreateBookingPointOfSaleResponse{
-		BookingId: createBookingResponse.BookingEvent.(*bookingv1.BookingCreatedEvent).BookingId,
+		BookingId: bookingID,
 	}, nil
 }
 
@@ -763,6 +1074,11 @@ func (b *BookingAPI) GetEligibilityPointOfSaleJourney(ctx context.Context, req *
 		return nil, status.Error(codes.InvalidArgument, "provided mpan is missing")
 	}
 
+	mpan, mprn, postcode, err := validateMeterpoints(req.Mpan, req.Mprn, req.Postcode)
+	if err != nil {
+		return nil, err
+	}
+
 	err = b.validateCredentials(ctx, auth.GetAction, auth.POSResource, resourceID)
 	if err != nil {
 		switch {
@@ -774,12 +1090,12 @@ func (b *BookingAPI) GetEligibilityPointOfSaleJourney(ctx context.Context, req *
 	}
 
 	result, err := b.bookingDomain.ProcessEligibility(ctx, domain.ProcessEligibilityParams{
-		Postcode: req.Postcode,
+		Postcode: postcode,
 		ElecOrderSupplies: models.OrderSupply{
-			MPXN: req.Mpan,
+			MPXN: mpan,
 		},
 		GasOrderSupplies: models.OrderSupply{
-			MPXN: req.Mprn,
+			MPXN: mprn,
 		},
 	})
 	if err != nil {
@@ -787,11 +1103,17 @@ func (b *BookingAPI) GetEligibilityPointOfSaleJourney(ctx context.Context, req *
 	}
 
 	if b.useTracing {
-		span.AddEvent("response", trace.WithAttributes(attribute.Bool("eligible", result.Eligible)))
+		span.AddEvent("response", trace.WithAttributes(
+			attribute.Bool("eligible", result.Eligible),
+			attribute.String("electricity.reasons", fmt.Sprintf("%v", result.ElectricityIneligibleReasons)),
+			attribute.String("gas.reasons", fmt.Sprintf("%v", result.GasIneligibleReasons)),
+		))
 	}
 
 	return &bookingv1.GetEligibilityPointOfSaleJourneyResponse{
-		Eligible: result.Eligible,
+		Eligible:                     result.Eligible,
+		ElectricityIneligibleReasons: result.ElectricityIneligibleReasons,
+		GasIneligibleReasons:         result.GasIneligibleReasons,
 	}, nil
 }
 
@@ -841,6 +1163,11 @@ func (b *BookingAPI) GetClickLinkPointOfSaleJourney(ctx context.Context, req *bo
 		return nil, status.Error(codes.InvalidArgument, "provided mprn is not empty, but gas tariff type is unknown")
 	}
 
+	mpan, mprn, postcode, err := validateMeterpoints(req.Mpan, req.Mprn, req.SiteAddress.Paf.Postcode)
+	if err != nil {
+		return nil, err
+	}
+
 	err = b.validateCredentials(ctx, auth.GetAction, auth.POSResource, req.AccountNumber)
 	if err != nil {
 		switch {
@@ -873,17 +1200,17 @@ func (b *BookingAPI) GetClickLinkPointOfSaleJourney(ctx context.Context, req *bo
 					DoubleDependentLocality: req.SiteAddress.Paf.DoubleDependentLocality,
 					Organisation:            req.SiteAddress.Paf.Organisation,
 					PostTown:                req.SiteAddress.Paf.PostTown,
-					Postcode:                req.SiteAddress.Paf.Postcode,
+					Postcode:                postcode,
 					SubBuilding:             req.SiteAddress.Paf.SubBuilding,
 					Thoroughfare:            req.SiteAddress.Paf.Thoroughfare,
 				},
 			},
 			ElecOrderSupplies: models.OrderSupply{
-				MPXN:       req.Mpan,
+				MPXN:       mpan,
 				TariffType: req.ElectricityTariffType,
 			},
 			GasOrderSupplies: models.OrderSupply{
-				MPXN:       req.Mprn,
+				MPXN:       mprn,
 				TariffType: req.GasTariffType,
 			},
 		},
@@ -984,6 +1311,27 @@ func validateRequest(req accountIder) error {
 	return nil
 }
 
+// validateMeterpoints checks the meterpoint numbers and postcode provided for a point of sale
+// journey and returns them normalised, the mprn is optional.
+func validateMeterpoints(mpan, mprn, postcode string) (string, string, string, error) {
+	mpan, err := validation.MPAN(mpan)
+	if err != nil {
+		return "", "", "", status.Error(codes.InvalidArgument, err.Error())
+	}
+	if mprn != "" {
+		mprn, err = validation.MPRN(mprn)
+		if err != nil {
+			return "", "", "", status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	postcode, err = validation.Postcode(postcode)
+	if err != nil {
+		return "", "", "", status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return mpan, mprn, postcode, nil
+}
+
 func (b *BookingAPI) validateCredentials(ctx context.Context, action, resource, requestAccountID string) error {
 
 	authorised, err := b.auth.Authorize(ctx, &auth.PolicyParams{
@@ -1002,6 +1350,116 @@ func (b *BookingAPI) validateCredentials(ctx context.Context, action, resource,
 	return nil
 }
 
+// mapSlotFilter validates the slot search options of a request and maps them to the domain filter.
+func mapSlotFilter(from, to *date.Date, daysOfWeek []dayofweek.DayOfWeek, timeWindow bookingv1.SlotTimeWindow, limit int32) (domain.SlotFilter, error) {
+	fromAsTime := time.Date(int(from.Year), time.Month(from.Month), int(from.Day), 0, 0, 0, 0, time.UTC)
+	toAsTime := time.Date(int(to.Year), time.Month(to.Month), int(to.Day), 0, 0, 0, 0, time.UTC)
+
+	if fromAsTime.After(toAsTime) {
+		return domain.SlotFilter{}, status.Error(codes.InvalidArgument, "date From is after date To")
+	}
+
+	if limit < 0 {
+		return domain.SlotFilter{}, status.Error(codes.InvalidArgument, "limit can not be negative")
+	}
+
+	filter := domain.SlotFilter{
+		Limit: int(limit),
+	}
+
+	for _, dayOfWeek := range daysOfWeek {
+		if dayOfWeek < dayofweek.DayOfWeek_MONDAY || dayOfWeek > dayofweek.DayOfWeek_SUNDAY {
+			return domain.SlotFilter{}, status.Errorf(codes.InvalidArgument, "invalid day of week provided: %s", dayOfWeek)
+		}
+		// google.type.DayOfWeek starts on Monday=1 and ends on Sunday=7, while time.Weekday starts on Sunday=0
+		filter.Weekdays = append(filter.Weekdays, time.Weekday(dayOfWeek%7))
+	}
+
+	switch timeWindow {
+	case bookingv1.SlotTimeWindow_SLOT_TIME_WINDOW_UNKNOWN:
+		filter.Window = domain.SlotWindowAny
+	case bookingv1.SlotTimeWindow_SLOT_TIME_WINDOW_AM:
+		filter.Window = domain.SlotWindowAM
+	case bookingv1.SlotTimeWindow_SLOT_TIME_WINDOW_PM:
+		filter.Window = domain.SlotWindowPM
+	default:
+		return domain.SlotFilter{}, status.Errorf(codes.InvalidArgument, "invalid time window provided: %s", timeWindow)
+	}
+
+	return filter, nil
+}
+
+func mapBookingSlots(slots []models.BookingSlot) []*bookingv1.BookingSlot {
+	bookingSlots := make([]*bookingv1.BookingSlot, len(slots))
+
+	for index, slot := range slots {
+
+		bookingSlot := bookingv1.BookingSlot{
+			Date: &date.Date{
+				Year:  int32(slot.Date.Year()),
+				Month: int32(slot.Date.Month()),
+				Day:   int32(slot.Date.Day()),
+			},
+			StartTime: int32(slot.StartTime),
+			EndTime:   int32(slot.EndTime),
+		}
+
+		bookingSlots[index] = &bookingSlot
+	}
+
+	return bookingSlots
+}
+
+func mapSlotDays(days []domain.SlotDay) []*bookingv1.SlotDay {
+	slotDays := make([]*bookingv1.SlotDay, len(days))
+
+	for index, day := range days {
+		slotDays[index] = &bookingv1.SlotDay{
+			Date: &date.Date{
+				Year:  int32(day.Date.Year()),
+				Month: int32(day.Date.Month()),
+				Day:   int32(day.Date.Day()),
+			},
+			Slots: mapBookingSlots(day.Slots),
+		}
+	}
+
+	return slotDays
+}
+
+func mapAccountAddress(accountAddress models.AccountAddress) *addressv1.Address {
+	return &addressv1.Address{
+		Uprn: accountAddress.UPRN,
+		Paf: &addressv1.Address_PAF{
+			Organisation:            accountAddress.PAF.Organisation,
+			Department:              accountAddress.PAF.Department,
+			SubBuilding:             accountAddress.PAF.SubBuilding,
+			BuildingName:            accountAddress.PAF.BuildingName,
+			BuildingNumber:          accountAddress.PAF.BuildingNumber,
+			DependentThoroughfare:   accountAddress.PAF.DependentThoroughfare,
+			Thoroughfare:            accountAddress.PAF.Thoroughfare,
+			DoubleDependentLocality: accountAddress.PAF.DoubleDependentLocality,
+			DependentLocality:       accountAddress.PAF.DependentLocality,
+			PostTown:                accountAddress.PAF.PostTown,
+			Postcode:                accountAddress.PAF.Postcode,
+		},
+	}
+}
+
+// failedPreconditionWithReason adds the reason of the failed precondition to the error details, for
+// the clients to tell the preconditions apart.
+func failedPreconditionWithReason(reason, message string, err error) error {
+	st := status.Newf(codes.FailedPrecondition, message, err)
+	detailed, detailsErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: errorInfoDomain,
+	})
+	if detailsErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
 func mapError(message string, err error) error {
 	switch {
 	case errors.Is(err, gateway.ErrInvalidArgument):
@@ -1025,6 +1483,42 @@ func mapError(message string, err error) error {
 	case errors.Is(err, gateway.ErrAlreadyExists):
 		return status.Errorf(codes.AlreadyExists, message, err)
 
+	case errors.Is(err, gateway.ErrFailedPrecondition):
+		return status.Errorf(codes.FailedPrecondition, message, err)
+
+	case errors.Is(err, store.ErrBookingNotFound):
+		return status.Errorf(codes.NotFound, message, err)
+
+	case errors.Is(err, domain.ErrBookingAccountMismatch):
+		return status.Errorf(codes.PermissionDenied, message, err)
+
+	case errors.Is(err, domain.ErrBookingAlreadyCancelled):
+		return status.Errorf(codes.FailedPrecondition, message, err)
+
+	case errors.Is(err, domain.ErrOccupancyAccountMismatch):
+		return status.Errorf(codes.PermissionDenied, message, err)
+
+	case errors.Is(err, domain.ErrOccupancyNotEligible):
+		return status.Errorf(codes.FailedPrecondition, message, err)
+
+	case errors.Is(err, domain.ErrBookingOccupancyMismatch):
+		return status.Errorf(codes.InvalidArgument, message, err)
+
+	case errors.Is(err, domain.ErrNoEligibleOccupanciesFound):
+		return status.Errorf(codes.NotFound, message, err)
+
+	case errors.Is(err, domain.ErrRescheduleNoticeTooShort):
+		return failedPreconditionWithReason(ReasonRescheduleNoticeTooShort, message, err)
+
+	case errors.Is(err, domain.ErrRescheduleLimitReached):
+		return failedPreconditionWithReason(ReasonRescheduleLimitReached, message, err)
+
+	case errors.Is(err, domain.ErrSlotUnavailable):
+		return status.Errorf(codes.ResourceExhausted, message, err)
+
+	case errors.Is(err, domain.ErrSlotHoldsNotSupported):
+		return status.Errorf(codes.Unimplemented, message, err)
+
 	case errors.Is(err, gateway.ErrInvalidAppointmentDate):
 		return status.Errorf(codes.InvalidArgument, message, err)
 
@@ -15,15 +15,19 @@ import (
 	"github.com/utilitywarehouse/account-platform/pkg/id"
 	"github.com/utilitywarehouse/bill-contracts/go/pkg/generated/bill_contracts"
 	addressv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/energy_entities/address/v1"
+	smart "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart/v1"
 	bookingv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/booking/v1"
 	commsv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/comms/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/genproto/googleapis/type/dayofweek"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/api"
 	mocks "github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/api/mocks"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/domain"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/repository/store"
 	"github.com/utilitywarehouse/energy-smart-booking/internal/auth"
 	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
 	"github.com/utilitywarehouse/energy-smart-booking/internal/repository/gateway"
@@ -424,6 +428,149 @@ func Test_GetCustomerSiteAddress(t *testing.T) {
 	}
 }
 
+func Test_ListBookableOccupancies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	defer ctrl.Finish()
+
+	bookingDomain := mocks.NewMockBookingDomain(ctrl)
+	mockAuth := mocks.NewMockAuth(ctrl)
+
+	myAPIHandler := api.New(bookingDomain, nil, nil, nil, nil, nil, mockAuth, false)
+
+	type inputParams struct {
+		req *bookingv1.ListBookableOccupanciesRequest
+	}
+
+	type outputParams struct {
+		res *bookingv1.ListBookableOccupanciesResponse
+		err error
+	}
+
+	type testSetup struct {
+		description string
+		setup       func(ctx context.Context, domain *mocks.MockBookingDomain, mAuth *mocks.MockAuth)
+		input       inputParams
+		output      outputParams
+	}
+
+	testCases := []testSetup{
+		{
+			description: "should list the bookable occupancies of the account",
+			input: inputParams{
+				req: &bookingv1.ListBookableOccupanciesRequest{
+					AccountId: "account-id-1",
+				},
+			},
+			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, mAuth *mocks.MockAuth) {
+
+				mAuth.EXPECT().Authorize(ctx, &auth.PolicyParams{
+					Action:     "get",
+					Resource:   "uw.energy.v1.account.smart-meter-booking",
+					ResourceID: "account-id-1",
+				}).Return(true, nil)
+
+				bkDomain.EXPECT().GetBookableOccupancies(ctx, "account-id-1").Return([]domain.BookableOccupancy{
+					{
+						OccupancyID: "occupancy-id-2",
+						SiteAddress: models.AccountAddress{
+							UPRN: "uprn-2",
+							PAF: models.PAF{
+								BuildingNumber: "2",
+								Thoroughfare:   "tf",
+								PostTown:       "pt",
+								Postcode:       "E2 1ZZ",
+							},
+						},
+					},
+					{
+						OccupancyID: "occupancy-id-1",
+						SiteAddress: models.AccountAddress{
+							UPRN: "uprn-1",
+							PAF: models.PAF{
+								BuildingNumber: "1",
+								Thoroughfare:   "tf",
+								PostTown:       "pt",
+								Postcode:       "E2 1ZZ",
+							},
+						},
+					},
+				}, nil)
+			},
+			output: outputParams{
+				res: &bookingv1.ListBookableOccupanciesResponse{
+					Occupancies: []*bookingv1.BookableOccupancy{
+						{
+							OccupancyId: "occupancy-id-2",
+							SiteAddress: &addressv1.Address{
+								Uprn: "uprn-2",
+								Paf: &addressv1.Address_PAF{
+									BuildingNumber: "2",
+									Thoroughfare:   "tf",
+									PostTown:       "pt",
+									Postcode:       "E2 1ZZ",
+								},
+							},
+						},
+						{
+							OccupancyId: "occupancy-id-1",
+							SiteAddress: &addressv1.Address{
+								Uprn: "uprn-1",
+								Paf: &addressv1.Address_PAF{
+									BuildingNumber: "1",
+									Thoroughfare:   "tf",
+									PostTown:       "pt",
+									Postcode:       "E2 1ZZ",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "should fail to list the bookable occupancies because user is not authorised",
+			input: inputParams{
+				req: &bookingv1.ListBookableOccupanciesRequest{
+					AccountId: "account-id-1",
+				},
+			},
+			setup: func(ctx context.Context, _ *mocks.MockBookingDomain, mAuth *mocks.MockAuth) {
+
+				mAuth.EXPECT().Authorize(ctx, &auth.PolicyParams{
+					Action:     "get",
+					Resource:   "uw.energy.v1.account.smart-meter-booking",
+					ResourceID: "account-id-1",
+				}).Return(false, nil)
+			},
+			output: outputParams{
+				res: nil,
+				err: status.Errorf(codes.PermissionDenied, "user does not have access to this action, %s", api.ErrUserUnauthorised),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+
+			tc.setup(ctx, bookingDomain, mockAuth)
+
+			expected, err := myAPIHandler.ListBookableOccupancies(ctx, tc.input.req)
+			if tc.output.err != nil {
+				if diff := cmp.Diff(err.Error(), tc.output.err.Error()); diff != "" {
+					t.Fatal(diff)
+				}
+			}
+
+			if diff := cmp.Diff(expected, tc.output.res, cmpopts.IgnoreUnexported(addressv1.Address{}, addressv1.Address_PAF{}, bookingv1.BookableOccupancy{}, bookingv1.ListBookableOccupanciesResponse{})); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
 func Test_GetCustomerBookings(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
@@ -798,6 +945,80 @@ func Test_GetAvailableSlot(t *testing.T) {
 							EndTime:   18,
 						},
 					},
+					Days: []*bookingv1.SlotDay{
+						{
+							Date: &date.Date{
+								Year:  2021,
+								Month: 8,
+								Day:   1,
+							},
+							Slots: []*bookingv1.BookingSlot{
+								{
+									Date: &date.Date{
+										Year:  2021,
+										Month: 8,
+										Day:   1,
+									},
+									StartTime: 12,
+									EndTime:   18,
+								},
+							},
+						},
+						{
+							Date: &date.Date{
+								Year:  2021,
+								Month: 8,
+								Day:   2,
+							},
+							Slots: []*bookingv1.BookingSlot{
+								{
+									Date: &date.Date{
+										Year:  2021,
+										Month: 8,
+										Day:   2,
+									},
+									StartTime: 12,
+									EndTime:   18,
+								},
+							},
+						},
+						{
+							Date: &date.Date{
+								Year:  2021,
+								Month: 8,
+								Day:   3,
+							},
+							Slots: []*bookingv1.BookingSlot{
+								{
+									Date: &date.Date{
+										Year:  2021,
+										Month: 8,
+										Day:   3,
+									},
+									StartTime: 12,
+									EndTime:   18,
+								},
+							},
+						},
+						{
+							Date: &date.Date{
+								Year:  2021,
+								Month: 8,
+								Day:   4,
+							},
+							Slots: []*bookingv1.BookingSlot{
+								{
+									Date: &date.Date{
+										Year:  2021,
+										Month: 8,
+										Day:   4,
+									},
+									StartTime: 12,
+									EndTime:   18,
+								},
+							},
+						},
+					},
 				},
 			},
 		},
@@ -1220,6 +1441,129 @@ func Test_GetAvailableSlot(t *testing.T) {
 				err: status.Errorf(codes.PermissionDenied, "user does not have access to this action, %s", api.ErrUserUnauthorised),
 			},
 		},
+		{
+			description: "should pass the slot search filter to the domain",
+			input: inputParams{
+				req: &bookingv1.GetAvailableSlotsRequest{
+					AccountId: "account-id-1",
+					From: &date.Date{
+						Year:  2021,
+						Month: 8,
+						Day:   1,
+					},
+					To: &date.Date{
+						Year:  2021,
+						Month: 8,
+						Day:   31,
+					},
+					DaysOfWeek: []dayofweek.DayOfWeek{dayofweek.DayOfWeek_MONDAY, dayofweek.DayOfWeek_SUNDAY},
+					TimeWindow: bookingv1.SlotTimeWindow_SLOT_TIME_WINDOW_AM,
+					Limit:      1,
+				},
+			},
+			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, _ *mocks.MockPublisher, mAuth *mocks.MockAuth) {
+
+				mAuth.EXPECT().Authorize(ctx, &auth.PolicyParams{
+					Action:     "get",
+					Resource:   "uw.energy.v1.account.smart-meter-booking",
+					ResourceID: "account-id-1",
+				}).Return(true, nil)
+
+				params := domain.GetAvailableSlotsParams{
+					AccountID: "account-id-1",
+					From: &date.Date{
+						Year:  2021,
+						Month: 8,
+						Day:   1,
+					},
+					To: &date.Date{
+						Year:  2021,
+						Month: 8,
+						Day:   31,
+					},
+					Filter: domain.SlotFilter{
+						Weekdays: []time.Weekday{time.Monday, time.Sunday},
+						Window:   domain.SlotWindowAM,
+						Limit:    1,
+					},
+				}
+
+				bkDomain.EXPECT().GetAvailableSlots(ctx, params).Return(domain.GetAvailableSlotsResponse{
+					Slots: []models.BookingSlot{
+						{
+							Date:      time.Date(2021, time.August, 1, 0, 0, 0, 0, time.UTC),
+							StartTime: 8,
+							EndTime:   12,
+						},
+					},
+				}, nil)
+			},
+			output: outputParams{
+				res: &bookingv1.GetAvailableSlotsResponse{
+					Slots: []*bookingv1.BookingSlot{
+						{
+							Date: &date.Date{
+								Year:  2021,
+								Month: 8,
+								Day:   1,
+							},
+							StartTime: 8,
+							EndTime:   12,
+						},
+					},
+					Days: []*bookingv1.SlotDay{
+						{
+							Date: &date.Date{
+								Year:  2021,
+								Month: 8,
+								Day:   1,
+							},
+							Slots: []*bookingv1.BookingSlot{
+								{
+									Date: &date.Date{
+										Year:  2021,
+										Month: 8,
+										Day:   1,
+									},
+									StartTime: 8,
+									EndTime:   12,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "should fail to get available slots when date From is after date To",
+			input: inputParams{
+				req: &bookingv1.GetAvailableSlotsRequest{
+					AccountId: "account-id-1",
+					From: &date.Date{
+						Year:  2021,
+						Month: 8,
+						Day:   31,
+					},
+					To: &date.Date{
+						Year:  2021,
+						Month: 8,
+						Day:   1,
+					},
+				},
+			},
+			setup: func(ctx context.Context, _ *mocks.MockBookingDomain, _ *mocks.MockPublisher, mAuth *mocks.MockAuth) {
+
+				mAuth.EXPECT().Authorize(ctx, &auth.PolicyParams{
+					Action:     "get",
+					Resource:   "uw.energy.v1.account.smart-meter-booking",
+					ResourceID: "account-id-1",
+				}).Return(true, nil)
+			},
+			output: outputParams{
+				res: nil,
+				err: status.Error(codes.InvalidArgument, "date From is after date To"),
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1234,7 +1578,7 @@ func Test_GetAvailableSlot(t *testing.T) {
 				}
 			}
 
-			if diff := cmp.Diff(expected, tc.output.res, cmpopts.IgnoreUnexported(date.Date{}, bookingv1.GetAvailableSlotsResponse{}, bookingv1.Booking{}, addressv1.Address{}, addressv1.Address_PAF{},
+			if diff := cmp.Diff(expected, tc.output.res, cmpopts.IgnoreUnexported(date.Date{}, bookingv1.GetAvailableSlotsResponse{}, bookingv1.SlotDay{}, bookingv1.Booking{}, addressv1.Address{}, addressv1.Address_PAF{},
 				bookingv1.ContactDetails{}, bookingv1.BookingSlot{}, bookingv1.VulnerabilityDetails{})); diff != "" {
 				t.Fatal(diff)
 			}
@@ -3248,7 +3592,7 @@ func Test_RescheduleBooking(t *testing.T) {
 	}
 }
 
-func Test_GetAvailableSlotsPointOfSale(t *testing.T) {
+func Test_RescheduleBooking_ReschedulePolicy(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
 	ctx := context.Background()
@@ -3256,33 +3600,564 @@ func Test_GetAvailableSlotsPointOfSale(t *testing.T) {
 	defer ctrl.Finish()
 
 	bookingDomain := mocks.NewMockBookingDomain(ctrl)
-	bookingPublisher := mocks.NewMockPublisher(ctrl)
-	commPublisher := mocks.NewMockPublisher(ctrl)
-	commReschedulePublisher := mocks.NewMockPublisher(ctrl)
 	mockAuth := mocks.NewMockAuth(ctrl)
 
-	myAPIHandler := api.New(bookingDomain, nil, bookingPublisher, commPublisher, commReschedulePublisher, nil, mockAuth, false)
+	myAPIHandler := api.New(bookingDomain, nil, nil, nil, nil, nil, mockAuth, false)
 
-	type inputParams struct {
-		req *bookingv1.GetAvailableSlotsPointOfSaleRequest
+	req := &bookingv1.RescheduleBookingRequest{
+		AccountId: "account-id-1",
+		BookingId: "booking-id-1",
+		Slot: &bookingv1.BookingSlot{
+			Date: &date.Date{
+				Year:  2020,
+				Month: 1,
+				Day:   12,
+			},
+			StartTime: 10,
+			EndTime:   20,
+		},
+		Platform:             bookingv1.Platform_PLATFORM_APP,
+		VulnerabilityDetails: &bookingv1.VulnerabilityDetails{},
+		ContactDetails:       &bookingv1.ContactDetails{},
 	}
 
-	type outputParams struct {
-		res *bookingv1.GetAvailableSlotsPointOfSaleResponse
-		err error
+	testCases := []struct {
+		description    string
+		domainErr      error
+		expectedReason string
+	}{
+		{
+			description:    "should reject a reschedule within the minimum notice",
+			domainErr:      domain.ErrRescheduleNoticeTooShort,
+			expectedReason: api.ReasonRescheduleNoticeTooShort,
+		},
+		{
+			description:    "should reject a reschedule over the maximum number of reschedules",
+			domainErr:      domain.ErrRescheduleLimitReached,
+			expectedReason: api.ReasonRescheduleLimitReached,
+		},
 	}
 
-	type testSetup struct {
-		description string
-		setup       func(ctx context.Context, domain *mocks.MockBookingDomain, publisher *mocks.MockPublisher, mAuth *mocks.MockAuth)
-		input       inputParams
-		output      outputParams
-	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			mockAuth.EXPECT().Authorize(ctx, &auth.PolicyParams{
+				Action:     "update",
+				Resource:   "uw.energy.v1.account.smart-meter-booking",
+				ResourceID: "account-id-1",
+			}).Return(true, nil)
+			bookingDomain.EXPECT().RescheduleBooking(ctx, gomock.Any()).Return(domain.RescheduleBookingResponse{}, tc.domainErr)
+
+			_, err := myAPIHandler.RescheduleBooking(ctx, req)
+
+			st, ok := status.FromError(err)
+			if !ok || st.Code() != codes.FailedPrecondition {
+				t.Fatalf("expected a failed precondition error, got %v", err)
+			}
 
-	testCases := []testSetup{
-		{
-			description: "should get the account details by account id",
-			input: inputParams{
+			var reasons []string
+			for _, detail := range st.Details() {
+				if info, ok := detail.(*errdetails.ErrorInfo); ok {
+					reasons = append(reasons, info.GetReason())
+				}
+			}
+			if diff := cmp.Diff([]string{tc.expectedReason}, reasons); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func Test_CancelBooking(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	defer ctrl.Finish()
+
+	bookingDomain := mocks.NewMockBookingDomain(ctrl)
+	bookingPublisher := mocks.NewMockPublisher(ctrl)
+	mockAuth := mocks.NewMockAuth(ctrl)
+
+	myAPIHandler := api.New(bookingDomain, nil, bookingPublisher, nil, nil, nil, mockAuth, false)
+
+	type inputParams struct {
+		req *bookingv1.CancelBookingRequest
+	}
+
+	type outputParams struct {
+		res *bookingv1.CancelBookingResponse
+		err error
+	}
+
+	type testSetup struct {
+		description string
+		setup       func(ctx context.Context, domain *mocks.MockBookingDomain, publisher *mocks.MockPublisher, mAuth *mocks.MockAuth)
+		input       inputParams
+		output      outputParams
+	}
+
+	params := domain.CancelBookingParams{
+		AccountID: "account-id-1",
+		BookingID: "booking-id-1",
+		Source:    bookingv1.BookingSource_BOOKING_SOURCE_PLATFORM_APP,
+		Reason:    "customer request",
+	}
+
+	testCases := []testSetup{
+		{
+			description: "should cancel a booking",
+			input: inputParams{
+				req: &bookingv1.CancelBookingRequest{
+					AccountId: "account-id-1",
+					BookingId: "booking-id-1",
+					Platform:  bookingv1.Platform_PLATFORM_APP,
+					Reason:    "customer request",
+				},
+			},
+			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, publisher *mocks.MockPublisher, mAuth *mocks.MockAuth) {
+
+				mAuth.EXPECT().Authorize(ctx, &auth.PolicyParams{
+					Action:     "update",
+					Resource:   "uw.energy.v1.account.smart-meter-booking",
+					ResourceID: "account-id-1",
+				}).Return(true, nil)
+
+				event := &bookingv1.BookingCancelledEvent{
+					BookingId:     "booking-id-1",
+					AccountId:     "account-id-1",
+					OccupancyId:   "occupancy-id-1",
+					Status:        bookingv1.BookingStatus_BOOKING_STATUS_CANCELLED,
+					BookingSource: bookingv1.BookingSource_BOOKING_SOURCE_PLATFORM_APP,
+					Reason:        "customer request",
+				}
+
+				bkDomain.EXPECT().CancelBooking(ctx, params).Return(domain.CancelBookingResponse{
+					Event: event,
+				}, nil)
+
+				publisher.EXPECT().Sink(ctx, event, gomock.Any()).Return(nil)
+			},
+			output: outputParams{
+				res: &bookingv1.CancelBookingResponse{
+					BookingId: "booking-id-1",
+				},
+				err: nil,
+			},
+		},
+		{
+			description: "should fail to cancel a booking when the cancelled event cannot be written",
+			input: inputParams{
+				req: &bookingv1.CancelBookingRequest{
+					AccountId: "account-id-1",
+					BookingId: "booking-id-1",
+					Platform:  bookingv1.Platform_PLATFORM_APP,
+					Reason:    "customer request",
+				},
+			},
+			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, publisher *mocks.MockPublisher, mAuth *mocks.MockAuth) {
+
+				mAuth.EXPECT().Authorize(ctx, &auth.PolicyParams{
+					Action:     "update",
+					Resource:   "uw.energy.v1.account.smart-meter-booking",
+					ResourceID: "account-id-1",
+				}).Return(true, nil)
+
+				event := &bookingv1.BookingCancelledEvent{
+					BookingId: "booking-id-1",
+					AccountId: "account-id-1",
+				}
+
+				bkDomain.EXPECT().CancelBooking(ctx, params).Return(domain.CancelBookingResponse{
+					Event: event,
+				}, nil)
+
+				publisher.EXPECT().Sink(ctx, event, gomock.Any()).Return(errOops)
+			},
+			output: outputParams{
+				res: nil,
+				err: status.Errorf(codes.Internal, "failed to sink cancel booking event of booking booking-id-1, %s", errOops),
+			},
+		},
+		{
+			description: "should fail to cancel a booking when no booking id is provided",
+			input: inputParams{
+				req: &bookingv1.CancelBookingRequest{
+					AccountId: "account-id-1",
+					Platform:  bookingv1.Platform_PLATFORM_APP,
+				},
+			},
+			setup: func(ctx context.Context, _ *mocks.MockBookingDomain, _ *mocks.MockPublisher, mAuth *mocks.MockAuth) {
+
+				mAuth.EXPECT().Authorize(ctx, &auth.PolicyParams{
+					Action:     "update",
+					Resource:   "uw.energy.v1.account.smart-meter-booking",
+					ResourceID: "account-id-1",
+				}).Return(true, nil)
+			},
+			output: outputParams{
+				res: nil,
+				err: status.Error(codes.InvalidArgument, "no booking id provided"),
+			},
+		},
+		{
+			description: "should fail to cancel a booking that belongs to another account",
+			input: inputParams{
+				req: &bookingv1.CancelBookingRequest{
+					AccountId: "account-id-1",
+					BookingId: "booking-id-1",
+					Platform:  bookingv1.Platform_PLATFORM_APP,
+					Reason:    "customer request",
+				},
+			},
+			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, _ *mocks.MockPublisher, mAuth *mocks.MockAuth) {
+
+				mAuth.EXPECT().Authorize(ctx, &auth.PolicyParams{
+					Action:     "update",
+					Resource:   "uw.energy.v1.account.smart-meter-booking",
+					ResourceID: "account-id-1",
+				}).Return(true, nil)
+
+				bkDomain.EXPECT().CancelBooking(ctx, params).Return(domain.CancelBookingResponse{}, domain.ErrBookingAccountMismatch)
+			},
+			output: outputParams{
+				res: nil,
+				err: status.Errorf(codes.PermissionDenied, "failed to cancel booking, %s", domain.ErrBookingAccountMismatch),
+			},
+		},
+		{
+			description: "should fail to cancel a booking that is already cancelled",
+			input: inputParams{
+				req: &bookingv1.CancelBookingRequest{
+					AccountId: "account-id-1",
+					BookingId: "booking-id-1",
+					Platform:  bookingv1.Platform_PLATFORM_APP,
+					Reason:    "customer request",
+				},
+			},
+			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, _ *mocks.MockPublisher, mAuth *mocks.MockAuth) {
+
+				mAuth.EXPECT().Authorize(ctx, &auth.PolicyParams{
+					Action:     "update",
+					Resource:   "uw.energy.v1.account.smart-meter-booking",
+					ResourceID: "account-id-1",
+				}).Return(true, nil)
+
+				bkDomain.EXPECT().CancelBooking(ctx, params).Return(domain.CancelBookingResponse{}, domain.ErrBookingAlreadyCancelled)
+			},
+			output: outputParams{
+				res: nil,
+				err: status.Errorf(codes.FailedPrecondition, "failed to cancel booking, %s", domain.ErrBookingAlreadyCancelled),
+			},
+		},
+		{
+			description: "should fail to cancel a booking when lowribeck returns out of range",
+			input: inputParams{
+				req: &bookingv1.CancelBookingRequest{
+					AccountId: "account-id-1",
+					BookingId: "booking-id-1",
+					Platform:  bookingv1.Platform_PLATFORM_APP,
+					Reason:    "customer request",
+				},
+			},
+			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, _ *mocks.MockPublisher, mAuth *mocks.MockAuth) {
+
+				mAuth.EXPECT().Authorize(ctx, &auth.PolicyParams{
+					Action:     "update",
+					Resource:   "uw.energy.v1.account.smart-meter-booking",
+					ResourceID: "account-id-1",
+				}).Return(true, nil)
+
+				bkDomain.EXPECT().CancelBooking(ctx, params).Return(domain.CancelBookingResponse{}, gateway.ErrOutOfRange)
+			},
+			output: outputParams{
+				res: nil,
+				err: status.Errorf(codes.OutOfRange, "failed to cancel booking, %s", gateway.ErrOutOfRange),
+			},
+		},
+		{
+			description: "should fail to cancel a booking because user is unauthorised",
+			input: inputParams{
+				req: &bookingv1.CancelBookingRequest{
+					AccountId: "account-id-1",
+					BookingId: "booking-id-1",
+					Platform:  bookingv1.Platform_PLATFORM_APP,
+				},
+			},
+			setup: func(ctx context.Context, _ *mocks.MockBookingDomain, _ *mocks.MockPublisher, mAuth *mocks.MockAuth) {
+
+				mAuth.EXPECT().Authorize(ctx, &auth.PolicyParams{
+					Action:     "update",
+					Resource:   "uw.energy.v1.account.smart-meter-booking",
+					ResourceID: "account-id-1",
+				}).Return(false, nil)
+			},
+			output: outputParams{
+				res: nil,
+				err: status.Errorf(codes.PermissionDenied, "user does not have access to this action, %s", api.ErrUserUnauthorised),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+
+			tc.setup(ctx, bookingDomain, bookingPublisher, mockAuth)
+
+			expected, err := myAPIHandler.CancelBooking(ctx, tc.input.req)
+			if tc.output.err != nil {
+				if diff := cmp.Diff(err.Error(), tc.output.err.Error()); diff != "" {
+					t.Fatal(diff)
+				}
+			}
+
+			if diff := cmp.Diff(expected, tc.output.res, cmpopts.IgnoreUnexported(bookingv1.CancelBookingResponse{})); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func Test_UpdateBookingContactDetails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	defer ctrl.Finish()
+
+	bookingDomain := mocks.NewMockBookingDomain(ctrl)
+	bookingPublisher := mocks.NewMockPublisher(ctrl)
+	mockAuth := mocks.NewMockAuth(ctrl)
+
+	myAPIHandler := api.New(bookingDomain, nil, bookingPublisher, nil, nil, nil, mockAuth, false)
+
+	type inputParams struct {
+		req *bookingv1.UpdateBookingContactDetailsRequest
+	}
+
+	type outputParams struct {
+		res *bookingv1.UpdateBookingContactDetailsResponse
+		err error
+	}
+
+	type testSetup struct {
+		description string
+		setup       func(ctx context.Context, domain *mocks.MockBookingDomain, publisher *mocks.MockPublisher, mAuth *mocks.MockAuth)
+		input       inputParams
+		output      outputParams
+	}
+
+	vulnerabilityDetails := &bookingv1.VulnerabilityDetails{
+		Vulnerabilities: []bookingv1.Vulnerability{
+			bookingv1.Vulnerability_VULNERABILITY_FOREIGN_LANGUAGE_ONLY,
+		},
+		Other: "Bad Knee",
+	}
+
+	contactDetails := &bookingv1.ContactDetails{
+		Title:     "Mr",
+		FirstName: "John",
+		LastName:  "Doe",
+		Phone:     "555-0145",
+		Email:     "jdoe@example.com",
+	}
+
+	req := &bookingv1.UpdateBookingContactDetailsRequest{
+		AccountId:            "account-id-1",
+		BookingId:            "booking-id-1",
+		Platform:             bookingv1.Platform_PLATFORM_APP,
+		ContactDetails:       contactDetails,
+		VulnerabilityDetails: vulnerabilityDetails,
+	}
+
+	params := domain.UpdateBookingContactDetailsParams{
+		AccountID: "account-id-1",
+		BookingID: "booking-id-1",
+		Source:    bookingv1.BookingSource_BOOKING_SOURCE_PLATFORM_APP,
+		ContactDetails: models.AccountDetails{
+			Title:     "Mr",
+			FirstName: "John",
+			LastName:  "Doe",
+			Email:     "jdoe@example.com",
+			Mobile:    "555-0145",
+		},
+		VulnerabilityDetails: vulnerabilityDetails,
+	}
+
+	authorised := func(ctx context.Context, mAuth *mocks.MockAuth) {
+		mAuth.EXPECT().Authorize(ctx, &auth.PolicyParams{
+			Action:     "update",
+			Resource:   "uw.energy.v1.account.smart-meter-booking",
+			ResourceID: "account-id-1",
+		}).Return(true, nil)
+	}
+
+	testCases := []testSetup{
+		{
+			description: "should update the contact details of a booking",
+			input: inputParams{
+				req: req,
+			},
+			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, publisher *mocks.MockPublisher, mAuth *mocks.MockAuth) {
+				authorised(ctx, mAuth)
+
+				event := &bookingv1.BookingContactDetailsUpdatedEvent{
+					BookingId:            "booking-id-1",
+					AccountId:            "account-id-1",
+					OccupancyId:          "occupancy-id-1",
+					ContactDetails:       contactDetails,
+					VulnerabilityDetails: vulnerabilityDetails,
+					BookingSource:        bookingv1.BookingSource_BOOKING_SOURCE_PLATFORM_APP,
+				}
+
+				bkDomain.EXPECT().UpdateBookingContactDetails(ctx, params).Return(domain.UpdateBookingContactDetailsResponse{
+					Event: event,
+				}, nil)
+
+				publisher.EXPECT().Sink(ctx, event, gomock.Any()).Return(nil)
+			},
+			output: outputParams{
+				res: &bookingv1.UpdateBookingContactDetailsResponse{
+					BookingId: "booking-id-1",
+				},
+				err: nil,
+			},
+		},
+		{
+			description: "should fail to update the contact details when none are provided",
+			input: inputParams{
+				req: &bookingv1.UpdateBookingContactDetailsRequest{
+					AccountId:            "account-id-1",
+					BookingId:            "booking-id-1",
+					Platform:             bookingv1.Platform_PLATFORM_APP,
+					VulnerabilityDetails: vulnerabilityDetails,
+				},
+			},
+			setup: func(ctx context.Context, _ *mocks.MockBookingDomain, _ *mocks.MockPublisher, mAuth *mocks.MockAuth) {
+				authorised(ctx, mAuth)
+			},
+			output: outputParams{
+				res: nil,
+				err: status.Error(codes.InvalidArgument, "no contact details provided"),
+			},
+		},
+		{
+			description: "should fail to update the contact details of a booking that belongs to another account",
+			input: inputParams{
+				req: req,
+			},
+			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, _ *mocks.MockPublisher, mAuth *mocks.MockAuth) {
+				authorised(ctx, mAuth)
+
+				bkDomain.EXPECT().UpdateBookingContactDetails(ctx, params).Return(domain.UpdateBookingContactDetailsResponse{}, domain.ErrBookingAccountMismatch)
+			},
+			output: outputParams{
+				res: nil,
+				err: status.Errorf(codes.PermissionDenied, "failed to update booking contact details, %s", domain.ErrBookingAccountMismatch),
+			},
+		},
+		{
+			description: "should fail to update the contact details when lowribeck does not find the booking",
+			input: inputParams{
+				req: req,
+			},
+			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, _ *mocks.MockPublisher, mAuth *mocks.MockAuth) {
+				authorised(ctx, mAuth)
+
+				bkDomain.EXPECT().UpdateBookingContactDetails(ctx, params).Return(domain.UpdateBookingContactDetailsResponse{}, gateway.ErrNotFound)
+			},
+			output: outputParams{
+				res: nil,
+				err: status.Errorf(codes.NotFound, "failed to update booking contact details, %s", gateway.ErrNotFound),
+			},
+		},
+		{
+			description: "should fail to update the contact details when lowribeck does not return success",
+			input: inputParams{
+				req: req,
+			},
+			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, _ *mocks.MockPublisher, mAuth *mocks.MockAuth) {
+				authorised(ctx, mAuth)
+
+				bkDomain.EXPECT().UpdateBookingContactDetails(ctx, params).Return(domain.UpdateBookingContactDetailsResponse{}, domain.ErrUnsuccessfulContactDetailsUpdate)
+			},
+			output: outputParams{
+				res: nil,
+				err: status.Errorf(codes.Internal, "failed to update booking contact details, %s", domain.ErrUnsuccessfulContactDetailsUpdate),
+			},
+		},
+		{
+			description: "should fail to update the contact details because user is unauthorised",
+			input: inputParams{
+				req: req,
+			},
+			setup: func(ctx context.Context, _ *mocks.MockBookingDomain, _ *mocks.MockPublisher, mAuth *mocks.MockAuth) {
+				mAuth.EXPECT().Authorize(ctx, &auth.PolicyParams{
+					Action:     "update",
+					Resource:   "uw.energy.v1.account.smart-meter-booking",
+					ResourceID: "account-id-1",
+				}).Return(false, nil)
+			},
+			output: outputParams{
+				res: nil,
+				err: status.Errorf(codes.PermissionDenied, "user does not have access to this action, %s", api.ErrUserUnauthorised),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+
+			tc.setup(ctx, bookingDomain, bookingPublisher, mockAuth)
+
+			expected, err := myAPIHandler.UpdateBookingContactDetails(ctx, tc.input.req)
+			if tc.output.err != nil {
+				if diff := cmp.Diff(err.Error(), tc.output.err.Error()); diff != "" {
+					t.Fatal(diff)
+				}
+			}
+
+			if diff := cmp.Diff(expected, tc.output.res, cmpopts.IgnoreUnexported(bookingv1.UpdateBookingContactDetailsResponse{})); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func Test_GetAvailableSlotsPointOfSale(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	defer ctrl.Finish()
+
+	bookingDomain := mocks.NewMockBookingDomain(ctrl)
+	bookingPublisher := mocks.NewMockPublisher(ctrl)
+	commPublisher := mocks.NewMockPublisher(ctrl)
+	commReschedulePublisher := mocks.NewMockPublisher(ctrl)
+	mockAuth := mocks.NewMockAuth(ctrl)
+
+	myAPIHandler := api.New(bookingDomain, nil, bookingPublisher, commPublisher, commReschedulePublisher, nil, mockAuth, false)
+
+	type inputParams struct {
+		req *bookingv1.GetAvailableSlotsPointOfSaleRequest
+	}
+
+	type outputParams struct {
+		res *bookingv1.GetAvailableSlotsPointOfSaleResponse
+		err error
+	}
+
+	type testSetup struct {
+		description string
+		setup       func(ctx context.Context, domain *mocks.MockBookingDomain, publisher *mocks.MockPublisher, mAuth *mocks.MockAuth)
+		input       inputParams
+		output      outputParams
+	}
+
+	testCases := []testSetup{
+		{
+			description: "should get the account details by account id",
+			input: inputParams{
 				req: &bookingv1.GetAvailableSlotsPointOfSaleRequest{
 					AccountNumber: "account-number-1",
 					From: &date.Date{
@@ -3363,8 +4238,64 @@ func Test_GetAvailableSlotsPointOfSale(t *testing.T) {
 								Month: 8,
 								Day:   2,
 							},
-							StartTime: 12,
-							EndTime:   18,
+							StartTime: 12,
+							EndTime:   18,
+						},
+						{
+							Date: &date.Date{
+								Year:  2021,
+								Month: 8,
+								Day:   3,
+							},
+							StartTime: 12,
+							EndTime:   18,
+						},
+						{
+							Date: &date.Date{
+								Year:  2021,
+								Month: 8,
+								Day:   4,
+							},
+							StartTime: 12,
+							EndTime:   18,
+						},
+					},
+					Days: []*bookingv1.SlotDay{
+						{
+							Date: &date.Date{
+								Year:  2021,
+								Month: 8,
+								Day:   1,
+							},
+							Slots: []*bookingv1.BookingSlot{
+								{
+									Date: &date.Date{
+										Year:  2021,
+										Month: 8,
+										Day:   1,
+									},
+									StartTime: 12,
+									EndTime:   18,
+								},
+							},
+						},
+						{
+							Date: &date.Date{
+								Year:  2021,
+								Month: 8,
+								Day:   2,
+							},
+							Slots: []*bookingv1.BookingSlot{
+								{
+									Date: &date.Date{
+										Year:  2021,
+										Month: 8,
+										Day:   2,
+									},
+									StartTime: 12,
+									EndTime:   18,
+								},
+							},
 						},
 						{
 							Date: &date.Date{
@@ -3372,8 +4303,17 @@ func Test_GetAvailableSlotsPointOfSale(t *testing.T) {
 								Month: 8,
 								Day:   3,
 							},
-							StartTime: 12,
-							EndTime:   18,
+							Slots: []*bookingv1.BookingSlot{
+								{
+									Date: &date.Date{
+										Year:  2021,
+										Month: 8,
+										Day:   3,
+									},
+									StartTime: 12,
+									EndTime:   18,
+								},
+							},
 						},
 						{
 							Date: &date.Date{
@@ -3381,8 +4321,17 @@ func Test_GetAvailableSlotsPointOfSale(t *testing.T) {
 								Month: 8,
 								Day:   4,
 							},
-							StartTime: 12,
-							EndTime:   18,
+							Slots: []*bookingv1.BookingSlot{
+								{
+									Date: &date.Date{
+										Year:  2021,
+										Month: 8,
+										Day:   4,
+									},
+									StartTime: 12,
+									EndTime:   18,
+								},
+							},
 						},
 					},
 				},
@@ -3832,7 +4781,7 @@ func Test_GetAvailableSlotsPointOfSale(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			if diff := cmp.Diff(expected, tc.output.res, cmpopts.IgnoreUnexported(date.Date{}, bookingv1.GetAvailableSlotsPointOfSaleResponse{}, bookingv1.Booking{}, addressv1.Address{}, addressv1.Address_PAF{},
+			if diff := cmp.Diff(expected, tc.output.res, cmpopts.IgnoreUnexported(date.Date{}, bookingv1.GetAvailableSlotsPointOfSaleResponse{}, bookingv1.SlotDay{}, bookingv1.Booking{}, addressv1.Address{}, addressv1.Address_PAF{},
 				bookingv1.ContactDetails{}, bookingv1.BookingSlot{}, bookingv1.VulnerabilityDetails{})); diff != "" {
 				t.Fatal(diff)
 			}
@@ -5165,8 +6114,8 @@ func Test_GetClickLinkPointOfSaleJourney(t *testing.T) {
 			input: inputParams{
 				req: &bookingv1.GetClickLinkPointOfSaleJourneyRequest{
 					AccountNumber:         "account-number-1",
-					Mpan:                  "mpan-1",
-					Mprn:                  "mprn-1",
+					Mpan:                  "2199996734008",
+					Mprn:                  "2724968810",
 					ElectricityTariffType: bookingv1.TariffType_TARIFF_TYPE_CREDIT,
 					GasTariffType:         bookingv1.TariffType_TARIFF_TYPE_CREDIT,
 					ContactDetails: &bookingv1.ContactDetails{
@@ -5187,7 +6136,7 @@ func Test_GetClickLinkPointOfSaleJourney(t *testing.T) {
 							DoubleDependentLocality: "ddl",
 							Organisation:            "o",
 							PostTown:                "pt",
-							Postcode:                "E2 1Z",
+							Postcode:                "E2 1ZZ",
 							SubBuilding:             "sb",
 							Thoroughfare:            "tf",
 						},
@@ -5224,17 +6173,17 @@ func Test_GetClickLinkPointOfSaleJourney(t *testing.T) {
 								DoubleDependentLocality: "ddl",
 								Organisation:            "o",
 								PostTown:                "pt",
-								Postcode:                "E2 1Z",
+								Postcode:                "E2 1ZZ",
 								SubBuilding:             "sb",
 								Thoroughfare:            "tf",
 							},
 						},
 						ElecOrderSupplies: models.OrderSupply{
-							MPXN:       "mpan-1",
+							MPXN:       "2199996734008",
 							TariffType: bookingv1.TariffType_TARIFF_TYPE_CREDIT,
 						},
 						GasOrderSupplies: models.OrderSupply{
-							MPXN:       "mprn-1",
+							MPXN:       "2724968810",
 							TariffType: bookingv1.TariffType_TARIFF_TYPE_CREDIT,
 						},
 					},
@@ -5256,8 +6205,8 @@ func Test_GetClickLinkPointOfSaleJourney(t *testing.T) {
 			input: inputParams{
 				req: &bookingv1.GetClickLinkPointOfSaleJourneyRequest{
 					AccountNumber:         "account-number-1",
-					Mpan:                  "mpan-1",
-					Mprn:                  "mprn-1",
+					Mpan:                  "2199996734008",
+					Mprn:                  "2724968810",
 					ElectricityTariffType: bookingv1.TariffType_TARIFF_TYPE_CREDIT,
 					GasTariffType:         bookingv1.TariffType_TARIFF_TYPE_CREDIT,
 					ContactDetails:        nil,
@@ -5272,7 +6221,7 @@ func Test_GetClickLinkPointOfSaleJourney(t *testing.T) {
 							DoubleDependentLocality: "ddl",
 							Organisation:            "o",
 							PostTown:                "pt",
-							Postcode:                "E2 1Z",
+							Postcode:                "E2 1ZZ",
 							SubBuilding:             "sb",
 							Thoroughfare:            "tf",
 						},
@@ -5291,8 +6240,8 @@ func Test_GetClickLinkPointOfSaleJourney(t *testing.T) {
 			input: inputParams{
 				req: &bookingv1.GetClickLinkPointOfSaleJourneyRequest{
 					AccountNumber:         "account-number-1",
-					Mpan:                  "mpan-1",
-					Mprn:                  "mprn-1",
+					Mpan:                  "2199996734008",
+					Mprn:                  "2724968810",
 					ElectricityTariffType: bookingv1.TariffType_TARIFF_TYPE_CREDIT,
 					GasTariffType:         bookingv1.TariffType_TARIFF_TYPE_CREDIT,
 					ContactDetails: &bookingv1.ContactDetails{
@@ -5317,8 +6266,8 @@ func Test_GetClickLinkPointOfSaleJourney(t *testing.T) {
 			input: inputParams{
 				req: &bookingv1.GetClickLinkPointOfSaleJourneyRequest{
 					AccountNumber:         "account-number-1",
-					Mpan:                  "mpan-1",
-					Mprn:                  "mprn-1",
+					Mpan:                  "2199996734008",
+					Mprn:                  "2724968810",
 					ElectricityTariffType: bookingv1.TariffType_TARIFF_TYPE_CREDIT,
 					GasTariffType:         bookingv1.TariffType_TARIFF_TYPE_CREDIT,
 					ContactDetails: &bookingv1.ContactDetails{
@@ -5346,8 +6295,8 @@ func Test_GetClickLinkPointOfSaleJourney(t *testing.T) {
 			input: inputParams{
 				req: &bookingv1.GetClickLinkPointOfSaleJourneyRequest{
 					AccountNumber:         "account-number-1",
-					Mpan:                  "mpan-1",
-					Mprn:                  "mprn-1",
+					Mpan:                  "2199996734008",
+					Mprn:                  "2724968810",
 					ElectricityTariffType: bookingv1.TariffType_TARIFF_TYPE_CREDIT,
 					GasTariffType:         bookingv1.TariffType_TARIFF_TYPE_CREDIT,
 					ContactDetails: &bookingv1.ContactDetails{
@@ -5387,8 +6336,8 @@ func Test_GetClickLinkPointOfSaleJourney(t *testing.T) {
 			input: inputParams{
 				req: &bookingv1.GetClickLinkPointOfSaleJourneyRequest{
 					AccountNumber:         "",
-					Mpan:                  "mpan-1",
-					Mprn:                  "mprn-1",
+					Mpan:                  "2199996734008",
+					Mprn:                  "2724968810",
 					ElectricityTariffType: bookingv1.TariffType_TARIFF_TYPE_CREDIT,
 					GasTariffType:         bookingv1.TariffType_TARIFF_TYPE_CREDIT,
 					ContactDetails: &bookingv1.ContactDetails{
@@ -5409,7 +6358,7 @@ func Test_GetClickLinkPointOfSaleJourney(t *testing.T) {
 							DoubleDependentLocality: "ddl",
 							Organisation:            "o",
 							PostTown:                "pt",
-							Postcode:                "E2 1Z",
+							Postcode:                "E2 1ZZ",
 							SubBuilding:             "sb",
 							Thoroughfare:            "tf",
 						},
@@ -5429,7 +6378,7 @@ func Test_GetClickLinkPointOfSaleJourney(t *testing.T) {
 				req: &bookingv1.GetClickLinkPointOfSaleJourneyRequest{
 					AccountNumber:         "account-number-1",
 					Mpan:                  "",
-					Mprn:                  "mprn-1",
+					Mprn:                  "2724968810",
 					ElectricityTariffType: bookingv1.TariffType_TARIFF_TYPE_CREDIT,
 					GasTariffType:         bookingv1.TariffType_TARIFF_TYPE_CREDIT,
 					ContactDetails: &bookingv1.ContactDetails{
@@ -5450,7 +6399,7 @@ func Test_GetClickLinkPointOfSaleJourney(t *testing.T) {
 							DoubleDependentLocality: "ddl",
 							Organisation:            "o",
 							PostTown:                "pt",
-							Postcode:                "E2 1Z",
+							Postcode:                "E2 1ZZ",
 							SubBuilding:             "sb",
 							Thoroughfare:            "tf",
 						},
@@ -5469,8 +6418,8 @@ func Test_GetClickLinkPointOfSaleJourney(t *testing.T) {
 			input: inputParams{
 				req: &bookingv1.GetClickLinkPointOfSaleJourneyRequest{
 					AccountNumber:         "account-number-1",
-					Mpan:                  "mpan-1",
-					Mprn:                  "mprn-1",
+					Mpan:                  "2199996734008",
+					Mprn:                  "2724968810",
 					ElectricityTariffType: bookingv1.TariffType_TARIFF_TYPE_UNKNOWN,
 					GasTariffType:         bookingv1.TariffType_TARIFF_TYPE_CREDIT,
 					ContactDetails: &bookingv1.ContactDetails{
@@ -5491,7 +6440,7 @@ func Test_GetClickLinkPointOfSaleJourney(t *testing.T) {
 							DoubleDependentLocality: "ddl",
 							Organisation:            "o",
 							PostTown:                "pt",
-							Postcode:                "E2 1Z",
+							Postcode:                "E2 1ZZ",
 							SubBuilding:             "sb",
 							Thoroughfare:            "tf",
 						},
@@ -5510,8 +6459,8 @@ func Test_GetClickLinkPointOfSaleJourney(t *testing.T) {
 			input: inputParams{
 				req: &bookingv1.GetClickLinkPointOfSaleJourneyRequest{
 					AccountNumber:         "account-number-1",
-					Mpan:                  "mpan-1",
-					Mprn:                  "mprn-1",
+					Mpan:                  "2199996734008",
+					Mprn:                  "2724968810",
 					ElectricityTariffType: bookingv1.TariffType_TARIFF_TYPE_CREDIT,
 					GasTariffType:         bookingv1.TariffType_TARIFF_TYPE_UNKNOWN,
 					ContactDetails: &bookingv1.ContactDetails{
@@ -5532,7 +6481,7 @@ func Test_GetClickLinkPointOfSaleJourney(t *testing.T) {
 							DoubleDependentLocality: "ddl",
 							Organisation:            "o",
 							PostTown:                "pt",
-							Postcode:                "E2 1Z",
+							Postcode:                "E2 1ZZ",
 							SubBuilding:             "sb",
 							Thoroughfare:            "tf",
 						},
@@ -5606,9 +6555,9 @@ func Test_GetEligibilityPointOfSaleJourney(t *testing.T) {
 			description: "should process an eligibility request for a candidate to a point of sale journey",
 			input: inputParams{
 				req: &bookingv1.GetEligibilityPointOfSaleJourneyRequest{
-					Mpan:     "mpan-1",
-					Mprn:     "mprn-1",
-					Postcode: "E2 1Z",
+					Mpan:     "2199996734008",
+					Mprn:     "2724968810",
+					Postcode: "E2 1ZZ",
 				},
 			},
 			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, mAuth *mocks.MockAuth) {
@@ -5620,12 +6569,12 @@ func Test_GetEligibilityPointOfSaleJourney(t *testing.T) {
 				}).Return(true, nil)
 
 				bkDomain.EXPECT().ProcessEligibility(ctx, domain.ProcessEligibilityParams{
-					Postcode: "E2 1Z",
+					Postcode: "E2 1ZZ",
 					ElecOrderSupplies: models.OrderSupply{
-						MPXN: "mpan-1",
+						MPXN: "2199996734008",
 					},
 					GasOrderSupplies: models.OrderSupply{
-						MPXN: "mprn-1",
+						MPXN: "2724968810",
 					},
 				}).Return(domain.ProcessEligibilityResult{
 					Eligible: true,
@@ -5638,12 +6587,52 @@ func Test_GetEligibilityPointOfSaleJourney(t *testing.T) {
 				err: nil,
 			},
 		},
+		{
+			description: "should return the ineligible reasons of both fuels",
+			input: inputParams{
+				req: &bookingv1.GetEligibilityPointOfSaleJourneyRequest{
+					Mpan:     "2199996734008",
+					Mprn:     "2724968810",
+					Postcode: "E2 1ZZ",
+				},
+			},
+			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, mAuth *mocks.MockAuth) {
+
+				mAuth.EXPECT().Authorize(ctx, &auth.PolicyParams{
+					Action:     "get",
+					Resource:   "uw.energy.v1.point-of-sale-smart-meter-booking",
+					ResourceID: "booking-api-server",
+				}).Return(true, nil)
+
+				bkDomain.EXPECT().ProcessEligibility(ctx, domain.ProcessEligibilityParams{
+					Postcode: "E2 1ZZ",
+					ElecOrderSupplies: models.OrderSupply{
+						MPXN: "2199996734008",
+					},
+					GasOrderSupplies: models.OrderSupply{
+						MPXN: "2724968810",
+					},
+				}).Return(domain.ProcessEligibilityResult{
+					Eligible:                     false,
+					ElectricityIneligibleReasons: []smart.IneligibleReason{smart.IneligibleReason_INELIGIBLE_REASON_ALREADY_SMART},
+					GasIneligibleReasons:         []smart.IneligibleReason{smart.IneligibleReason_INELIGIBLE_REASON_METER_LARGE_CAPACITY},
+				}, nil)
+			},
+			output: outputParams{
+				res: &bookingv1.GetEligibilityPointOfSaleJourneyResponse{
+					Eligible:                     false,
+					ElectricityIneligibleReasons: []smart.IneligibleReason{smart.IneligibleReason_INELIGIBLE_REASON_ALREADY_SMART},
+					GasIneligibleReasons:         []smart.IneligibleReason{smart.IneligibleReason_INELIGIBLE_REASON_METER_LARGE_CAPACITY},
+				},
+				err: nil,
+			},
+		},
 		{
 			description: "should fail to get eligibility because postcode is nil",
 			input: inputParams{
 				req: &bookingv1.GetEligibilityPointOfSaleJourneyRequest{
-					Mpan:     "mpan-1",
-					Mprn:     "mprn-1",
+					Mpan:     "2199996734008",
+					Mprn:     "2724968810",
 					Postcode: "",
 				},
 			},
@@ -5659,8 +6648,8 @@ func Test_GetEligibilityPointOfSaleJourney(t *testing.T) {
 			input: inputParams{
 				req: &bookingv1.GetEligibilityPointOfSaleJourneyRequest{
 					Mpan:     "",
-					Mprn:     "mprn-1",
-					Postcode: "E2 1Z",
+					Mprn:     "2724968810",
+					Postcode: "E2 1ZZ",
 				},
 			},
 			setup: func(_ context.Context, _ *mocks.MockBookingDomain, _ *mocks.MockAuth) {
@@ -5670,6 +6659,90 @@ func Test_GetEligibilityPointOfSaleJourney(t *testing.T) {
 				err: status.Error(codes.InvalidArgument, "provided mpan is missing"),
 			},
 		},
+		{
+			description: "should normalise the meterpoint numbers and postcode before processing eligibility",
+			input: inputParams{
+				req: &bookingv1.GetEligibilityPointOfSaleJourneyRequest{
+					Mpan:     "21 9999 6734 008",
+					Mprn:     "2724 968 810",
+					Postcode: "e21zz",
+				},
+			},
+			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, mAuth *mocks.MockAuth) {
+
+				mAuth.EXPECT().Authorize(ctx, &auth.PolicyParams{
+					Action:     "get",
+					Resource:   "uw.energy.v1.point-of-sale-smart-meter-booking",
+					ResourceID: "booking-api-server",
+				}).Return(true, nil)
+
+				bkDomain.EXPECT().ProcessEligibility(ctx, domain.ProcessEligibilityParams{
+					Postcode: "E2 1ZZ",
+					ElecOrderSupplies: models.OrderSupply{
+						MPXN: "2199996734008",
+					},
+					GasOrderSupplies: models.OrderSupply{
+						MPXN: "2724968810",
+					},
+				}).Return(domain.ProcessEligibilityResult{
+					Eligible: true,
+				}, nil)
+			},
+			output: outputParams{
+				res: &bookingv1.GetEligibilityPointOfSaleJourneyResponse{
+					Eligible: true,
+				},
+				err: nil,
+			},
+		},
+		{
+			description: "should fail to get eligibility because the mpan check digit is wrong",
+			input: inputParams{
+				req: &bookingv1.GetEligibilityPointOfSaleJourneyRequest{
+					Mpan:     "2199996734009",
+					Mprn:     "2724968810",
+					Postcode: "E2 1ZZ",
+				},
+			},
+			setup: func(_ context.Context, _ *mocks.MockBookingDomain, _ *mocks.MockAuth) {
+			},
+			output: outputParams{
+				res: nil,
+				err: status.Error(codes.InvalidArgument, "invalid mpan: check digit mismatch"),
+			},
+		},
+		{
+			description: "should fail to get eligibility because the mprn check digits are wrong",
+			input: inputParams{
+				req: &bookingv1.GetEligibilityPointOfSaleJourneyRequest{
+					Mpan:     "2199996734008",
+					Mprn:     "2724968811",
+					Postcode: "E2 1ZZ",
+				},
+			},
+			setup: func(_ context.Context, _ *mocks.MockBookingDomain, _ *mocks.MockAuth) {
+			},
+			output: outputParams{
+				res: nil,
+				err: status.Error(codes.InvalidArgument, "invalid mprn: check digits mismatch"),
+			},
+		},
+		{
+			description: "should fail to get eligibility because the postcode is not a valid UK postcode",
+			input: inputParams{
+				req: &bookingv1.GetEligibilityPointOfSaleJourneyRequest{
+					Mpan:     "2199996734008",
+					Mprn:     "2724968810",
+					Postcode: "E2 1Z",
+				},
+			},
+			setup: func(_ context.Context, _ *mocks.MockBookingDomain, _ *mocks.MockAuth) {
+			},
+			output: outputParams{
+				res: nil,
+				err: status.Error(codes.InvalidArgument, "invalid postcode"),
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -5999,3 +7072,298 @@ func Test_RegisterInterest(t *testing.T) {
 		})
 	}
 }
+
+func Test_CreateBooking_Idempotency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	defer ctrl.Finish()
+
+	bookingDomain := mocks.NewMockBookingDomain(ctrl)
+	bookingPublisher := mocks.NewMockPublisher(ctrl)
+	mockAuth := mocks.NewMockAuth(ctrl)
+	idempotencyStore := mocks.NewMockIdempotencyStore(ctrl)
+
+	myAPIHandler := api.New(bookingDomain, nil, bookingPublisher, nil, nil, nil, mockAuth, false).WithIdempotency(idempotencyStore)
+
+	req := &bookingv1.CreateBookingRequest{
+		AccountId: "account-id-1",
+		Slot: &bookingv1.BookingSlot{
+			Date: &date.Date{
+				Year:  2020,
+				Month: 10,
+				Day:   10,
+			},
+			StartTime: 10,
+			EndTime:   18,
+		},
+		VulnerabilityDetails: &bookingv1.VulnerabilityDetails{},
+		ContactDetails: &bookingv1.ContactDetails{
+			FirstName: "Joe",
+		},
+		Platform:       bookingv1.Platform_PLATFORM_APP,
+		IdempotencyKey: "idempotency-key-1",
+	}
+
+	type outputParams struct {
+		res *bookingv1.CreateBookingResponse
+		err error
+	}
+
+	testCases := []struct {
+		description string
+		setup       func(ctx context.Context, bkDomain *mocks.MockBookingDomain, publisher *mocks.MockPublisher, idSt *mocks.MockIdempotencyStore)
+		output      outputParams
+	}{
+		{
+			description: "should create the booking and complete the idempotency key",
+			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, publisher *mocks.MockPublisher, idSt *mocks.MockIdempotencyStore) {
+				event := &bookingv1.BookingCreatedEvent{BookingId: "booking-id-1"}
+
+				idSt.EXPECT().Reserve(ctx, "account-id-1", "create-booking", "idempotency-key-1", gomock.Any()).Return("", nil)
+				bkDomain.EXPECT().CreateBooking(ctx, gomock.Any()).Return(domain.CreateBookingResponse{Event: event}, nil)
+				idSt.EXPECT().Complete(ctx, "account-id-1", "create-booking", "idempotency-key-1", "booking-id-1").Return(nil)
+				publisher.EXPECT().Sink(ctx, event, gomock.Any()).Return(nil)
+			},
+			output: outputParams{
+				res: &bookingv1.CreateBookingResponse{BookingId: "booking-id-1"},
+			},
+		},
+		{
+			description: "should return the original booking ID on a replay without creating a booking",
+			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, publisher *mocks.MockPublisher, idSt *mocks.MockIdempotencyStore) {
+				idSt.EXPECT().Reserve(ctx, "account-id-1", "create-booking", "idempotency-key-1", gomock.Any()).Return("booking-id-1", nil)
+			},
+			output: outputParams{
+				res: &bookingv1.CreateBookingResponse{BookingId: "booking-id-1"},
+			},
+		},
+		{
+			description: "should release the idempotency key when the booking fails",
+			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, publisher *mocks.MockPublisher, idSt *mocks.MockIdempotencyStore) {
+				idSt.EXPECT().Reserve(ctx, "account-id-1", "create-booking", "idempotency-key-1", gomock.Any()).Return("", nil)
+				bkDomain.EXPECT().CreateBooking(ctx, gomock.Any()).Return(domain.CreateBookingResponse{}, errOops)
+				idSt.EXPECT().Release(ctx, "account-id-1", "create-booking", "idempotency-key-1").Return(nil)
+			},
+			output: outputParams{
+				res: &bookingv1.CreateBookingResponse{},
+				err: status.Errorf(codes.Internal, "failed to create booking, %s", errOops),
+			},
+		},
+		{
+			description: "should return InvalidArgument when the idempotency key was used for a different request",
+			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, publisher *mocks.MockPublisher, idSt *mocks.MockIdempotencyStore) {
+				idSt.EXPECT().Reserve(ctx, "account-id-1", "create-booking", "idempotency-key-1", gomock.Any()).Return("", store.ErrIdempotencyKeyReused)
+			},
+			output: outputParams{
+				err: status.Errorf(codes.InvalidArgument, "failed to reserve idempotency key, %s", store.ErrIdempotencyKeyReused),
+			},
+		},
+		{
+			description: "should return Aborted while the original request is in progress",
+			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, publisher *mocks.MockPublisher, idSt *mocks.MockIdempotencyStore) {
+				idSt.EXPECT().Reserve(ctx, "account-id-1", "create-booking", "idempotency-key-1", gomock.Any()).Return("", store.ErrIdempotentRequestInProgress)
+			},
+			output: outputParams{
+				err: status.Errorf(codes.Aborted, "failed to reserve idempotency key, %s", store.ErrIdempotentRequestInProgress),
+			},
+		},
+		{
+			description: "should return Unavailable when the original request stopped before completing",
+			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, publisher *mocks.MockPublisher, idSt *mocks.MockIdempotencyStore) {
+				idSt.EXPECT().Reserve(ctx, "account-id-1", "create-booking", "idempotency-key-1", gomock.Any()).Return("", store.ErrIdempotentRequestStale)
+			},
+			output: outputParams{
+				err: status.Errorf(codes.Unavailable, "failed to reserve idempotency key, %s", store.ErrIdempotentRequestStale),
+			},
+		},
+		{
+			description: "should return Internal and keep the idempotency key pending when the created event cannot be written",
+			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, publisher *mocks.MockPublisher, idSt *mocks.MockIdempotencyStore) {
+				event := &bookingv1.BookingCreatedEvent{BookingId: "booking-id-1"}
+
+				idSt.EXPECT().Reserve(ctx, "account-id-1", "create-booking", "idempotency-key-1", gomock.Any()).Return("", nil)
+				bkDomain.EXPECT().CreateBooking(ctx, gomock.Any()).Return(domain.CreateBookingResponse{Event: event}, nil)
+				publisher.EXPECT().Sink(ctx, event, gomock.Any()).Return(errOops)
+			},
+			output: outputParams{
+				err: status.Errorf(codes.Internal, "failed to sink create booking event of booking booking-id-1, %s", errOops),
+			},
+		},
+		{
+			description: "should return Internal when the idempotency key cannot be completed",
+			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, publisher *mocks.MockPublisher, idSt *mocks.MockIdempotencyStore) {
+				event := &bookingv1.BookingCreatedEvent{BookingId: "booking-id-1"}
+
+				idSt.EXPECT().Reserve(ctx, "account-id-1", "create-booking", "idempotency-key-1", gomock.Any()).Return("", nil)
+				bkDomain.EXPECT().CreateBooking(ctx, gomock.Any()).Return(domain.CreateBookingResponse{Event: event}, nil)
+				idSt.EXPECT().Complete(ctx, "account-id-1", "create-booking", "idempotency-key-1", "booking-id-1").Return(errOops)
+				publisher.EXPECT().Sink(ctx, event, gomock.Any()).Return(nil)
+			},
+			output: outputParams{
+				err: status.Errorf(codes.Internal, "failed to complete idempotency key of booking booking-id-1, %s", errOops),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			mockAuth.EXPECT().Authorize(ctx, &auth.PolicyParams{
+				Action:     "create",
+				Resource:   "uw.energy.v1.account.smart-meter-booking",
+				ResourceID: "account-id-1",
+			}).Return(true, nil)
+
+			tc.setup(ctx, bookingDomain, bookingPublisher, idempotencyStore)
+
+			actual, err := myAPIHandler.CreateBooking(ctx, req)
+			if tc.output.err != nil {
+				if diff := cmp.Diff(tc.output.err.Error(), err.Error()); diff != "" {
+					t.Fatal(diff)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.output.res, actual, cmpopts.IgnoreUnexported(bookingv1.CreateBookingResponse{})); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func Test_RescheduleBooking_Transaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	defer ctrl.Finish()
+
+	bookingDomain := mocks.NewMockBookingDomain(ctrl)
+	bookingPublisher := mocks.NewMockPublisher(ctrl)
+	commReschedulePublisher := mocks.NewMockPublisher(ctrl)
+	mockAuth := mocks.NewMockAuth(ctrl)
+	idempotencyStore := mocks.NewMockIdempotencyStore(ctrl)
+	transactor := mocks.NewMockTransactor(ctrl)
+
+	myAPIHandler := api.New(bookingDomain, nil, bookingPublisher, nil, commReschedulePublisher, nil, mockAuth, false).
+		WithIdempotency(idempotencyStore).
+		WithTransactions(transactor)
+
+	req := &bookingv1.RescheduleBookingRequest{
+		AccountId: "account-id-1",
+		BookingId: "booking-id-1",
+		Slot: &bookingv1.BookingSlot{
+			Date: &date.Date{
+				Year:  2020,
+				Month: 1,
+				Day:   12,
+			},
+			StartTime: 10,
+			EndTime:   20,
+		},
+		Platform:             bookingv1.Platform_PLATFORM_APP,
+		VulnerabilityDetails: &bookingv1.VulnerabilityDetails{},
+		ContactDetails: &bookingv1.ContactDetails{
+			FirstName: "John",
+		},
+		IdempotencyKey: "idempotency-key-1",
+	}
+
+	bookingEvent := &bookingv1.BookingRescheduledEvent{BookingId: "booking-id-1"}
+	commsEvent := &commsv1.BookingRescheduledCommsEvent{AccountId: "account-id-1"}
+
+	runInTx := func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	}
+
+	type outputParams struct {
+		res *bookingv1.RescheduleBookingResponse
+		err error
+	}
+
+	testCases := []struct {
+		description string
+		setup       func(ctx context.Context, bkDomain *mocks.MockBookingDomain, idSt *mocks.MockIdempotencyStore, tx *mocks.MockTransactor)
+		output      outputParams
+	}{
+		{
+			description: "should write both events and complete the idempotency key in one transaction",
+			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, idSt *mocks.MockIdempotencyStore, tx *mocks.MockTransactor) {
+				idSt.EXPECT().Reserve(ctx, "account-id-1", "reschedule-booking", "idempotency-key-1", gomock.Any()).Return("", nil)
+				bkDomain.EXPECT().RescheduleBooking(ctx, gomock.Any()).Return(domain.RescheduleBookingResponse{BookingEvent: bookingEvent, CommsEvent: commsEvent}, nil)
+				tx.EXPECT().InTx(ctx, gomock.Any()).DoAndReturn(runInTx)
+				bookingPublisher.EXPECT().Sink(ctx, bookingEvent, gomock.Any()).Return(nil)
+				commReschedulePublisher.EXPECT().Sink(ctx, commsEvent, gomock.Any()).Return(nil)
+				idSt.EXPECT().Complete(ctx, "account-id-1", "reschedule-booking", "idempotency-key-1", "booking-id-1").Return(nil)
+			},
+			output: outputParams{
+				res: &bookingv1.RescheduleBookingResponse{BookingId: "booking-id-1"},
+			},
+		},
+		{
+			description: "should roll back the booking event and keep the idempotency key pending when the comms event cannot be written",
+			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, idSt *mocks.MockIdempotencyStore, tx *mocks.MockTransactor) {
+				idSt.EXPECT().Reserve(ctx, "account-id-1", "reschedule-booking", "idempotency-key-1", gomock.Any()).Return("", nil)
+				bkDomain.EXPECT().RescheduleBooking(ctx, gomock.Any()).Return(domain.RescheduleBookingResponse{BookingEvent: bookingEvent, CommsEvent: commsEvent}, nil)
+				tx.EXPECT().InTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					err := fn(ctx)
+					if diff := cmp.Diff(status.Errorf(codes.Internal, "failed to sink reschedule comms event of booking booking-id-1, %s", errOops).Error(), err.Error()); diff != "" {
+						t.Fatalf("expected the transaction to be rolled back, %s", diff)
+					}
+					return err
+				})
+				bookingPublisher.EXPECT().Sink(ctx, bookingEvent, gomock.Any()).Return(nil)
+				commReschedulePublisher.EXPECT().Sink(ctx, commsEvent, gomock.Any()).Return(errOops)
+			},
+			output: outputParams{
+				err: status.Errorf(codes.Internal, "failed to sink reschedule comms event of booking booking-id-1, %s", errOops),
+			},
+		},
+		{
+			description: "should return Internal when the transaction cannot be committed",
+			setup: func(ctx context.Context, bkDomain *mocks.MockBookingDomain, idSt *mocks.MockIdempotencyStore, tx *mocks.MockTransactor) {
+				idSt.EXPECT().Reserve(ctx, "account-id-1", "reschedule-booking", "idempotency-key-1", gomock.Any()).Return("", nil)
+				bkDomain.EXPECT().RescheduleBooking(ctx, gomock.Any()).Return(domain.RescheduleBookingResponse{BookingEvent: bookingEvent, CommsEvent: commsEvent}, nil)
+				tx.EXPECT().InTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					if err := fn(ctx); err != nil {
+						return err
+					}
+					return errOops
+				})
+				bookingPublisher.EXPECT().Sink(ctx, bookingEvent, gomock.Any()).Return(nil)
+				commReschedulePublisher.EXPECT().Sink(ctx, commsEvent, gomock.Any()).Return(nil)
+				idSt.EXPECT().Complete(ctx, "account-id-1", "reschedule-booking", "idempotency-key-1", "booking-id-1").Return(nil)
+			},
+			output: outputParams{
+				err: status.Errorf(codes.Internal, "failed to write the outcome of the request, %s", errOops),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			mockAuth.EXPECT().Authorize(ctx, &auth.PolicyParams{
+				Action:     "update",
+				Resource:   "uw.energy.v1.account.smart-meter-booking",
+				ResourceID: "account-id-1",
+			}).Return(true, nil)
+
+			tc.setup(ctx, bookingDomain, idempotencyStore, transactor)
+
+			actual, err := myAPIHandler.RescheduleBooking(ctx, req)
+			if tc.output.err != nil {
+				if diff := cmp.Diff(tc.output.err.Error(), err.Error()); diff != "" {
+					t.Fatal(diff)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.output.res, actual, cmpopts.IgnoreUnexported(bookingv1.RescheduleBookingResponse{})); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/repository/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	operationCreateBooking            = "create-booking"
+	operationRescheduleBooking        = "reschedule-booking"
+	operationCreateBookingPointOfSale = "create-booking-point-of-sale"
+
+	maxIdempotencyKeyLength = 255
+)
+
+type idempotentRequest interface {
+	proto.Message
+	GetIdempotencyKey() string
+}
+
+// reserveIdempotencyKey claims the idempotency key of the request, if any. It returns the booking ID
+// of the original request when the request is a replay of one which completed.
+func (b *BookingAPI) reserveIdempotencyKey(ctx context.Context, operation, accountID string, req idempotentRequest) (string, error) {
+	key := req.GetIdempotencyKey()
+	if b.idempotency == nil || key == "" {
+		return "", nil
+	}
+
+	if len(key) > maxIdempotencyKeyLength {
+		return "", status.Errorf(codes.InvalidArgument, "idempotency key is longer than %d characters", maxIdempotencyKeyLength)
+	}
+
+	hash, err := requestHash(req)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed to hash request, %s", err)
+	}
+
+	bookingID, err := b.idempotency.Reserve(ctx, accountID, operation, key, hash)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrIdempotencyKeyReused):
+			return "", status.Errorf(codes.InvalidArgument, "failed to reserve idempotency key, %s", err)
+		case errors.Is(err, store.ErrIdempotentRequestInProgress):
+			return "", status.Errorf(codes.Aborted, "failed to reserve idempotency key, %s", err)
+		case errors.Is(err, store.ErrIdempotentRequestStale):
+			return "", status.Errorf(codes.Unavailable, "failed to reserve idempotency key, %s", err)
+		default:
+			return "", status.Errorf(codes.Internal, "failed to reserve idempotency key, %s", err)
+		}
+	}
+
+	if bookingID != "" {
+		slog.Info("replaying idempotent request", "operation", operation, "account_id", accountID, "idempotency_key", key, "booking_id", bookingID)
+	}
+
+	return bookingID, nil
+}
+
+// completeIdempotencyKey records the booking the request resulted in, so that its retries return it.
+// On failure the key stays pending, so that the retries fail rather than booking again.
+func (b *BookingAPI) completeIdempotencyKey(ctx context.Context, operation, accountID string, req idempotentRequest, bookingID string) error {
+	key := req.GetIdempotencyKey()
+	if b.idempotency == nil || key == "" {
+		return nil
+	}
+
+	if err := b.idempotency.Complete(ctx, accountID, operation, key, bookingID); err != nil {
+		slog.Error("failed to complete idempotency key", "error", err, "operation", operation, "account_id", accountID, "idempotency_key", key, "booking_id", bookingID)
+		return status.Errorf(codes.Internal, "failed to complete idempotency key of booking %s, %s", bookingID, err)
+	}
+
+	return nil
+}
+
+// releaseIdempotencyKey frees the idempotency key of a failed request, so that it can be retried.
+func (b *BookingAPI) releaseIdempotencyKey(ctx context.Context, operation, accountID string, req idempotentRequest) {
+	key := req.GetIdempotencyKey()
+	if b.idempotency == nil || key == "" {
+		return
+	}
+
+	if err := b.idempotency.Release(ctx, accountID, operation, key); err != nil {
+		slog.Error("failed to release idempotency key", "error", err, "operation", operation, "account_id", accountID, "idempotency_key", key)
+	}
+}
+
+// inTx runs fn in a transaction of the transactor, if any, so that the events of a request are written
+// and its idempotency key completed together.
+func (b *BookingAPI) inTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if b.transactor == nil {
+		return fn(ctx)
+	}
+
+	err := b.transactor.InTx(ctx, fn)
+	if _, ok := status.FromError(err); !ok {
+		return status.Errorf(codes.Internal, "failed to write the outcome of the request, %s", err)
+	}
+
+	return err
+}
+
+// requestHash hashes the request without its idempotency key, which tells a retry from a different
+// request reusing the key.
+func requestHash(req proto.Message) (string, error) {
+	msg := proto.Clone(req).ProtoReflect()
+	if fd := msg.Descriptor().Fields().ByName("idempotency_key"); fd != nil {
+		msg.Clear(fd)
+	}
+
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg.Interface())
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -40,6 +40,21 @@ func (m *MockBookingDomain) EXPECT() *MockBookingDomainMockRecorder {
 	return m.recorder
 }
 
+// CancelBooking mocks base method.
+func (m *MockBookingDomain) CancelBooking(ctx context.Context, params domain.CancelBookingParams) (domain.CancelBookingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelBooking", ctx, params)
+	ret0, _ := ret[0].(domain.CancelBookingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelBooking indicates an expected call of CancelBooking.
+func (mr *MockBookingDomainMockRecorder) CancelBooking(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelBooking", reflect.TypeOf((*MockBookingDomain)(nil).CancelBooking), ctx, params)
+}
+
 // CreateBooking mocks base method.
 func (m *MockBookingDomain) CreateBooking(ctx context.Context, params domain.CreateBookingParams) (domain.CreateBookingResponse, error) {
 	m.ctrl.T.Helper()
@@ -115,6 +130,21 @@ func (mr *MockBookingDomainMockRecorder) GetAvailableSlotsPointOfSale(ctx, param
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailableSlotsPointOfSale", reflect.TypeOf((*MockBookingDomain)(nil).GetAvailableSlotsPointOfSale), ctx, params)
 }
 
+// GetBookableOccupancies mocks base method.
+func (m *MockBookingDomain) GetBookableOccupancies(ctx context.Context, accountID string) ([]domain.BookableOccupancy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookableOccupancies", ctx, accountID)
+	ret0, _ := ret[0].([]domain.BookableOccupancy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookableOccupancies indicates an expected call of GetBookableOccupancies.
+func (mr *MockBookingDomainMockRecorder) GetBookableOccupancies(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookableOccupancies", reflect.TypeOf((*MockBookingDomain)(nil).GetBookableOccupancies), ctx, accountID)
+}
+
 // GetClickLink mocks base method.
 func (m *MockBookingDomain) GetClickLink(arg0 context.Context, arg1 domain.GetClickLinkParams) (domain.GetClickLinkResult, error) {
 	m.ctrl.T.Helper()
@@ -175,6 +205,21 @@ func (mr *MockBookingDomainMockRecorder) GetCustomerDetailsPointOfSale(ctx, acco
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerDetailsPointOfSale", reflect.TypeOf((*MockBookingDomain)(nil).GetCustomerDetailsPointOfSale), ctx, accountNumber)
 }
 
+// HoldSlot mocks base method.
+func (m *MockBookingDomain) HoldSlot(ctx context.Context, params domain.HoldSlotParams) (domain.HoldSlotResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HoldSlot", ctx, params)
+	ret0, _ := ret[0].(domain.HoldSlotResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HoldSlot indicates an expected call of HoldSlot.
+func (mr *MockBookingDomainMockRecorder) HoldSlot(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HoldSlot", reflect.TypeOf((*MockBookingDomain)(nil).HoldSlot), ctx, params)
+}
+
 // ProcessEligibility mocks base method.
 func (m *MockBookingDomain) ProcessEligibility(arg0 context.Context, arg1 domain.ProcessEligibilityParams) (domain.ProcessEligibilityResult, error) {
 	m.ctrl.T.Helper()
@@ -205,6 +250,21 @@ func (mr *MockBookingDomainMockRecorder) RescheduleBooking(ctx, params interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescheduleBooking", reflect.TypeOf((*MockBookingDomain)(nil).RescheduleBooking), ctx, params)
 }
 
+// UpdateBookingContactDetails mocks base method.
+func (m *MockBookingDomain) UpdateBookingContactDetails(ctx context.Context, params domain.UpdateBookingContactDetailsParams) (domain.UpdateBookingContactDetailsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBookingContactDetails", ctx, params)
+	ret0, _ := ret[0].(domain.UpdateBookingContactDetailsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBookingContactDetails indicates an expected call of UpdateBookingContactDetails.
+func (mr *MockBookingDomainMockRecorder) UpdateBookingContactDetails(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBookingContactDetails", reflect.TypeOf((*MockBookingDomain)(nil).UpdateBookingContactDetails), ctx, params)
+}
+
 // MockSmartMeterInterestDomain is a mock of SmartMeterInterestDomain interface.
 type MockSmartMeterInterestDomain struct {
 	ctrl     *gomock.Controller
@@ -318,6 +378,109 @@ func (mr *MockAuthMockRecorder) Authorize(ctx, params interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockAuth)(nil).Authorize), ctx, params)
 }
 
+// MockIdempotencyStore is a mock of IdempotencyStore interface.
+type MockIdempotencyStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyStoreMockRecorder
+}
+
+// MockIdempotencyStoreMockRecorder is the mock recorder for MockIdempotencyStore.
+type MockIdempotencyStoreMockRecorder struct {
+	mock *MockIdempotencyStore
+}
+
+// NewMockIdempotencyStore creates a new mock instance.
+func NewMockIdempotencyStore(ctrl *gomock.Controller) *MockIdempotencyStore {
+	mock := &MockIdempotencyStore{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyStore) EXPECT() *MockIdempotencyStoreMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method.
+func (m *MockIdempotencyStore) Complete(ctx context.Context, accountID, operation, key, bookingID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, accountID, operation, key, bookingID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockIdempotencyStoreMockRecorder) Complete(ctx, accountID, operation, key, bookingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockIdempotencyStore)(nil).Complete), ctx, accountID, operation, key, bookingID)
+}
+
+// Release mocks base method.
+func (m *MockIdempotencyStore) Release(ctx context.Context, accountID, operation, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, accountID, operation, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockIdempotencyStoreMockRecorder) Release(ctx, accountID, operation, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockIdempotencyStore)(nil).Release), ctx, accountID, operation, key)
+}
+
+// Reserve mocks base method.
+func (m *MockIdempotencyStore) Reserve(ctx context.Context, accountID, operation, key, requestHash string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, accountID, operation, key, requestHash)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockIdempotencyStoreMockRecorder) Reserve(ctx, accountID, operation, key, requestHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockIdempotencyStore)(nil).Reserve), ctx, accountID, operation, key, requestHash)
+}
+
+// MockTransactor is a mock of Transactor interface.
+type MockTransactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactorMockRecorder
+}
+
+// MockTransactorMockRecorder is the mock recorder for MockTransactor.
+type MockTransactorMockRecorder struct {
+	mock *MockTransactor
+}
+
+// NewMockTransactor creates a new mock instance.
+func NewMockTransactor(ctrl *gomock.Controller) *MockTransactor {
+	mock := &MockTransactor{ctrl: ctrl}
+	mock.recorder = &MockTransactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactor) EXPECT() *MockTransactorMockRecorder {
+	return m.recorder
+}
+
+// InTx mocks base method.
+func (m *MockTransactor) InTx(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InTx indicates an expected call of InTx.
+func (mr *MockTransactorMockRecorder) InTx(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InTx", reflect.TypeOf((*MockTransactor)(nil).InTx), ctx, fn)
+}
+
 // MockaccountIder is a mock of accountIder interface.
 type MockaccountIder struct {
 	ctrl     *gomock.Controller
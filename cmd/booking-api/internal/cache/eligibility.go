@@ -4,17 +4,19 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
 )
 
 var ErrNotFound = errors.New("not cached")
 
 type EligibilityGateway interface {
-	GetMeterpointEligibility(ctx context.Context, mpan, mprn, postcode string) (bool, error)
+	GetMeterpointEligibility(ctx context.Context, mpan, mprn, postcode string) (models.MeterpointEligibility, error)
 }
 
 type EligibilityCache interface {
-	GetEligibilityForMpxn(ctx context.Context, mpan, mprn string) (bool, error)
-	SetEligibilityForMpxn(ctx context.Context, mpan, mprn string, eligible bool) error
+	GetEligibilityForMpxn(ctx context.Context, mpan, mprn string) (models.MeterpointEligibility, error)
+	SetEligibilityForMpxn(ctx context.Context, mpan, mprn string, eligibility models.MeterpointEligibility) error
 }
 
 type MeterpointEligibilityCacheWrapper struct {
@@ -29,24 +31,24 @@ func NewMeterpointEligibilityCacheWrapper(gw EligibilityGateway, cache Eligibili
 	}
 }
 
-func (c *MeterpointEligibilityCacheWrapper) GetMeterpointEligibility(ctx context.Context, mpan, mprn, postcode string) (bool, error) {
-	eligible, err := c.cache.GetEligibilityForMpxn(ctx, mpan, mprn)
+func (c *MeterpointEligibilityCacheWrapper) GetMeterpointEligibility(ctx context.Context, mpan, mprn, postcode string) (models.MeterpointEligibility, error) {
+	eligibility, err := c.cache.GetEligibilityForMpxn(ctx, mpan, mprn)
 	if err == nil {
-		return eligible, nil
+		return eligibility, nil
 	}
 
 	if !errors.Is(err, ErrNotFound) {
-		return false, err
+		return models.MeterpointEligibility{}, err
 	}
 
-	eligible, err = c.gw.GetMeterpointEligibility(ctx, mpan, mprn, postcode)
+	eligibility, err = c.gw.GetMeterpointEligibility(ctx, mpan, mprn, postcode)
 	if err != nil {
-		return false, err
+		return models.MeterpointEligibility{}, err
 	}
 
-	err = c.cache.SetEligibilityForMpxn(ctx, mpan, mprn, eligible)
+	err = c.cache.SetEligibilityForMpxn(ctx, mpan, mprn, eligibility)
 	if err != nil {
 		slog.Warn("unable to write to cache", "mpan", mpan, "error", err)
 	}
-	return eligible, nil
+	return eligibility, nil
 }
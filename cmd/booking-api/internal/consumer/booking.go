@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/utilitywarehouse/energy-contracts/pkg/generated"
 	bookingv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/booking/v1"
 	"github.com/utilitywarehouse/energy-pkg/metrics"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/repository/store"
 	utilities "github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/utils"
 	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
 	"github.com/uw-labs/substrate"
@@ -16,9 +18,12 @@ import (
 
 type BookingStore interface {
 	Upsert(models.Booking)
-	UpdateStatus(bookingID string, newStatus bookingv1.BookingStatus)
+	UpdateStatus(bookingID string, newStatus bookingv1.BookingStatus, at time.Time)
+	UpdateSchedule(bookingID string, bookingSlot models.BookingSlot, at time.Time)
+	AddStatusChange(bookingID, eventUUID string, change models.BookingStatusChange)
 
 	UpdateBookingOnReschedule(bookingID string, contactDetails models.AccountDetails, bookingSlot models.BookingSlot, vulnerabilityDetails models.VulnerabilityDetails)
+	UpdateContactDetails(bookingID string, contactDetails models.AccountDetails, vulnerabilityDetails models.VulnerabilityDetails)
 
 	Begin()
 	Commit(context.Context) error
@@ -63,6 +68,12 @@ func (h *BookingHandler) Handle(_ context.Context, message substrate.Message) er
 	if err != nil {
 		return fmt.Errorf("failed to unmarshall event in booking topic [%s|%s]: %w", eventUUID, env.Message.TypeUrl, err)
 	}
+
+	occurredAt := time.Now()
+	if env.GetOccurredAt() != nil {
+		occurredAt = env.GetOccurredAt().AsTime()
+	}
+
 	switch ev := payload.(type) {
 	case *bookingv1.BookingCreatedEvent:
 		details := ev.GetDetails()
@@ -98,6 +109,10 @@ func (h *BookingHandler) Handle(_ context.Context, message substrate.Message) er
 			BookingReference: details.GetExternalReference(),
 			BookingType:      details.BookingType,
 		})
+		h.bookingStore.AddStatusChange(ev.GetBookingId(), eventUUID, models.BookingStatusChange{
+			Status:     details.GetStatus(),
+			OccurredAt: occurredAt,
+		})
 	case *bookingv1.BookingRescheduledEvent:
 		bookingID := ev.GetBookingId()
 		dt, err := utilities.DateIntoTime(ev.GetSlot().GetDate())
@@ -120,6 +135,49 @@ func (h *BookingHandler) Handle(_ context.Context, message substrate.Message) er
 			Vulnerabilities: ev.GetVulnerabilityDetails().Vulnerabilities,
 			Other:           ev.GetVulnerabilityDetails().Other,
 		})
+		h.bookingStore.UpdateStatus(bookingID, bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED, occurredAt)
+		h.bookingStore.AddStatusChange(bookingID, eventUUID, models.BookingStatusChange{
+			Status:     bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED,
+			Reason:     store.RescheduledReason,
+			OccurredAt: occurredAt,
+		})
+	case *bookingv1.BookingContactDetailsUpdatedEvent:
+		contactDetails := ev.GetContactDetails()
+		h.bookingStore.UpdateContactDetails(ev.GetBookingId(), models.AccountDetails{
+			Title:     contactDetails.GetTitle(),
+			FirstName: contactDetails.GetFirstName(),
+			LastName:  contactDetails.GetLastName(),
+			Email:     contactDetails.GetEmail(),
+			Mobile:    contactDetails.GetPhone(),
+		}, models.VulnerabilityDetails{
+			Vulnerabilities: ev.GetVulnerabilityDetails().GetVulnerabilities(),
+			Other:           ev.GetVulnerabilityDetails().GetOther(),
+		})
+	case *bookingv1.BookingCancelledEvent:
+		h.bookingStore.UpdateStatus(ev.GetBookingId(), bookingv1.BookingStatus_BOOKING_STATUS_CANCELLED, occurredAt)
+		h.bookingStore.AddStatusChange(ev.GetBookingId(), eventUUID, models.BookingStatusChange{
+			Status:     bookingv1.BookingStatus_BOOKING_STATUS_CANCELLED,
+			Reason:     ev.GetReason(),
+			OccurredAt: occurredAt,
+		})
+	case *bookingv1.BookingStatusChangedEvent:
+		if slot := ev.GetSlot(); slot != nil {
+			dt, err := utilities.DateIntoTime(slot.GetDate())
+			if err != nil {
+				return err
+			}
+			h.bookingStore.UpdateSchedule(ev.GetBookingId(), models.BookingSlot{
+				Date:      *dt,
+				StartTime: int(slot.GetStartTime()),
+				EndTime:   int(slot.GetEndTime()),
+			}, occurredAt)
+		}
+		h.bookingStore.UpdateStatus(ev.GetBookingId(), ev.GetStatus(), occurredAt)
+		h.bookingStore.AddStatusChange(ev.GetBookingId(), eventUUID, models.BookingStatusChange{
+			Status:     ev.GetStatus(),
+			Reason:     ev.GetReason(),
+			OccurredAt: occurredAt,
+		})
 	}
 
 	return nil
@@ -0,0 +1,108 @@
+//go:generate mockgen -source=booking.go -destination ./mocks/booking_mocks.go
+
+package consumer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	bookingv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/booking/v1"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/consumer"
+	mocks "github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/consumer/mocks"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
+	"google.golang.org/genproto/googleapis/type/date"
+)
+
+func Test_BookingHandler_Handle_StatusChanged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	defer ctrl.Finish()
+
+	bookingSt := mocks.NewMockBookingStore(ctrl)
+	occupancySt := mocks.NewMockOccupancyReadOnlyStore(ctrl)
+
+	handler := consumer.HandleBooking(bookingSt, occupancySt)
+
+	occurredAt := time.Date(2023, time.December, 1, 10, 0, 0, 0, time.UTC)
+
+	type testSetup struct {
+		description string
+		setup       func(*mocks.MockBookingStore)
+		input       *bookingv1.BookingStatusChangedEvent
+		err         error
+	}
+
+	testCases := []testSetup{
+		{
+			description: "should update the status of the booking and record the status change",
+			input: &bookingv1.BookingStatusChangedEvent{
+				BookingId:   "booking-id-1",
+				AccountId:   "account-id-1",
+				OccupancyId: "occupancy-id-1",
+				Status:      bookingv1.BookingStatus_BOOKING_STATUS_ABORTED,
+				Reason:      "unsafe meter position",
+			},
+			setup: func(b *mocks.MockBookingStore) {
+				gomock.InOrder(
+					b.EXPECT().UpdateStatus("booking-id-1", bookingv1.BookingStatus_BOOKING_STATUS_ABORTED, occurredAt),
+					b.EXPECT().AddStatusChange("booking-id-1", "event-uuid-1", models.BookingStatusChange{
+						Status:     bookingv1.BookingStatus_BOOKING_STATUS_ABORTED,
+						Reason:     "unsafe meter position",
+						OccurredAt: occurredAt,
+					}),
+				)
+			},
+		},
+		{
+			description: "should move the booking to the new slot before updating its status when the status change has one",
+			input: &bookingv1.BookingStatusChangedEvent{
+				BookingId:   "booking-id-1",
+				AccountId:   "account-id-1",
+				OccupancyId: "occupancy-id-1",
+				Status:      bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED,
+				Slot: &bookingv1.BookingSlot{
+					Date: &date.Date{
+						Year:  2023,
+						Month: 12,
+						Day:   12,
+					},
+					StartTime: 12,
+					EndTime:   16,
+				},
+			},
+			setup: func(b *mocks.MockBookingStore) {
+				gomock.InOrder(
+					b.EXPECT().UpdateSchedule("booking-id-1", models.BookingSlot{
+						Date:      time.Date(2023, time.December, 12, 0, 0, 0, 0, time.UTC),
+						StartTime: 12,
+						EndTime:   16,
+					}, occurredAt),
+					b.EXPECT().UpdateStatus("booking-id-1", bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED, occurredAt),
+					b.EXPECT().AddStatusChange("booking-id-1", "event-uuid-1", models.BookingStatusChange{
+						Status:     bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED,
+						OccurredAt: occurredAt,
+					}),
+				)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+
+			tc.setup(bookingSt)
+
+			err := handler.Handle(ctx, makeMessage(t, "event-uuid-1", tc.input, occurredAt))
+
+			if diff := cmp.Diff(err, tc.err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/utilitywarehouse/energy-contracts/pkg/generated"
+	bookingv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/booking/v1"
+	lowribeckv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/third_party/lowribeck/v1"
+	"github.com/utilitywarehouse/energy-pkg/metrics"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/repository/store"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
+	"github.com/uw-labs/substrate"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/protobuf/proto"
+)
+
+type BookingReferenceStore interface {
+	GetBookingByReference(ctx context.Context, reference string) (models.Booking, error)
+}
+
+type BookingPublisher interface {
+	Sink(ctx context.Context, proto proto.Message, at time.Time) error
+}
+
+// JobOutcomeHandler turns the outcomes of the installers' jobs reported by Lowri-Beck into booking
+// status changes, which are published to the booking topic to be projected.
+type JobOutcomeHandler struct {
+	bookingStore BookingReferenceStore
+	publisher    BookingPublisher
+}
+
+func HandleJobOutcome(bookings BookingReferenceStore, publisher BookingPublisher) *JobOutcomeHandler {
+	return &JobOutcomeHandler{bookingStore: bookings, publisher: publisher}
+}
+
+func (h *JobOutcomeHandler) PreHandle(_ context.Context) error {
+	return nil
+}
+
+func (h *JobOutcomeHandler) PostHandle(_ context.Context) error {
+	return nil
+}
+
+func (h *JobOutcomeHandler) Handle(ctx context.Context, message substrate.Message) error {
+	var env generated.Envelope
+	if err := proto.Unmarshal(message.Data(), &env); err != nil {
+		return err
+	}
+
+	eventUUID := env.Uuid
+	if env.Message == nil {
+		slog.Info("skipping empty message", "event_uuid", eventUUID)
+		metrics.SkippedMessageCounter.WithLabelValues("empty_message").Inc()
+		return nil
+	}
+
+	payload, err := env.Message.UnmarshalNew()
+	if err != nil {
+		return fmt.Errorf("failed to unmarshall event in job outcome topic [%s|%s]: %w", eventUUID, env.Message.TypeUrl, err)
+	}
+
+	ev, ok := payload.(*lowribeckv1.JobOutcomeEvent)
+	if !ok {
+		return nil
+	}
+
+	occurredAt := time.Now()
+	if env.GetOccurredAt() != nil {
+		occurredAt = env.GetOccurredAt().AsTime()
+	}
+
+	status, ok := jobOutcomeStatus(ev.GetOutcome())
+	if !ok {
+		slog.Warn("skipping unknown job outcome", "event_uuid", eventUUID, "outcome", ev.GetOutcome().String())
+		metrics.SkippedMessageCounter.WithLabelValues("unknown_job_outcome").Inc()
+		return nil
+	}
+
+	booking, err := h.bookingStore.GetBookingByReference(ctx, ev.GetReference())
+	if err != nil {
+		if errors.Is(err, store.ErrBookingNotFound) {
+			slog.Warn("skipping job outcome of unknown booking", "event_uuid", eventUUID, "reference", ev.GetReference())
+			metrics.SkippedMessageCounter.WithLabelValues("booking_not_found").Inc()
+			return nil
+		}
+		return fmt.Errorf("failed to get booking for reference %s: %w", ev.GetReference(), err)
+	}
+
+	statusChange := &bookingv1.BookingStatusChangedEvent{
+		BookingId:   booking.BookingID,
+		AccountId:   booking.AccountID,
+		OccupancyId: booking.OccupancyID,
+		Status:      status,
+		Reason:      ev.GetReason(),
+	}
+
+	// the installer moved the appointment to another slot
+	if ev.GetOutcome() == lowribeckv1.JobOutcome_JOB_OUTCOME_RESCHEDULED && ev.GetSlot() != nil {
+		statusChange.Slot = &bookingv1.BookingSlot{
+			Date: &date.Date{
+				Year:  ev.GetSlot().GetDate().GetYear(),
+				Month: ev.GetSlot().GetDate().GetMonth(),
+				Day:   ev.GetSlot().GetDate().GetDay(),
+			},
+			StartTime: ev.GetSlot().GetStartTime(),
+			EndTime:   ev.GetSlot().GetEndTime(),
+		}
+	}
+
+	if err := h.publisher.Sink(ctx, statusChange, occurredAt); err != nil {
+		return fmt.Errorf("failed to publish status change of booking %s: %w", booking.BookingID, err)
+	}
+
+	return nil
+}
+
+func jobOutcomeStatus(outcome lowribeckv1.JobOutcome) (bookingv1.BookingStatus, bool) {
+	switch outcome {
+	case lowribeckv1.JobOutcome_JOB_OUTCOME_COMPLETED:
+		return bookingv1.BookingStatus_BOOKING_STATUS_COMPLETED, true
+	case lowribeckv1.JobOutcome_JOB_OUTCOME_ABORTED:
+		return bookingv1.BookingStatus_BOOKING_STATUS_ABORTED, true
+	case lowribeckv1.JobOutcome_JOB_OUTCOME_NO_ACCESS:
+		return bookingv1.BookingStatus_BOOKING_STATUS_NO_ACCESS, true
+	case lowribeckv1.JobOutcome_JOB_OUTCOME_RESCHEDULED:
+		return bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED, true
+	default:
+		return bookingv1.BookingStatus_BOOKING_STATUS_UNKNOWN, false
+	}
+}
@@ -0,0 +1,246 @@
+//go:generate mockgen -source=job_outcome.go -destination ./mocks/job_outcome_mocks.go
+
+package consumer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	envelope "github.com/utilitywarehouse/energy-contracts/pkg/generated"
+	bookingv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/booking/v1"
+	lowribeckv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/third_party/lowribeck/v1"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/consumer"
+	mocks "github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/consumer/mocks"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/repository/store"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
+	"github.com/uw-labs/substrate"
+	"github.com/uw-labs/substrate-tools/message"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var errOops = errors.New("oops")
+
+func makeMessage(t *testing.T, eventUUID string, msg proto.Message, occurredAt time.Time) substrate.Message {
+	t.Helper()
+
+	payload, err := anypb.New(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := proto.Marshal(&envelope.Envelope{
+		Uuid:       eventUUID,
+		CreatedAt:  timestamppb.Now(),
+		Message:    payload,
+		OccurredAt: timestamppb.New(occurredAt),
+		Sender: &envelope.Sender{
+			Application: "app",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return message.NewMessage(data)
+}
+
+func Test_JobOutcomeHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	defer ctrl.Finish()
+
+	bookingSt := mocks.NewMockBookingReferenceStore(ctrl)
+	publisher := mocks.NewMockBookingPublisher(ctrl)
+
+	handler := consumer.HandleJobOutcome(bookingSt, publisher)
+
+	occurredAt := time.Date(2023, time.December, 1, 10, 0, 0, 0, time.UTC)
+
+	booking := models.Booking{
+		BookingID:        "booking-id-1",
+		AccountID:        "account-id-1",
+		OccupancyID:      "occupancy-id-1",
+		BookingReference: "booking-reference-1",
+		Status:           bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED,
+	}
+
+	type testSetup struct {
+		description string
+		setup       func(context.Context, *mocks.MockBookingReferenceStore, *mocks.MockBookingPublisher)
+		input       *lowribeckv1.JobOutcomeEvent
+		err         error
+	}
+
+	testCases := []testSetup{
+		{
+			description: "should publish a completed status change for a completed job",
+			input: &lowribeckv1.JobOutcomeEvent{
+				Reference: "booking-reference-1",
+				Outcome:   lowribeckv1.JobOutcome_JOB_OUTCOME_COMPLETED,
+			},
+			setup: func(ctx context.Context, b *mocks.MockBookingReferenceStore, p *mocks.MockBookingPublisher) {
+				b.EXPECT().GetBookingByReference(ctx, "booking-reference-1").Return(booking, nil)
+				p.EXPECT().Sink(ctx, &bookingv1.BookingStatusChangedEvent{
+					BookingId:   "booking-id-1",
+					AccountId:   "account-id-1",
+					OccupancyId: "occupancy-id-1",
+					Status:      bookingv1.BookingStatus_BOOKING_STATUS_COMPLETED,
+				}, occurredAt).Return(nil)
+			},
+		},
+		{
+			description: "should publish an aborted status change with the reason for an aborted job",
+			input: &lowribeckv1.JobOutcomeEvent{
+				Reference: "booking-reference-1",
+				Outcome:   lowribeckv1.JobOutcome_JOB_OUTCOME_ABORTED,
+				Reason:    "unsafe meter position",
+			},
+			setup: func(ctx context.Context, b *mocks.MockBookingReferenceStore, p *mocks.MockBookingPublisher) {
+				b.EXPECT().GetBookingByReference(ctx, "booking-reference-1").Return(booking, nil)
+				p.EXPECT().Sink(ctx, &bookingv1.BookingStatusChangedEvent{
+					BookingId:   "booking-id-1",
+					AccountId:   "account-id-1",
+					OccupancyId: "occupancy-id-1",
+					Status:      bookingv1.BookingStatus_BOOKING_STATUS_ABORTED,
+					Reason:      "unsafe meter position",
+				}, occurredAt).Return(nil)
+			},
+		},
+		{
+			description: "should publish a no access status change for a job without access to the property",
+			input: &lowribeckv1.JobOutcomeEvent{
+				Reference: "booking-reference-1",
+				Outcome:   lowribeckv1.JobOutcome_JOB_OUTCOME_NO_ACCESS,
+			},
+			setup: func(ctx context.Context, b *mocks.MockBookingReferenceStore, p *mocks.MockBookingPublisher) {
+				b.EXPECT().GetBookingByReference(ctx, "booking-reference-1").Return(booking, nil)
+				p.EXPECT().Sink(ctx, &bookingv1.BookingStatusChangedEvent{
+					BookingId:   "booking-id-1",
+					AccountId:   "account-id-1",
+					OccupancyId: "occupancy-id-1",
+					Status:      bookingv1.BookingStatus_BOOKING_STATUS_NO_ACCESS,
+				}, occurredAt).Return(nil)
+			},
+		},
+		{
+			description: "should publish a scheduled status change with the new slot for a job rescheduled by the installer",
+			input: &lowribeckv1.JobOutcomeEvent{
+				Reference: "booking-reference-1",
+				Outcome:   lowribeckv1.JobOutcome_JOB_OUTCOME_RESCHEDULED,
+				Slot: &lowribeckv1.BookingSlot{
+					Date: &date.Date{
+						Year:  2023,
+						Month: 12,
+						Day:   12,
+					},
+					StartTime: 12,
+					EndTime:   16,
+				},
+			},
+			setup: func(ctx context.Context, b *mocks.MockBookingReferenceStore, p *mocks.MockBookingPublisher) {
+				b.EXPECT().GetBookingByReference(ctx, "booking-reference-1").Return(booking, nil)
+				p.EXPECT().Sink(ctx, &bookingv1.BookingStatusChangedEvent{
+					BookingId:   "booking-id-1",
+					AccountId:   "account-id-1",
+					OccupancyId: "occupancy-id-1",
+					Status:      bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED,
+					Slot: &bookingv1.BookingSlot{
+						Date: &date.Date{
+							Year:  2023,
+							Month: 12,
+							Day:   12,
+						},
+						StartTime: 12,
+						EndTime:   16,
+					},
+				}, occurredAt).Return(nil)
+			},
+		},
+		{
+			description: "should publish a scheduled status change without a slot for a rescheduled job without one",
+			input: &lowribeckv1.JobOutcomeEvent{
+				Reference: "booking-reference-1",
+				Outcome:   lowribeckv1.JobOutcome_JOB_OUTCOME_RESCHEDULED,
+			},
+			setup: func(ctx context.Context, b *mocks.MockBookingReferenceStore, p *mocks.MockBookingPublisher) {
+				b.EXPECT().GetBookingByReference(ctx, "booking-reference-1").Return(booking, nil)
+				p.EXPECT().Sink(ctx, &bookingv1.BookingStatusChangedEvent{
+					BookingId:   "booking-id-1",
+					AccountId:   "account-id-1",
+					OccupancyId: "occupancy-id-1",
+					Status:      bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED,
+				}, occurredAt).Return(nil)
+			},
+		},
+		{
+			description: "should skip an unknown job outcome without looking up the booking",
+			input: &lowribeckv1.JobOutcomeEvent{
+				Reference: "booking-reference-1",
+				Outcome:   lowribeckv1.JobOutcome(0),
+			},
+			setup: func(ctx context.Context, b *mocks.MockBookingReferenceStore, p *mocks.MockBookingPublisher) {},
+		},
+		{
+			description: "should skip the job outcome of an unknown booking reference",
+			input: &lowribeckv1.JobOutcomeEvent{
+				Reference: "booking-reference-2",
+				Outcome:   lowribeckv1.JobOutcome_JOB_OUTCOME_COMPLETED,
+			},
+			setup: func(ctx context.Context, b *mocks.MockBookingReferenceStore, p *mocks.MockBookingPublisher) {
+				b.EXPECT().GetBookingByReference(ctx, "booking-reference-2").Return(models.Booking{}, store.ErrBookingNotFound)
+			},
+		},
+		{
+			description: "should fail when the booking cannot be looked up",
+			input: &lowribeckv1.JobOutcomeEvent{
+				Reference: "booking-reference-1",
+				Outcome:   lowribeckv1.JobOutcome_JOB_OUTCOME_COMPLETED,
+			},
+			setup: func(ctx context.Context, b *mocks.MockBookingReferenceStore, p *mocks.MockBookingPublisher) {
+				b.EXPECT().GetBookingByReference(ctx, "booking-reference-1").Return(models.Booking{}, errOops)
+			},
+			err: errOops,
+		},
+		{
+			description: "should fail when the status change cannot be published",
+			input: &lowribeckv1.JobOutcomeEvent{
+				Reference: "booking-reference-1",
+				Outcome:   lowribeckv1.JobOutcome_JOB_OUTCOME_COMPLETED,
+			},
+			setup: func(ctx context.Context, b *mocks.MockBookingReferenceStore, p *mocks.MockBookingPublisher) {
+				b.EXPECT().GetBookingByReference(ctx, "booking-reference-1").Return(booking, nil)
+				p.EXPECT().Sink(ctx, &bookingv1.BookingStatusChangedEvent{
+					BookingId:   "booking-id-1",
+					AccountId:   "account-id-1",
+					OccupancyId: "occupancy-id-1",
+					Status:      bookingv1.BookingStatus_BOOKING_STATUS_COMPLETED,
+				}, occurredAt).Return(errOops)
+			},
+			err: errOops,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+
+			tc.setup(ctx, bookingSt, publisher)
+
+			err := handler.Handle(ctx, makeMessage(t, uuid.New().String(), tc.input, occurredAt))
+
+			if diff := cmp.Diff(err, tc.err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
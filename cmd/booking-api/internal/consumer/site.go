@@ -9,6 +9,7 @@ import (
 	"github.com/utilitywarehouse/energy-contracts/pkg/generated/platform"
 	"github.com/utilitywarehouse/energy-pkg/metrics"
 	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/validation"
 	"github.com/uw-labs/substrate"
 	"google.golang.org/protobuf/proto"
 )
@@ -64,9 +65,15 @@ func (h *SiteHandler) Handle(_ context.Context, message substrate.Message) error
 				return nil
 			}
 
+			postcode, err := models.ParsePostcode(address.GetPostcode())
+			if err != nil && address.GetPostcode() != "" {
+				slog.Warn("site postcode is not a valid UK postcode", "event_uuid", eventUUID, "site_id", ev.GetSiteId(), "postcode", address.GetPostcode())
+				validation.PostcodeParseFailures.WithLabelValues("booking_site").Inc()
+			}
+
 			site := models.Site{
 				SiteID:                  ev.GetSiteId(),
-				Postcode:                address.GetPostcode(),
+				Postcode:                postcode.String(),
 				UPRN:                    address.GetUprn(),
 				BuildingNameNumber:      address.GetBuildingNameNumber(),
 				DependentThoroughfare:   address.GetDependentThoroughfare(),
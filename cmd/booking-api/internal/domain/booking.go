@@ -17,6 +17,8 @@ type BookingDomain struct {
 	eligibilityGw                   EligibilityGateway
 	clickGw                         ClickGateway
 	useTracing                      bool
+	slotHolds                       SlotHoldStore
+	reschedulePolicy                ReschedulePolicy
 }
 
 func NewBookingDomain(accounts AccountGateway,
@@ -43,6 +45,8 @@ func NewBookingDomain(accounts AccountGateway,
 		eligibilityGw,
 		clickGw,
 		useTracing,
+		nil,
+		ReschedulePolicy{},
 	}
 }
 
@@ -10,6 +10,7 @@ import (
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/repository/store"
 	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
 	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var (
@@ -31,7 +32,35 @@ func (d BookingDomain) GetAccountAddressByAccountID(ctx context.Context, account
 		return models.AccountAddress{}, fmt.Errorf("failed to get occupancies by account id, %w", err)
 	}
 
-	address := models.AccountAddress{
+	return siteToAccountAddress(*site), nil
+}
+
+// BookableOccupancy is an occupancy of an account which a smart meter installation can be booked for.
+type BookableOccupancy struct {
+	OccupancyID string
+	SiteAddress models.AccountAddress
+}
+
+// GetBookableOccupancies returns the eligible occupancies of an account, the most recent first.
+func (d BookingDomain) GetBookableOccupancies(ctx context.Context, accountID string) ([]BookableOccupancy, error) {
+	eligibleOccupancies, err := d.occupancyStore.GetEligibleOccupanciesByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get eligible occupancies by account id, %w", err)
+	}
+
+	bookableOccupancies := make([]BookableOccupancy, 0, len(eligibleOccupancies))
+	for _, eligibleOccupancy := range eligibleOccupancies {
+		bookableOccupancies = append(bookableOccupancies, BookableOccupancy{
+			OccupancyID: eligibleOccupancy.Eligibility.OccupancyID,
+			SiteAddress: siteToAccountAddress(eligibleOccupancy.Site),
+		})
+	}
+
+	return bookableOccupancies, nil
+}
+
+func siteToAccountAddress(site models.Site) models.AccountAddress {
+	return models.AccountAddress{
 		UPRN: site.UPRN,
 		PAF: models.PAF{
 			BuildingName:            site.BuildingNameNumber,
@@ -47,8 +76,6 @@ func (d BookingDomain) GetAccountAddressByAccountID(ctx context.Context, account
 			PostTown:                site.Town,
 		},
 	}
-
-	return address, nil
 }
 
 func (d BookingDomain) GetCustomerBookings(ctx context.Context, accountID string) ([]*bookingv1.Booking, error) {
@@ -62,6 +89,11 @@ func (d BookingDomain) GetCustomerBookings(ctx context.Context, accountID string
 		return nil, err
 	}
 
+	statusHistory, err := d.bookingStore.GetStatusHistoryByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
 	contractBookings := make([]*bookingv1.Booking, 0, len(bookingModels))
 	for _, bm := range bookingModels {
 		y, m, d := bm.Slot.Date.Date()
@@ -90,13 +122,28 @@ func (d BookingDomain) GetCustomerBookings(ctx context.Context, accountID string
 				Vulnerabilities: bm.VulnerabilityDetails.Vulnerabilities,
 				Other:           bm.VulnerabilityDetails.Other,
 			},
-			Status: bm.Status,
+			Status:        bm.Status,
+			StatusHistory: toStatusHistory(statusHistory[bm.BookingID]),
 		})
 	}
 
 	return contractBookings, nil
 }
 
+// toStatusHistory maps the status history of a booking, which is empty for the bookings projected
+// before it was recorded.
+func toStatusHistory(changes []models.BookingStatusChange) []*bookingv1.BookingStatusChange {
+	var history []*bookingv1.BookingStatusChange
+	for _, change := range changes {
+		history = append(history, &bookingv1.BookingStatusChange{
+			Status:     change.Status,
+			Reason:     change.Reason,
+			OccurredAt: timestamppb.New(change.OccurredAt),
+		})
+	}
+	return history
+}
+
 func (d BookingDomain) GetCustomerDetailsPointOfSale(ctx context.Context, accountNumber string) (*models.PointOfSaleCustomerDetails, error) {
 	return d.getCustomerDetailsPointOfSale(ctx, accountNumber)
 }
@@ -5,6 +5,7 @@ package domain_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
@@ -16,6 +17,7 @@ import (
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/repository/store"
 	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
 	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func Test_GetCustomerContactDetails(t *testing.T) {
@@ -257,6 +259,7 @@ func Test_GetCustomerBookings(t *testing.T) {
 						},
 					},
 				}, nil)
+				bSt.EXPECT().GetStatusHistoryByAccountID(ctx, "account-id-1").Return(map[string][]models.BookingStatusChange{}, nil)
 				sSt.EXPECT().GetSiteByOccupancyID(ctx, "occupancy-id-1").Return(&models.Site{
 					SiteID:                  "site-id-1",
 					Postcode:                "postcode",
@@ -375,6 +378,19 @@ func Test_GetCustomerBookings(t *testing.T) {
 						},
 					},
 				}, nil)
+				bSt.EXPECT().GetStatusHistoryByAccountID(ctx, "account-id-1").Return(map[string][]models.BookingStatusChange{
+					"booking-id-1": {
+						{
+							Status:     bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED,
+							OccurredAt: time.Date(2023, time.July, 1, 10, 0, 0, 0, time.UTC),
+						},
+						{
+							Status:     bookingv1.BookingStatus_BOOKING_STATUS_COMPLETED,
+							Reason:     "meter installed",
+							OccurredAt: time.Date(2023, time.July, 30, 15, 0, 0, 0, time.UTC),
+						},
+					},
+				}, nil)
 				sSt.EXPECT().GetSiteByOccupancyID(ctx, "occupancy-id-1").Return(&models.Site{
 					SiteID:                  "site-id-1",
 					Postcode:                "postcode-1",
@@ -457,6 +473,17 @@ func Test_GetCustomerBookings(t *testing.T) {
 							Other:           "",
 						},
 						Status: bookingv1.BookingStatus_BOOKING_STATUS_COMPLETED,
+						StatusHistory: []*bookingv1.BookingStatusChange{
+							{
+								Status:     bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED,
+								OccurredAt: timestamppb.New(time.Date(2023, time.July, 1, 10, 0, 0, 0, time.UTC)),
+							},
+							{
+								Status:     bookingv1.BookingStatus_BOOKING_STATUS_COMPLETED,
+								Reason:     "meter installed",
+								OccurredAt: timestamppb.New(time.Date(2023, time.July, 30, 15, 0, 0, 0, time.UTC)),
+							},
+						},
 					},
 					{
 						Id:        "booking-id-2",
@@ -518,7 +545,8 @@ func Test_GetCustomerBookings(t *testing.T) {
 			if diff := cmp.Diff(
 				actual,
 				tc.output.bookings,
-				cmpopts.IgnoreUnexported(bookingv1.Booking{}, addressv1.Address{}, addressv1.Address_PAF{}, bookingv1.ContactDetails{}, bookingv1.BookingSlot{}, bookingv1.VulnerabilityDetails{}, date.Date{}),
+				cmpopts.IgnoreUnexported(bookingv1.Booking{}, addressv1.Address{}, addressv1.Address_PAF{}, bookingv1.ContactDetails{}, bookingv1.BookingSlot{}, bookingv1.VulnerabilityDetails{}, date.Date{},
+					bookingv1.BookingStatusChange{}, timestamppb.Timestamp{}),
 			); diff != "" {
 				t.Fatal(diff)
 			}
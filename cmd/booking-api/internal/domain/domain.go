@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	bookingv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/booking/v1"
 	lowribeckv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/third_party/lowribeck/v1"
@@ -19,7 +20,10 @@ type AccountNumberGateway interface {
 
 type OccupancyStore interface {
 	GetSiteExternalReferenceByAccountID(ctx context.Context, accountID string) (*models.Site, *models.OccupancyEligibility, error)
+	GetSiteExternalReferenceByOccupancyID(ctx context.Context, occupancyID string) (*models.Site, *models.OccupancyEligibility, error)
+	GetEligibleOccupanciesByAccountID(ctx context.Context, accountID string) ([]models.EligibleOccupancy, error)
 	GetOccupancyByAccountID(context.Context, string) (*models.Occupancy, error)
+	GetOccupancyByID(ctx context.Context, occupancyID string) (*models.Occupancy, error)
 }
 
 type SiteStore interface {
@@ -31,10 +35,12 @@ type LowriBeckGateway interface {
 	CreateBooking(ctx context.Context, postcode, reference string, slot models.BookingSlot, contactDetails models.AccountDetails, vulnerabilities []lowribeckv1.Vulnerability, other string) (gateway.CreateBookingResponse, error)
 	GetAvailableSlotsPointOfSale(ctx context.Context, postcode, mpan, mprn string, tariffElectricity, tariffGas lowribeckv1.TariffType) (gateway.AvailableSlotsResponse, error)
 	CreateBookingPointOfSale(ctx context.Context, mpan, mprn string, tariffElectricity, tariffGas lowribeckv1.TariffType, slot models.BookingSlot, contactDetails models.AccountDetails, vulnerabilities []lowribeckv1.Vulnerability, other string, siteAddress models.AccountAddress) (gateway.CreateBookingPointOfSaleResponse, error)
+	CancelBooking(ctx context.Context, reference, reason string) (gateway.CancelBookingResponse, error)
+	UpdateContactDetails(ctx context.Context, reference string, contactDetails models.AccountDetails, vulnerabilities []lowribeckv1.Vulnerability, other string) (gateway.UpdateContactDetailsResponse, error)
 }
 
 type EligibilityGateway interface {
-	GetMeterpointEligibility(ctx context.Context, mpan, mprn, postcode string) (bool, error)
+	GetMeterpointEligibility(ctx context.Context, mpan, mprn, postcode string) (models.MeterpointEligibility, error)
 }
 
 type ClickGateway interface {
@@ -44,6 +50,8 @@ type ClickGateway interface {
 type BookingStore interface {
 	GetBookingByBookingID(ctx context.Context, bookingID string) (models.Booking, error)
 	GetBookingsByAccountID(ctx context.Context, accountID string) ([]models.Booking, error)
+	GetStatusHistoryByAccountID(ctx context.Context, accountID string) (map[string][]models.BookingStatusChange, error)
+	CountReschedules(ctx context.Context, bookingID string) (int, error)
 }
 
 type PartialBookingStore interface {
@@ -55,6 +63,12 @@ type PointOfSaleCustomerDetailsStore interface {
 	Upsert(context.Context, string, models.PointOfSaleCustomerDetails) error
 }
 
+type SlotHoldStore interface {
+	Hold(ctx context.Context, accountID, postcode string, slot models.BookingSlot) (time.Time, error)
+	Release(ctx context.Context, accountID, postcode string, slot models.BookingSlot) error
+	FullyHeld(ctx context.Context, accountID, postcode string, slots []models.BookingSlot) ([]bool, error)
+}
+
 type SmartMeterInterestStore interface {
 	Insert(ctx context.Context, smartMeterInterest models.SmartMeterInterest) error
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	smart "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart/v1"
 	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
 )
 
@@ -16,18 +17,22 @@ type ProcessEligibilityParams struct {
 }
 
 type ProcessEligibilityResult struct {
-	Eligible bool
+	Eligible                     bool
+	ElectricityIneligibleReasons []smart.IneligibleReason
+	GasIneligibleReasons         []smart.IneligibleReason
 }
 
 func (d BookingDomain) ProcessEligibility(ctx context.Context, params ProcessEligibilityParams) (ProcessEligibilityResult, error) {
 
-	eligible, err := d.eligibilityGw.GetMeterpointEligibility(ctx, params.ElecOrderSupplies.MPXN, params.GasOrderSupplies.MPXN, params.Postcode)
+	eligibility, err := d.eligibilityGw.GetMeterpointEligibility(ctx, params.ElecOrderSupplies.MPXN, params.GasOrderSupplies.MPXN, params.Postcode)
 	if err != nil {
 		return ProcessEligibilityResult{}, fmt.Errorf("failed to get meterpoint eligibility, %w", err)
 	}
 
 	return ProcessEligibilityResult{
-		Eligible: eligible,
+		Eligible:                     eligibility.Eligible,
+		ElectricityIneligibleReasons: eligibility.ElectricityIneligibleReasons,
+		GasIneligibleReasons:         eligibility.GasIneligibleReasons,
 	}, nil
 }
 
@@ -43,14 +48,14 @@ type GetClickLinkResult struct {
 
 func (d BookingDomain) GetClickLink(ctx context.Context, params GetClickLinkParams) (GetClickLinkResult, error) {
 
-	eligible, err := d.eligibilityGw.GetMeterpointEligibility(ctx, params.Details.ElecOrderSupplies.MPXN, params.Details.GasOrderSupplies.MPXN, params.Details.Address.PAF.Postcode)
+	eligibility, err := d.eligibilityGw.GetMeterpointEligibility(ctx, params.Details.ElecOrderSupplies.MPXN, params.Details.GasOrderSupplies.MPXN, params.Details.Address.PAF.Postcode)
 	if err != nil {
 		return GetClickLinkResult{}, fmt.Errorf("failed to get meterpoint eligibility for mpan/mprn: (%s/%s), %w", params.Details.ElecOrderSupplies.MPXN, params.Details.GasOrderSupplies.MPXN, err)
 	}
 
-	if !eligible {
+	if !eligibility.Eligible {
 		return GetClickLinkResult{
-			Eligible: eligible,
+			Eligible: false,
 			Link:     "",
 		}, nil
 	}
@@ -70,7 +75,7 @@ func (d BookingDomain) GetClickLink(ctx context.Context, params GetClickLinkPara
 	}
 
 	return GetClickLinkResult{
-		Eligible: eligible,
+		Eligible: true,
 		Link:     link,
 	}, nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	smart "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart/v1"
 	bookingv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/booking/v1"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/domain"
 	mocks "github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/domain/mocks"
@@ -85,7 +86,7 @@ func Test_GetClickLink(t *testing.T) {
 				},
 			},
 			setup: func(ctx context.Context, p *mocks.MockPointOfSaleCustomerDetailsStore, e *mocks.MockEligibilityGateway, c *mocks.MockClickGateway) {
-				e.EXPECT().GetMeterpointEligibility(ctx, "2199996734008", "2724968810", "E2 1Z").Return(true, nil)
+				e.EXPECT().GetMeterpointEligibility(ctx, "2199996734008", "2724968810", "E2 1Z").Return(models.MeterpointEligibility{Eligible: true}, nil)
 
 				p.EXPECT().Upsert(ctx, "1", models.PointOfSaleCustomerDetails{
 					AccountNumber: "1",
@@ -156,3 +157,37 @@ func Test_GetClickLink(t *testing.T) {
 		})
 	}
 }
+
+func Test_ProcessEligibility(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	defer ctrl.Finish()
+	eligbilityGw := mocks.NewMockEligibilityGateway(ctrl)
+
+	myDomain := domain.NewBookingDomain(nil, nil, nil, nil, nil, nil, nil, nil, eligbilityGw, nil, false)
+
+	eligbilityGw.EXPECT().GetMeterpointEligibility(ctx, "2199996734008", "2724968810", "E2 1Z").Return(models.MeterpointEligibility{
+		Eligible:                     false,
+		ElectricityIneligibleReasons: []smart.IneligibleReason{smart.IneligibleReason_INELIGIBLE_REASON_NO_WAN_COVERAGE, smart.IneligibleReason_INELIGIBLE_REASON_ALT_HAN},
+		GasIneligibleReasons:         []smart.IneligibleReason{smart.IneligibleReason_INELIGIBLE_REASON_METER_LARGE_CAPACITY},
+	}, nil)
+
+	expected, err := myDomain.ProcessEligibility(ctx, domain.ProcessEligibilityParams{
+		Postcode:          "E2 1Z",
+		ElecOrderSupplies: models.OrderSupply{MPXN: "2199996734008"},
+		GasOrderSupplies:  models.OrderSupply{MPXN: "2724968810"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(expected, domain.ProcessEligibilityResult{
+		Eligible:                     false,
+		ElectricityIneligibleReasons: []smart.IneligibleReason{smart.IneligibleReason_INELIGIBLE_REASON_NO_WAN_COVERAGE, smart.IneligibleReason_INELIGIBLE_REASON_ALT_HAN},
+		GasIneligibleReasons:         []smart.IneligibleReason{smart.IneligibleReason_INELIGIBLE_REASON_METER_LARGE_CAPACITY},
+	}); diff != "" {
+		t.Fatal(diff)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/google/uuid"
 	addressv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/energy_entities/address/v1"
@@ -27,16 +26,28 @@ var (
 	ErrUnsuccessfulBooking              = errors.New("create booking did not return success")
 	ErrUnsuccessfulPointOfSaleBooking   = errors.New("create booking point of sale did not return success")
 	ErrUnsuccessfulReschedule           = errors.New("reschedule booking did not return success")
+	ErrUnsuccessfulCancellation         = errors.New("cancel booking did not return success")
+	ErrUnsuccessfulContactDetailsUpdate = errors.New("update contact details did not return success")
+	ErrBookingAccountMismatch           = errors.New("booking does not belong to the provided account")
+	ErrBookingAlreadyCancelled          = errors.New("booking is already cancelled")
+	ErrOccupancyAccountMismatch         = errors.New("occupancy does not belong to the provided account")
+	ErrOccupancyNotEligible             = errors.New("occupancy is not eligible for a smart booking")
+	ErrBookingOccupancyMismatch         = errors.New("booking is not for the provided occupancy")
 )
 
 type GetAvailableSlotsParams struct {
 	AccountID string
-	From      *date.Date
-	To        *date.Date
+	// OccupancyID is optional, the most recent eligible occupancy of the account is used when empty
+	OccupancyID string
+	From        *date.Date
+	To          *date.Date
+	Filter      SlotFilter
 }
 
 type CreateBookingParams struct {
-	AccountID            string
+	AccountID string
+	// OccupancyID is optional, the most recent eligible occupancy of the account is used when empty
+	OccupancyID          string
 	ContactDetails       models.AccountDetails
 	Slot                 models.BookingSlot
 	Source               bookingv1.BookingSource
@@ -44,18 +55,36 @@ type CreateBookingParams struct {
 }
 
 type RescheduleBookingParams struct {
-	AccountID            string
-	BookingID            string
+	AccountID string
+	BookingID string
+	// OccupancyID is optional, when provided the booking must be for it
+	OccupancyID          string
 	Source               bookingv1.BookingSource
 	VulnerabilityDetails *bookingv1.VulnerabilityDetails
 	ContactDetails       models.AccountDetails
 	Slot                 models.BookingSlot
 }
 
+type CancelBookingParams struct {
+	AccountID string
+	BookingID string
+	Source    bookingv1.BookingSource
+	Reason    string
+}
+
+type UpdateBookingContactDetailsParams struct {
+	AccountID            string
+	BookingID            string
+	Source               bookingv1.BookingSource
+	ContactDetails       models.AccountDetails
+	VulnerabilityDetails *bookingv1.VulnerabilityDetails
+}
+
 type GetPOSAvailableSlotsParams struct {
 	AccountNumber string
 	From          *date.Date
 	To            *date.Date
+	Filter        SlotFilter
 }
 
 type CreatePOSBookingParams struct {
@@ -97,11 +126,16 @@ type RescheduleBookingResponse struct {
 	BookingEvent proto.Message
 }
 
-func (d BookingDomain) GetAvailableSlots(ctx context.Context, params GetAvailableSlotsParams) (GetAvailableSlotsResponse, error) {
-	fromAsTime := time.Date(int(params.From.Year), time.Month(params.From.Month), int(params.From.Day), 0, 0, 0, 0, time.UTC)
-	toAsTime := time.Date(int(params.To.Year), time.Month(params.To.Month), int(params.To.Day), 0, 0, 0, 0, time.UTC)
+type CancelBookingResponse struct {
+	Event proto.Message
+}
+
+type UpdateBookingContactDetailsResponse struct {
+	Event proto.Message
+}
 
-	site, occupancyEligibility, err := d.findLowriBeckKeys(ctx, params.AccountID)
+func (d BookingDomain) GetAvailableSlots(ctx context.Context, params GetAvailableSlotsParams) (GetAvailableSlotsResponse, error) {
+	site, occupancyEligibility, err := d.findLowriBeckKeys(ctx, params.AccountID, params.OccupancyID)
 	if err != nil {
 		return GetAvailableSlotsResponse{}, fmt.Errorf("failed to find postcode and booking reference, %w", err)
 	}
@@ -111,15 +145,9 @@ func (d BookingDomain) GetAvailableSlots(ctx context.Context, params GetAvailabl
 		return GetAvailableSlotsResponse{}, fmt.Errorf("failed to get available slots, %w", err)
 	}
 
-	targetedSlots := []models.BookingSlot{}
-
-	for _, elem := range slotsResponse.BookingSlots {
-		currentSlotTime := time.Date(elem.Date.Year(), elem.Date.Month(), elem.Date.Day(), 0, 0, 0, 0, time.UTC)
+	bookingSlots := d.withoutFullyHeldSlots(ctx, params.AccountID, site.Postcode, slotsResponse.BookingSlots)
 
-		if currentSlotTime.After(fromAsTime) && currentSlotTime.Before(toAsTime) {
-			targetedSlots = append(targetedSlots, elem)
-		}
-	}
+	targetedSlots := searchSlots(bookingSlots, params.From, params.To, params.Filter)
 
 	if len(targetedSlots) == 0 {
 		return GetAvailableSlotsResponse{
@@ -139,20 +167,28 @@ func (d BookingDomain) CreateBooking(ctx context.Context, params CreateBookingPa
 
 	lbVulnerabilities := mapLowribeckVulnerabilities(params.VulnerabilityDetails.GetVulnerabilities())
 
-	site, occupancyEligibility, err := d.findLowriBeckKeys(ctx, params.AccountID)
+	site, occupancyEligibility, err := d.findLowriBeckKeys(ctx, params.AccountID, params.OccupancyID)
 	if err != nil {
 		return CreateBookingResponse{}, err
 	}
 
+	if err := d.acquireSlot(ctx, params.AccountID, site.Postcode, params.Slot); err != nil {
+		return CreateBookingResponse{}, err
+	}
+
 	response, err := d.lowribeckGw.CreateBooking(ctx, site.Postcode, occupancyEligibility.Reference, params.Slot, params.ContactDetails, lbVulnerabilities, params.VulnerabilityDetails.Other)
 	if err != nil {
+		d.abandonSlot(ctx, params.AccountID, site.Postcode, params.Slot)
 		return CreateBookingResponse{}, fmt.Errorf("failed to create booking, %w", err)
 	}
 
 	if !response.Success {
+		d.abandonSlot(ctx, params.AccountID, site.Postcode, params.Slot)
 		return CreateBookingResponse{}, ErrUnsuccessfulBooking
 	}
 
+	d.consumeSlot(ctx, params.AccountID, site.Postcode, params.Slot)
+
 	bookingID := uuid.New().String()
 
 	event = &bookingv1.BookingCreatedEvent{
@@ -215,6 +251,19 @@ func (d BookingDomain) RescheduleBooking(ctx context.Context, params RescheduleB
 		return RescheduleBookingResponse{}, fmt.Errorf("failed to reschedule booking, %w", err)
 	}
 
+	if params.OccupancyID != "" {
+		if err := d.validateOccupancyAccount(ctx, params.AccountID, params.OccupancyID); err != nil {
+			return RescheduleBookingResponse{}, err
+		}
+		if booking.OccupancyID != params.OccupancyID {
+			return RescheduleBookingResponse{}, ErrBookingOccupancyMismatch
+		}
+	}
+
+	if err := d.checkReschedulePolicy(ctx, booking); err != nil {
+		return RescheduleBookingResponse{}, err
+	}
+
 	site, err := d.siteStore.GetSiteByOccupancyID(ctx, booking.OccupancyID)
 	if err != nil {
 		return RescheduleBookingResponse{}, fmt.Errorf("failed to reschedule booking, %w", err)
@@ -302,10 +351,89 @@ func (d BookingDomain) RescheduleBooking(ctx context.Context, params RescheduleB
 	}, nil
 }
 
-func (d BookingDomain) GetAvailableSlotsPointOfSale(ctx context.Context, params GetPOSAvailableSlotsParams) (GetAvailableSlotsResponse, error) {
-	fromAsTime := time.Date(int(params.From.Year), time.Month(params.From.Month), int(params.From.Day), 0, 0, 0, 0, time.UTC)
-	toAsTime := time.Date(int(params.To.Year), time.Month(params.To.Month), int(params.To.Day), 0, 0, 0, 0, time.UTC)
+func (d BookingDomain) CancelBooking(ctx context.Context, params CancelBookingParams) (CancelBookingResponse, error) {
+
+	booking, err := d.bookingStore.GetBookingByBookingID(ctx, params.BookingID)
+	if err != nil {
+		return CancelBookingResponse{}, fmt.Errorf("failed to cancel booking, %w", err)
+	}
+
+	if booking.AccountID != params.AccountID {
+		return CancelBookingResponse{}, ErrBookingAccountMismatch
+	}
+
+	if booking.Status == bookingv1.BookingStatus_BOOKING_STATUS_CANCELLED {
+		return CancelBookingResponse{}, ErrBookingAlreadyCancelled
+	}
+
+	response, err := d.lowribeckGw.CancelBooking(ctx, booking.BookingReference, params.Reason)
+	if err != nil {
+		return CancelBookingResponse{}, fmt.Errorf("failed to cancel booking, %w", err)
+	}
+
+	if !response.Success {
+		return CancelBookingResponse{}, ErrUnsuccessfulCancellation
+	}
+
+	return CancelBookingResponse{
+		Event: &bookingv1.BookingCancelledEvent{
+			BookingId:     booking.BookingID,
+			AccountId:     booking.AccountID,
+			OccupancyId:   booking.OccupancyID,
+			Status:        bookingv1.BookingStatus_BOOKING_STATUS_CANCELLED,
+			BookingSource: params.Source,
+			Reason:        params.Reason,
+		},
+	}, nil
+}
+
+// UpdateBookingContactDetails changes the contact and vulnerability details of a booking with
+// LowriBeck, the slot of the booking is left as it is.
+func (d BookingDomain) UpdateBookingContactDetails(ctx context.Context, params UpdateBookingContactDetailsParams) (UpdateBookingContactDetailsResponse, error) {
+
+	booking, err := d.bookingStore.GetBookingByBookingID(ctx, params.BookingID)
+	if err != nil {
+		return UpdateBookingContactDetailsResponse{}, fmt.Errorf("failed to update booking contact details, %w", err)
+	}
+
+	if booking.AccountID != params.AccountID {
+		return UpdateBookingContactDetailsResponse{}, ErrBookingAccountMismatch
+	}
+
+	if booking.Status == bookingv1.BookingStatus_BOOKING_STATUS_CANCELLED {
+		return UpdateBookingContactDetailsResponse{}, ErrBookingAlreadyCancelled
+	}
 
+	lbVulnerabilities := mapLowribeckVulnerabilities(params.VulnerabilityDetails.GetVulnerabilities())
+
+	response, err := d.lowribeckGw.UpdateContactDetails(ctx, booking.BookingReference, params.ContactDetails, lbVulnerabilities, params.VulnerabilityDetails.GetOther())
+	if err != nil {
+		return UpdateBookingContactDetailsResponse{}, fmt.Errorf("failed to update booking contact details, %w", err)
+	}
+
+	if !response.Success {
+		return UpdateBookingContactDetailsResponse{}, ErrUnsuccessfulContactDetailsUpdate
+	}
+
+	return UpdateBookingContactDetailsResponse{
+		Event: &bookingv1.BookingContactDetailsUpdatedEvent{
+			BookingId:   booking.BookingID,
+			AccountId:   booking.AccountID,
+			OccupancyId: booking.OccupancyID,
+			ContactDetails: &bookingv1.ContactDetails{
+				Title:     params.ContactDetails.Title,
+				FirstName: params.ContactDetails.FirstName,
+				LastName:  params.ContactDetails.LastName,
+				Phone:     params.ContactDetails.Mobile,
+				Email:     params.ContactDetails.Email,
+			},
+			VulnerabilityDetails: params.VulnerabilityDetails,
+			BookingSource:        params.Source,
+		},
+	}, nil
+}
+
+func (d BookingDomain) GetAvailableSlotsPointOfSale(ctx context.Context, params GetPOSAvailableSlotsParams) (GetAvailableSlotsResponse, error) {
 	customerAccountDetails, err := d.getCustomerDetailsPointOfSale(ctx, params.AccountNumber)
 	if err != nil {
 		return GetAvailableSlotsResponse{}, fmt.Errorf("failed getting available slots, %w", err)
@@ -323,15 +451,7 @@ func (d BookingDomain) GetAvailableSlotsPointOfSale(ctx context.Context, params
 		return GetAvailableSlotsResponse{}, fmt.Errorf("failed to get POS available slots, %w", err)
 	}
 
-	targetedSlots := []models.BookingSlot{}
-
-	for _, elem := range slotsResponse.BookingSlots {
-		currentSlotTime := time.Date(elem.Date.Year(), elem.Date.Month(), elem.Date.Day(), 0, 0, 0, 0, time.UTC)
-
-		if currentSlotTime.After(fromAsTime) && currentSlotTime.Before(toAsTime) {
-			targetedSlots = append(targetedSlots, elem)
-		}
-	}
+	targetedSlots := searchSlots(slotsResponse.BookingSlots, params.From, params.To, params.Filter)
 
 	if len(targetedSlots) == 0 {
 		return GetAvailableSlotsResponse{
@@ -405,8 +525,9 @@ func (d BookingDomain) CreateBookingPointOfSale(ctx context.Context, params Crea
 	}, nil
 }
 
-// this method takes in an accountID and returns the postcode and the booking reference
-func (d *BookingDomain) findLowriBeckKeys(ctx context.Context, accountID string) (_ models.Site, _ models.OccupancyEligibility, err error) {
+// this method takes in an accountID and returns the postcode and the booking reference,
+// of the given occupancy if one is provided or else of the most recent eligible occupancy of the account
+func (d *BookingDomain) findLowriBeckKeys(ctx context.Context, accountID, occupancyID string) (_ models.Site, _ models.OccupancyEligibility, err error) {
 	var span trace.Span
 	if d.useTracing {
 		ctx, span = tracing.Start(ctx, "BookingAPI.BookingDomain.GetSiteExternalReferenceByAccountID")
@@ -414,15 +535,33 @@ func (d *BookingDomain) findLowriBeckKeys(ctx context.Context, accountID string)
 			tracing.RecordError(span, err)
 			span.End()
 		}()
-		span.AddEvent("request", trace.WithAttributes(attribute.String("account.id", accountID)))
+		span.AddEvent("request", trace.WithAttributes(attribute.String("account.id", accountID), attribute.String("occupancy.id", occupancyID)))
 	}
 
-	site, occupancyEligible, err := d.occupancyStore.GetSiteExternalReferenceByAccountID(ctx, accountID)
-	if err != nil {
-		if errors.Is(err, store.ErrNoEligibleOccupancyFound) {
-			return models.Site{}, models.OccupancyEligibility{}, ErrNoEligibleOccupanciesFound
+	var (
+		site              *models.Site
+		occupancyEligible *models.OccupancyEligibility
+	)
+	if occupancyID == "" {
+		site, occupancyEligible, err = d.occupancyStore.GetSiteExternalReferenceByAccountID(ctx, accountID)
+		if err != nil {
+			if errors.Is(err, store.ErrNoEligibleOccupancyFound) {
+				return models.Site{}, models.OccupancyEligibility{}, ErrNoEligibleOccupanciesFound
+			}
+			return models.Site{}, models.OccupancyEligibility{}, fmt.Errorf("failed to get live occupancies by accountID, %w", err)
+		}
+	} else {
+		if err = d.validateOccupancyAccount(ctx, accountID, occupancyID); err != nil {
+			return models.Site{}, models.OccupancyEligibility{}, err
+		}
+
+		site, occupancyEligible, err = d.occupancyStore.GetSiteExternalReferenceByOccupancyID(ctx, occupancyID)
+		if err != nil {
+			if errors.Is(err, store.ErrNoEligibleOccupancyFound) {
+				return models.Site{}, models.OccupancyEligibility{}, ErrOccupancyNotEligible
+			}
+			return models.Site{}, models.OccupancyEligibility{}, fmt.Errorf("failed to get eligible occupancy by occupancyID, %w", err)
 		}
-		return models.Site{}, models.OccupancyEligibility{}, fmt.Errorf("failed to get live occupancies by accountID, %w", err)
 	}
 
 	if d.useTracing {
@@ -434,6 +573,24 @@ func (d *BookingDomain) findLowriBeckKeys(ctx context.Context, accountID string)
 	return *site, *occupancyEligible, nil
 }
 
+// validateOccupancyAccount checks the occupancy belongs to the account, occupancies which
+// don't exist are reported as a mismatch too so that their existence is not disclosed.
+func (d *BookingDomain) validateOccupancyAccount(ctx context.Context, accountID, occupancyID string) error {
+	occupancy, err := d.occupancyStore.GetOccupancyByID(ctx, occupancyID)
+	if err != nil {
+		if errors.Is(err, store.ErrOccupancyNotFound) {
+			return ErrOccupancyAccountMismatch
+		}
+		return fmt.Errorf("failed to get occupancy by id: %s, %w", occupancyID, err)
+	}
+
+	if occupancy.AccountID != accountID {
+		return ErrOccupancyAccountMismatch
+	}
+
+	return nil
+}
+
 func mapLowribeckVulnerabilities(vulnerabilities []bookingv1.Vulnerability) (lbVulnerabilities []lowribeckv1.Vulnerability) {
 	for _, vulnerability := range vulnerabilities {
 		lbVulnerabilities = append(lbVulnerabilities, models.BookingVulnerabilityToLowribeckVulnerability(vulnerability))
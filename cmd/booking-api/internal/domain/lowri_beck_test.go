@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
@@ -209,6 +210,389 @@ func Test_GetAvailableSlots(t *testing.T) {
 				err: domain.ErrNoEligibleOccupanciesFound,
 			},
 		},
+		{
+			description: "should get the available slots of the provided occupancy",
+			input: inputParams{
+				params: domain.GetAvailableSlotsParams{
+					AccountID:   "account-id-1",
+					OccupancyID: "occupancy-id-2",
+					From: &date.Date{
+						Year:  2023,
+						Month: 12,
+						Day:   1,
+					},
+					To: &date.Date{
+						Year:  2023,
+						Month: 12,
+						Day:   30,
+					},
+				},
+			},
+			setup: func(ctx context.Context, oSt *mocks.MockOccupancyStore, lbGw *mocks.MockLowriBeckGateway) {
+
+				oSt.EXPECT().GetOccupancyByID(ctx, "occupancy-id-2").Return(&models.Occupancy{
+					OccupancyID: "occupancy-id-2",
+					SiteID:      "site-id-2",
+					AccountID:   "account-id-1",
+				}, nil)
+
+				oSt.EXPECT().GetSiteExternalReferenceByOccupancyID(ctx, "occupancy-id-2").Return(
+					&models.Site{
+						Postcode: "SW1A 1AA",
+					},
+					&models.OccupancyEligibility{
+						OccupancyID: "occupancy-id-2",
+						Reference:   "booking-reference-2",
+					}, nil)
+
+				lbGw.EXPECT().GetAvailableSlots(ctx, "SW1A 1AA", "booking-reference-2").Return(gateway.AvailableSlotsResponse{
+					BookingSlots: []models.BookingSlot{
+						{
+							Date:      mustDate(t, "2023-12-05"),
+							StartTime: 9,
+							EndTime:   12,
+						},
+					},
+				}, nil)
+			},
+			output: outputParams{
+				output: domain.GetAvailableSlotsResponse{
+					Slots: []models.BookingSlot{
+						{
+							Date:      mustDate(t, "2023-12-05"),
+							StartTime: 9,
+							EndTime:   12,
+						},
+					},
+				},
+				err: nil,
+			},
+		},
+		{
+			description: "should return err ErrOccupancyAccountMismatch when the occupancy belongs to another account",
+			input: inputParams{
+				params: domain.GetAvailableSlotsParams{
+					AccountID:   "account-id-1",
+					OccupancyID: "occupancy-id-3",
+					From: &date.Date{
+						Year:  2023,
+						Month: 12,
+						Day:   1,
+					},
+					To: &date.Date{
+						Year:  2023,
+						Month: 12,
+						Day:   30,
+					},
+				},
+			},
+			setup: func(ctx context.Context, oSt *mocks.MockOccupancyStore, _ *mocks.MockLowriBeckGateway) {
+
+				oSt.EXPECT().GetOccupancyByID(ctx, "occupancy-id-3").Return(&models.Occupancy{
+					OccupancyID: "occupancy-id-3",
+					SiteID:      "site-id-3",
+					AccountID:   "account-id-2",
+				}, nil)
+			},
+			output: outputParams{
+				output: domain.GetAvailableSlotsResponse{},
+				err:    domain.ErrOccupancyAccountMismatch,
+			},
+		},
+		{
+			description: "should return err ErrOccupancyNotEligible when the provided occupancy is not eligible",
+			input: inputParams{
+				params: domain.GetAvailableSlotsParams{
+					AccountID:   "account-id-1",
+					OccupancyID: "occupancy-id-2",
+					From: &date.Date{
+						Year:  2023,
+						Month: 12,
+						Day:   1,
+					},
+					To: &date.Date{
+						Year:  2023,
+						Month: 12,
+						Day:   30,
+					},
+				},
+			},
+			setup: func(ctx context.Context, oSt *mocks.MockOccupancyStore, _ *mocks.MockLowriBeckGateway) {
+
+				oSt.EXPECT().GetOccupancyByID(ctx, "occupancy-id-2").Return(&models.Occupancy{
+					OccupancyID: "occupancy-id-2",
+					SiteID:      "site-id-2",
+					AccountID:   "account-id-1",
+				}, nil)
+
+				oSt.EXPECT().GetSiteExternalReferenceByOccupancyID(ctx, "occupancy-id-2").Return(nil, nil, store.ErrNoEligibleOccupancyFound)
+			},
+			output: outputParams{
+				output: domain.GetAvailableSlotsResponse{},
+				err:    domain.ErrOccupancyNotEligible,
+			},
+		},
+		{
+			description: "should include the From and To days and sort the slots by date and start time",
+			input: inputParams{
+				params: domain.GetAvailableSlotsParams{
+					AccountID: "account-id-1",
+					From: &date.Date{
+						Year:  2023,
+						Month: 12,
+						Day:   1,
+					},
+					To: &date.Date{
+						Year:  2023,
+						Month: 12,
+						Day:   30,
+					},
+				},
+			},
+			setup: func(ctx context.Context, oSt *mocks.MockOccupancyStore, lbGw *mocks.MockLowriBeckGateway) {
+
+				oSt.EXPECT().GetSiteExternalReferenceByAccountID(ctx, "account-id-1").Return(
+					&models.Site{
+						Postcode: "E2 1ZZ",
+					},
+					&models.OccupancyEligibility{
+						OccupancyID: "occupancy-id-1",
+						Reference:   "booking-reference-1",
+					}, nil)
+
+				lbGw.EXPECT().GetAvailableSlots(ctx, "E2 1ZZ", "booking-reference-1").Return(gateway.AvailableSlotsResponse{
+					BookingSlots: []models.BookingSlot{
+						{
+							Date:      mustDate(t, "2023-12-30"),
+							StartTime: 12,
+							EndTime:   16,
+						},
+						{
+							Date:      mustDate(t, "2023-12-05"),
+							StartTime: 12,
+							EndTime:   16,
+						},
+						{
+							Date:      mustDate(t, "2023-12-01"),
+							StartTime: 8,
+							EndTime:   12,
+						},
+						{
+							Date:      mustDate(t, "2023-12-05"),
+							StartTime: 8,
+							EndTime:   12,
+						},
+						{
+							Date:      mustDate(t, "2023-12-04"),
+							StartTime: 12,
+							EndTime:   16,
+						},
+						{
+							Date:      mustDate(t, "2023-12-31"),
+							StartTime: 8,
+							EndTime:   12,
+						},
+					},
+				}, nil)
+			},
+			output: outputParams{
+				output: domain.GetAvailableSlotsResponse{
+					Slots: []models.BookingSlot{
+						{
+							Date:      mustDate(t, "2023-12-01"),
+							StartTime: 8,
+							EndTime:   12,
+						},
+						{
+							Date:      mustDate(t, "2023-12-04"),
+							StartTime: 12,
+							EndTime:   16,
+						},
+						{
+							Date:      mustDate(t, "2023-12-05"),
+							StartTime: 8,
+							EndTime:   12,
+						},
+						{
+							Date:      mustDate(t, "2023-12-05"),
+							StartTime: 12,
+							EndTime:   16,
+						},
+						{
+							Date:      mustDate(t, "2023-12-30"),
+							StartTime: 12,
+							EndTime:   16,
+						},
+					},
+				},
+				err: nil,
+			},
+		},
+		{
+			description: "should only get the afternoon slots on the requested weekdays",
+			input: inputParams{
+				params: domain.GetAvailableSlotsParams{
+					AccountID: "account-id-1",
+					From: &date.Date{
+						Year:  2023,
+						Month: 12,
+						Day:   1,
+					},
+					To: &date.Date{
+						Year:  2023,
+						Month: 12,
+						Day:   30,
+					},
+					Filter: domain.SlotFilter{
+						Weekdays: []time.Weekday{time.Monday, time.Tuesday},
+						Window:   domain.SlotWindowPM,
+					},
+				},
+			},
+			setup: func(ctx context.Context, oSt *mocks.MockOccupancyStore, lbGw *mocks.MockLowriBeckGateway) {
+
+				oSt.EXPECT().GetSiteExternalReferenceByAccountID(ctx, "account-id-1").Return(
+					&models.Site{
+						Postcode: "E2 1ZZ",
+					},
+					&models.OccupancyEligibility{
+						OccupancyID: "occupancy-id-1",
+						Reference:   "booking-reference-1",
+					}, nil)
+
+				lbGw.EXPECT().GetAvailableSlots(ctx, "E2 1ZZ", "booking-reference-1").Return(gateway.AvailableSlotsResponse{
+					BookingSlots: []models.BookingSlot{
+						{
+							Date:      mustDate(t, "2023-12-30"),
+							StartTime: 12,
+							EndTime:   16,
+						},
+						{
+							Date:      mustDate(t, "2023-12-05"),
+							StartTime: 12,
+							EndTime:   16,
+						},
+						{
+							Date:      mustDate(t, "2023-12-01"),
+							StartTime: 8,
+							EndTime:   12,
+						},
+						{
+							Date:      mustDate(t, "2023-12-05"),
+							StartTime: 8,
+							EndTime:   12,
+						},
+						{
+							Date:      mustDate(t, "2023-12-04"),
+							StartTime: 12,
+							EndTime:   16,
+						},
+						{
+							Date:      mustDate(t, "2023-12-31"),
+							StartTime: 8,
+							EndTime:   12,
+						},
+					},
+				}, nil)
+			},
+			output: outputParams{
+				output: domain.GetAvailableSlotsResponse{
+					Slots: []models.BookingSlot{
+						{
+							Date:      mustDate(t, "2023-12-04"),
+							StartTime: 12,
+							EndTime:   16,
+						},
+						{
+							Date:      mustDate(t, "2023-12-05"),
+							StartTime: 12,
+							EndTime:   16,
+						},
+					},
+				},
+				err: nil,
+			},
+		},
+		{
+			description: "should only get the earliest morning slots up to the limit",
+			input: inputParams{
+				params: domain.GetAvailableSlotsParams{
+					AccountID: "account-id-1",
+					From: &date.Date{
+						Year:  2023,
+						Month: 12,
+						Day:   1,
+					},
+					To: &date.Date{
+						Year:  2023,
+						Month: 12,
+						Day:   30,
+					},
+					Filter: domain.SlotFilter{
+						Window: domain.SlotWindowAM,
+						Limit:  1,
+					},
+				},
+			},
+			setup: func(ctx context.Context, oSt *mocks.MockOccupancyStore, lbGw *mocks.MockLowriBeckGateway) {
+
+				oSt.EXPECT().GetSiteExternalReferenceByAccountID(ctx, "account-id-1").Return(
+					&models.Site{
+						Postcode: "E2 1ZZ",
+					},
+					&models.OccupancyEligibility{
+						OccupancyID: "occupancy-id-1",
+						Reference:   "booking-reference-1",
+					}, nil)
+
+				lbGw.EXPECT().GetAvailableSlots(ctx, "E2 1ZZ", "booking-reference-1").Return(gateway.AvailableSlotsResponse{
+					BookingSlots: []models.BookingSlot{
+						{
+							Date:      mustDate(t, "2023-12-30"),
+							StartTime: 12,
+							EndTime:   16,
+						},
+						{
+							Date:      mustDate(t, "2023-12-05"),
+							StartTime: 12,
+							EndTime:   16,
+						},
+						{
+							Date:      mustDate(t, "2023-12-01"),
+							StartTime: 8,
+							EndTime:   12,
+						},
+						{
+							Date:      mustDate(t, "2023-12-05"),
+							StartTime: 8,
+							EndTime:   12,
+						},
+						{
+							Date:      mustDate(t, "2023-12-04"),
+							StartTime: 12,
+							EndTime:   16,
+						},
+						{
+							Date:      mustDate(t, "2023-12-31"),
+							StartTime: 8,
+							EndTime:   12,
+						},
+					},
+				}, nil)
+			},
+			output: outputParams{
+				output: domain.GetAvailableSlotsResponse{
+					Slots: []models.BookingSlot{
+						{
+							Date:      mustDate(t, "2023-12-01"),
+							StartTime: 8,
+							EndTime:   12,
+						},
+					},
+				},
+				err: nil,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1076,6 +1460,396 @@ func Test_RescheduleBooking(t *testing.T) {
 	}
 }
 
+func Test_CancelBooking(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	defer ctrl.Finish()
+
+	lbGw := mocks.NewMockLowriBeckGateway(ctrl)
+	bookingStore := mocks.NewMockBookingStore(ctrl)
+
+	myDomain := domain.NewBookingDomain(nil, nil, lbGw, nil, nil, bookingStore, nil, nil, nil, nil, false)
+
+	type inputParams struct {
+		params domain.CancelBookingParams
+	}
+
+	type outputParams struct {
+		event domain.CancelBookingResponse
+		err   error
+	}
+
+	type testSetup struct {
+		description string
+		setup       func(ctx context.Context, lbGw *mocks.MockLowriBeckGateway, bSt *mocks.MockBookingStore)
+		input       inputParams
+		output      outputParams
+	}
+
+	params := domain.CancelBookingParams{
+		AccountID: "account-id-1",
+		BookingID: "booking-id-1",
+		Source:    bookingv1.BookingSource_BOOKING_SOURCE_PLATFORM_MY_ACCOUNT,
+		Reason:    "customer request",
+	}
+
+	testCases := []testSetup{
+		{
+			description: "should cancel booking",
+			input: inputParams{
+				params: params,
+			},
+			setup: func(ctx context.Context, lbGw *mocks.MockLowriBeckGateway, bSt *mocks.MockBookingStore) {
+				bSt.EXPECT().GetBookingByBookingID(ctx, "booking-id-1").Return(models.Booking{
+					BookingID:        "booking-id-1",
+					AccountID:        "account-id-1",
+					OccupancyID:      "occupancy-id-1",
+					Status:           bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED,
+					BookingReference: "booking-reference-1",
+				}, nil)
+
+				lbGw.EXPECT().CancelBooking(ctx, "booking-reference-1", "customer request").Return(gateway.CancelBookingResponse{
+					Success: true,
+				}, nil)
+			},
+			output: outputParams{
+				event: domain.CancelBookingResponse{
+					Event: &bookingv1.BookingCancelledEvent{
+						BookingId:     "booking-id-1",
+						AccountId:     "account-id-1",
+						OccupancyId:   "occupancy-id-1",
+						Status:        bookingv1.BookingStatus_BOOKING_STATUS_CANCELLED,
+						BookingSource: bookingv1.BookingSource_BOOKING_SOURCE_PLATFORM_MY_ACCOUNT,
+						Reason:        "customer request",
+					},
+				},
+				err: nil,
+			},
+		},
+		{
+			description: "should not cancel a booking that belongs to a different account",
+			input: inputParams{
+				params: params,
+			},
+			setup: func(ctx context.Context, _ *mocks.MockLowriBeckGateway, bSt *mocks.MockBookingStore) {
+				bSt.EXPECT().GetBookingByBookingID(ctx, "booking-id-1").Return(models.Booking{
+					BookingID:        "booking-id-1",
+					AccountID:        "account-id-2",
+					OccupancyID:      "occupancy-id-1",
+					Status:           bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED,
+					BookingReference: "booking-reference-1",
+				}, nil)
+			},
+			output: outputParams{
+				event: domain.CancelBookingResponse{},
+				err:   domain.ErrBookingAccountMismatch,
+			},
+		},
+		{
+			description: "should not cancel a booking that is already cancelled",
+			input: inputParams{
+				params: params,
+			},
+			setup: func(ctx context.Context, _ *mocks.MockLowriBeckGateway, bSt *mocks.MockBookingStore) {
+				bSt.EXPECT().GetBookingByBookingID(ctx, "booking-id-1").Return(models.Booking{
+					BookingID:        "booking-id-1",
+					AccountID:        "account-id-1",
+					OccupancyID:      "occupancy-id-1",
+					Status:           bookingv1.BookingStatus_BOOKING_STATUS_CANCELLED,
+					BookingReference: "booking-reference-1",
+				}, nil)
+			},
+			output: outputParams{
+				event: domain.CancelBookingResponse{},
+				err:   domain.ErrBookingAlreadyCancelled,
+			},
+		},
+		{
+			description: "should return an error when the booking is not found",
+			input: inputParams{
+				params: params,
+			},
+			setup: func(ctx context.Context, _ *mocks.MockLowriBeckGateway, bSt *mocks.MockBookingStore) {
+				bSt.EXPECT().GetBookingByBookingID(ctx, "booking-id-1").Return(models.Booking{}, store.ErrBookingNotFound)
+			},
+			output: outputParams{
+				event: domain.CancelBookingResponse{},
+				err:   store.ErrBookingNotFound,
+			},
+		},
+		{
+			description: "should return an error when lowribeck fails to cancel the booking",
+			input: inputParams{
+				params: params,
+			},
+			setup: func(ctx context.Context, lbGw *mocks.MockLowriBeckGateway, bSt *mocks.MockBookingStore) {
+				bSt.EXPECT().GetBookingByBookingID(ctx, "booking-id-1").Return(models.Booking{
+					BookingID:        "booking-id-1",
+					AccountID:        "account-id-1",
+					OccupancyID:      "occupancy-id-1",
+					Status:           bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED,
+					BookingReference: "booking-reference-1",
+				}, nil)
+
+				lbGw.EXPECT().CancelBooking(ctx, "booking-reference-1", "customer request").Return(gateway.CancelBookingResponse{}, gateway.ErrOutOfRange)
+			},
+			output: outputParams{
+				event: domain.CancelBookingResponse{},
+				err:   gateway.ErrOutOfRange,
+			},
+		},
+		{
+			description: "should return an error when lowribeck does not return success",
+			input: inputParams{
+				params: params,
+			},
+			setup: func(ctx context.Context, lbGw *mocks.MockLowriBeckGateway, bSt *mocks.MockBookingStore) {
+				bSt.EXPECT().GetBookingByBookingID(ctx, "booking-id-1").Return(models.Booking{
+					BookingID:        "booking-id-1",
+					AccountID:        "account-id-1",
+					OccupancyID:      "occupancy-id-1",
+					Status:           bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED,
+					BookingReference: "booking-reference-1",
+				}, nil)
+
+				lbGw.EXPECT().CancelBooking(ctx, "booking-reference-1", "customer request").Return(gateway.CancelBookingResponse{
+					Success: false,
+				}, nil)
+			},
+			output: outputParams{
+				event: domain.CancelBookingResponse{},
+				err:   domain.ErrUnsuccessfulCancellation,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+
+			tc.setup(ctx, lbGw, bookingStore)
+
+			actual, err := myDomain.CancelBooking(ctx, tc.input.params)
+
+			if tc.output.err != nil {
+				if !errors.Is(err, tc.output.err) {
+					t.Fatalf("expected: %s, actual: %s", tc.output.err, err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(actual, tc.output.event, cmpopts.IgnoreUnexported(bookingv1.BookingCancelledEvent{})); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func Test_UpdateBookingContactDetails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	defer ctrl.Finish()
+
+	lbGw := mocks.NewMockLowriBeckGateway(ctrl)
+	bookingStore := mocks.NewMockBookingStore(ctrl)
+
+	myDomain := domain.NewBookingDomain(nil, nil, lbGw, nil, nil, bookingStore, nil, nil, nil, nil, false)
+
+	type inputParams struct {
+		params domain.UpdateBookingContactDetailsParams
+	}
+
+	type outputParams struct {
+		event domain.UpdateBookingContactDetailsResponse
+		err   error
+	}
+
+	type testSetup struct {
+		description string
+		setup       func(ctx context.Context, lbGw *mocks.MockLowriBeckGateway, bSt *mocks.MockBookingStore)
+		input       inputParams
+		output      outputParams
+	}
+
+	contactDetails := models.AccountDetails{
+		Title:     "Mr",
+		FirstName: "John",
+		LastName:  "Doe",
+		Email:     "jdoe@example.com",
+		Mobile:    "555-0145",
+	}
+
+	vulnerabilityDetails := &bookingv1.VulnerabilityDetails{
+		Vulnerabilities: []bookingv1.Vulnerability{
+			bookingv1.Vulnerability_VULNERABILITY_FOREIGN_LANGUAGE_ONLY,
+		},
+		Other: "Bad Knee",
+	}
+
+	params := domain.UpdateBookingContactDetailsParams{
+		AccountID:            "account-id-1",
+		BookingID:            "booking-id-1",
+		Source:               bookingv1.BookingSource_BOOKING_SOURCE_PLATFORM_MY_ACCOUNT,
+		ContactDetails:       contactDetails,
+		VulnerabilityDetails: vulnerabilityDetails,
+	}
+
+	scheduledBooking := models.Booking{
+		BookingID:        "booking-id-1",
+		AccountID:        "account-id-1",
+		OccupancyID:      "occupancy-id-1",
+		Status:           bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED,
+		BookingReference: "booking-reference-1",
+	}
+
+	lbVulnerabilities := []lowribeckv1.Vulnerability{
+		lowribeckv1.Vulnerability_VULNERABILITY_FOREIGN_LANGUAGE_ONLY,
+	}
+
+	testCases := []testSetup{
+		{
+			description: "should update the contact details of a booking",
+			input: inputParams{
+				params: params,
+			},
+			setup: func(ctx context.Context, lbGw *mocks.MockLowriBeckGateway, bSt *mocks.MockBookingStore) {
+				bSt.EXPECT().GetBookingByBookingID(ctx, "booking-id-1").Return(scheduledBooking, nil)
+
+				lbGw.EXPECT().UpdateContactDetails(ctx, "booking-reference-1", contactDetails, lbVulnerabilities, "Bad Knee").Return(gateway.UpdateContactDetailsResponse{
+					Success: true,
+				}, nil)
+			},
+			output: outputParams{
+				event: domain.UpdateBookingContactDetailsResponse{
+					Event: &bookingv1.BookingContactDetailsUpdatedEvent{
+						BookingId:   "booking-id-1",
+						AccountId:   "account-id-1",
+						OccupancyId: "occupancy-id-1",
+						ContactDetails: &bookingv1.ContactDetails{
+							Title:     "Mr",
+							FirstName: "John",
+							LastName:  "Doe",
+							Phone:     "555-0145",
+							Email:     "jdoe@example.com",
+						},
+						VulnerabilityDetails: vulnerabilityDetails,
+						BookingSource:        bookingv1.BookingSource_BOOKING_SOURCE_PLATFORM_MY_ACCOUNT,
+					},
+				},
+				err: nil,
+			},
+		},
+		{
+			description: "should not update a booking that belongs to a different account",
+			input: inputParams{
+				params: params,
+			},
+			setup: func(ctx context.Context, _ *mocks.MockLowriBeckGateway, bSt *mocks.MockBookingStore) {
+				bSt.EXPECT().GetBookingByBookingID(ctx, "booking-id-1").Return(models.Booking{
+					BookingID:        "booking-id-1",
+					AccountID:        "account-id-2",
+					OccupancyID:      "occupancy-id-1",
+					Status:           bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED,
+					BookingReference: "booking-reference-1",
+				}, nil)
+			},
+			output: outputParams{
+				event: domain.UpdateBookingContactDetailsResponse{},
+				err:   domain.ErrBookingAccountMismatch,
+			},
+		},
+		{
+			description: "should not update a booking that is cancelled",
+			input: inputParams{
+				params: params,
+			},
+			setup: func(ctx context.Context, _ *mocks.MockLowriBeckGateway, bSt *mocks.MockBookingStore) {
+				bSt.EXPECT().GetBookingByBookingID(ctx, "booking-id-1").Return(models.Booking{
+					BookingID:        "booking-id-1",
+					AccountID:        "account-id-1",
+					OccupancyID:      "occupancy-id-1",
+					Status:           bookingv1.BookingStatus_BOOKING_STATUS_CANCELLED,
+					BookingReference: "booking-reference-1",
+				}, nil)
+			},
+			output: outputParams{
+				event: domain.UpdateBookingContactDetailsResponse{},
+				err:   domain.ErrBookingAlreadyCancelled,
+			},
+		},
+		{
+			description: "should return an error when the booking is not found",
+			input: inputParams{
+				params: params,
+			},
+			setup: func(ctx context.Context, _ *mocks.MockLowriBeckGateway, bSt *mocks.MockBookingStore) {
+				bSt.EXPECT().GetBookingByBookingID(ctx, "booking-id-1").Return(models.Booking{}, store.ErrBookingNotFound)
+			},
+			output: outputParams{
+				event: domain.UpdateBookingContactDetailsResponse{},
+				err:   store.ErrBookingNotFound,
+			},
+		},
+		{
+			description: "should return an error when lowribeck fails to update the contact details",
+			input: inputParams{
+				params: params,
+			},
+			setup: func(ctx context.Context, lbGw *mocks.MockLowriBeckGateway, bSt *mocks.MockBookingStore) {
+				bSt.EXPECT().GetBookingByBookingID(ctx, "booking-id-1").Return(scheduledBooking, nil)
+
+				lbGw.EXPECT().UpdateContactDetails(ctx, "booking-reference-1", contactDetails, lbVulnerabilities, "Bad Knee").Return(gateway.UpdateContactDetailsResponse{}, gateway.ErrNotFound)
+			},
+			output: outputParams{
+				event: domain.UpdateBookingContactDetailsResponse{},
+				err:   gateway.ErrNotFound,
+			},
+		},
+		{
+			description: "should return an error when lowribeck does not return success",
+			input: inputParams{
+				params: params,
+			},
+			setup: func(ctx context.Context, lbGw *mocks.MockLowriBeckGateway, bSt *mocks.MockBookingStore) {
+				bSt.EXPECT().GetBookingByBookingID(ctx, "booking-id-1").Return(scheduledBooking, nil)
+
+				lbGw.EXPECT().UpdateContactDetails(ctx, "booking-reference-1", contactDetails, lbVulnerabilities, "Bad Knee").Return(gateway.UpdateContactDetailsResponse{
+					Success: false,
+				}, nil)
+			},
+			output: outputParams{
+				event: domain.UpdateBookingContactDetailsResponse{},
+				err:   domain.ErrUnsuccessfulContactDetailsUpdate,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+
+			tc.setup(ctx, lbGw, bookingStore)
+
+			actual, err := myDomain.UpdateBookingContactDetails(ctx, tc.input.params)
+
+			if tc.output.err != nil {
+				if !errors.Is(err, tc.output.err) {
+					t.Fatalf("expected: %s, actual: %s", tc.output.err, err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(actual, tc.output.event, cmpopts.IgnoreUnexported(bookingv1.BookingContactDetailsUpdatedEvent{}, bookingv1.ContactDetails{}, bookingv1.VulnerabilityDetails{})); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
 // Point Of Sale Journey
 func Test_GetPOSAvailableSlots(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -7,6 +7,7 @@ package mock_domain
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	bookingv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/booking/v1"
@@ -114,6 +115,21 @@ func (m *MockOccupancyStore) EXPECT() *MockOccupancyStoreMockRecorder {
 	return m.recorder
 }
 
+// GetEligibleOccupanciesByAccountID mocks base method.
+func (m *MockOccupancyStore) GetEligibleOccupanciesByAccountID(ctx context.Context, accountID string) ([]models.EligibleOccupancy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEligibleOccupanciesByAccountID", ctx, accountID)
+	ret0, _ := ret[0].([]models.EligibleOccupancy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEligibleOccupanciesByAccountID indicates an expected call of GetEligibleOccupanciesByAccountID.
+func (mr *MockOccupancyStoreMockRecorder) GetEligibleOccupanciesByAccountID(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEligibleOccupanciesByAccountID", reflect.TypeOf((*MockOccupancyStore)(nil).GetEligibleOccupanciesByAccountID), ctx, accountID)
+}
+
 // GetOccupancyByAccountID mocks base method.
 func (m *MockOccupancyStore) GetOccupancyByAccountID(arg0 context.Context, arg1 string) (*models.Occupancy, error) {
 	m.ctrl.T.Helper()
@@ -129,6 +145,21 @@ func (mr *MockOccupancyStoreMockRecorder) GetOccupancyByAccountID(arg0, arg1 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOccupancyByAccountID", reflect.TypeOf((*MockOccupancyStore)(nil).GetOccupancyByAccountID), arg0, arg1)
 }
 
+// GetOccupancyByID mocks base method.
+func (m *MockOccupancyStore) GetOccupancyByID(ctx context.Context, occupancyID string) (*models.Occupancy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOccupancyByID", ctx, occupancyID)
+	ret0, _ := ret[0].(*models.Occupancy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOccupancyByID indicates an expected call of GetOccupancyByID.
+func (mr *MockOccupancyStoreMockRecorder) GetOccupancyByID(ctx, occupancyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOccupancyByID", reflect.TypeOf((*MockOccupancyStore)(nil).GetOccupancyByID), ctx, occupancyID)
+}
+
 // GetSiteExternalReferenceByAccountID mocks base method.
 func (m *MockOccupancyStore) GetSiteExternalReferenceByAccountID(ctx context.Context, accountID string) (*models.Site, *models.OccupancyEligibility, error) {
 	m.ctrl.T.Helper()
@@ -145,6 +176,22 @@ func (mr *MockOccupancyStoreMockRecorder) GetSiteExternalReferenceByAccountID(ct
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteExternalReferenceByAccountID", reflect.TypeOf((*MockOccupancyStore)(nil).GetSiteExternalReferenceByAccountID), ctx, accountID)
 }
 
+// GetSiteExternalReferenceByOccupancyID mocks base method.
+func (m *MockOccupancyStore) GetSiteExternalReferenceByOccupancyID(ctx context.Context, occupancyID string) (*models.Site, *models.OccupancyEligibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiteExternalReferenceByOccupancyID", ctx, occupancyID)
+	ret0, _ := ret[0].(*models.Site)
+	ret1, _ := ret[1].(*models.OccupancyEligibility)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSiteExternalReferenceByOccupancyID indicates an expected call of GetSiteExternalReferenceByOccupancyID.
+func (mr *MockOccupancyStoreMockRecorder) GetSiteExternalReferenceByOccupancyID(ctx, occupancyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiteExternalReferenceByOccupancyID", reflect.TypeOf((*MockOccupancyStore)(nil).GetSiteExternalReferenceByOccupancyID), ctx, occupancyID)
+}
+
 // MockSiteStore is a mock of SiteStore interface.
 type MockSiteStore struct {
 	ctrl     *gomock.Controller
@@ -206,6 +253,21 @@ func (m *MockLowriBeckGateway) EXPECT() *MockLowriBeckGatewayMockRecorder {
 	return m.recorder
 }
 
+// CancelBooking mocks base method.
+func (m *MockLowriBeckGateway) CancelBooking(ctx context.Context, reference, reason string) (gateway.CancelBookingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelBooking", ctx, reference, reason)
+	ret0, _ := ret[0].(gateway.CancelBookingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelBooking indicates an expected call of CancelBooking.
+func (mr *MockLowriBeckGatewayMockRecorder) CancelBooking(ctx, reference, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelBooking", reflect.TypeOf((*MockLowriBeckGateway)(nil).CancelBooking), ctx, reference, reason)
+}
+
 // CreateBooking mocks base method.
 func (m *MockLowriBeckGateway) CreateBooking(ctx context.Context, postcode, reference string, slot models.BookingSlot, contactDetails models.AccountDetails, vulnerabilities []lowribeckv1.Vulnerability, other string) (gateway.CreateBookingResponse, error) {
 	m.ctrl.T.Helper()
@@ -266,6 +328,21 @@ func (mr *MockLowriBeckGatewayMockRecorder) GetAvailableSlotsPointOfSale(ctx, po
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailableSlotsPointOfSale", reflect.TypeOf((*MockLowriBeckGateway)(nil).GetAvailableSlotsPointOfSale), ctx, postcode, mpan, mprn, tariffElectricity, tariffGas)
 }
 
+// UpdateContactDetails mocks base method.
+func (m *MockLowriBeckGateway) UpdateContactDetails(ctx context.Context, reference string, contactDetails models.AccountDetails, vulnerabilities []lowribeckv1.Vulnerability, other string) (gateway.UpdateContactDetailsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateContactDetails", ctx, reference, contactDetails, vulnerabilities, other)
+	ret0, _ := ret[0].(gateway.UpdateContactDetailsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateContactDetails indicates an expected call of UpdateContactDetails.
+func (mr *MockLowriBeckGatewayMockRecorder) UpdateContactDetails(ctx, reference, contactDetails, vulnerabilities, other interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContactDetails", reflect.TypeOf((*MockLowriBeckGateway)(nil).UpdateContactDetails), ctx, reference, contactDetails, vulnerabilities, other)
+}
+
 // MockEligibilityGateway is a mock of EligibilityGateway interface.
 type MockEligibilityGateway struct {
 	ctrl     *gomock.Controller
@@ -290,10 +367,10 @@ func (m *MockEligibilityGateway) EXPECT() *MockEligibilityGatewayMockRecorder {
 }
 
 // GetMeterpointEligibility mocks base method.
-func (m *MockEligibilityGateway) GetMeterpointEligibility(ctx context.Context, mpan, mprn, postcode string) (bool, error) {
+func (m *MockEligibilityGateway) GetMeterpointEligibility(ctx context.Context, mpan, mprn, postcode string) (models.MeterpointEligibility, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetMeterpointEligibility", ctx, mpan, mprn, postcode)
-	ret0, _ := ret[0].(bool)
+	ret0, _ := ret[0].(models.MeterpointEligibility)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -365,6 +442,21 @@ func (m *MockBookingStore) EXPECT() *MockBookingStoreMockRecorder {
 	return m.recorder
 }
 
+// CountReschedules mocks base method.
+func (m *MockBookingStore) CountReschedules(ctx context.Context, bookingID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountReschedules", ctx, bookingID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountReschedules indicates an expected call of CountReschedules.
+func (mr *MockBookingStoreMockRecorder) CountReschedules(ctx, bookingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountReschedules", reflect.TypeOf((*MockBookingStore)(nil).CountReschedules), ctx, bookingID)
+}
+
 // GetBookingByBookingID mocks base method.
 func (m *MockBookingStore) GetBookingByBookingID(ctx context.Context, bookingID string) (models.Booking, error) {
 	m.ctrl.T.Helper()
@@ -395,6 +487,21 @@ func (mr *MockBookingStoreMockRecorder) GetBookingsByAccountID(ctx, accountID in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingsByAccountID", reflect.TypeOf((*MockBookingStore)(nil).GetBookingsByAccountID), ctx, accountID)
 }
 
+// GetStatusHistoryByAccountID mocks base method.
+func (m *MockBookingStore) GetStatusHistoryByAccountID(ctx context.Context, accountID string) (map[string][]models.BookingStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatusHistoryByAccountID", ctx, accountID)
+	ret0, _ := ret[0].(map[string][]models.BookingStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatusHistoryByAccountID indicates an expected call of GetStatusHistoryByAccountID.
+func (mr *MockBookingStoreMockRecorder) GetStatusHistoryByAccountID(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatusHistoryByAccountID", reflect.TypeOf((*MockBookingStore)(nil).GetStatusHistoryByAccountID), ctx, accountID)
+}
+
 // MockPartialBookingStore is a mock of PartialBookingStore interface.
 type MockPartialBookingStore struct {
 	ctrl     *gomock.Controller
@@ -484,6 +591,73 @@ func (mr *MockPointOfSaleCustomerDetailsStoreMockRecorder) Upsert(arg0, arg1, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockPointOfSaleCustomerDetailsStore)(nil).Upsert), arg0, arg1, arg2)
 }
 
+// MockSlotHoldStore is a mock of SlotHoldStore interface.
+type MockSlotHoldStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockSlotHoldStoreMockRecorder
+}
+
+// MockSlotHoldStoreMockRecorder is the mock recorder for MockSlotHoldStore.
+type MockSlotHoldStoreMockRecorder struct {
+	mock *MockSlotHoldStore
+}
+
+// NewMockSlotHoldStore creates a new mock instance.
+func NewMockSlotHoldStore(ctrl *gomock.Controller) *MockSlotHoldStore {
+	mock := &MockSlotHoldStore{ctrl: ctrl}
+	mock.recorder = &MockSlotHoldStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSlotHoldStore) EXPECT() *MockSlotHoldStoreMockRecorder {
+	return m.recorder
+}
+
+// FullyHeld mocks base method.
+func (m *MockSlotHoldStore) FullyHeld(ctx context.Context, accountID, postcode string, slots []models.BookingSlot) ([]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FullyHeld", ctx, accountID, postcode, slots)
+	ret0, _ := ret[0].([]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FullyHeld indicates an expected call of FullyHeld.
+func (mr *MockSlotHoldStoreMockRecorder) FullyHeld(ctx, accountID, postcode, slots interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FullyHeld", reflect.TypeOf((*MockSlotHoldStore)(nil).FullyHeld), ctx, accountID, postcode, slots)
+}
+
+// Hold mocks base method.
+func (m *MockSlotHoldStore) Hold(ctx context.Context, accountID, postcode string, slot models.BookingSlot) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hold", ctx, accountID, postcode, slot)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hold indicates an expected call of Hold.
+func (mr *MockSlotHoldStoreMockRecorder) Hold(ctx, accountID, postcode, slot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hold", reflect.TypeOf((*MockSlotHoldStore)(nil).Hold), ctx, accountID, postcode, slot)
+}
+
+// Release mocks base method.
+func (m *MockSlotHoldStore) Release(ctx context.Context, accountID, postcode string, slot models.BookingSlot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, accountID, postcode, slot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockSlotHoldStoreMockRecorder) Release(ctx, accountID, postcode, slot interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockSlotHoldStore)(nil).Release), ctx, accountID, postcode, slot)
+}
+
 // MockSmartMeterInterestStore is a mock of SmartMeterInterestStore interface.
 type MockSmartMeterInterestStore struct {
 	ctrl     *gomock.Controller
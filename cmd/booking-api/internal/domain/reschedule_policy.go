@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	_ "time/tzdata" // the slots are in UK time, the images have no time zone database
+
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
+)
+
+var (
+	ErrRescheduleNoticeTooShort = errors.New("booking is within the minimum notice for a reschedule")
+	ErrRescheduleLimitReached   = errors.New("booking reached the maximum number of reschedules")
+)
+
+var ukLocation = mustLoadLocation("Europe/London")
+
+// ReschedulePolicy restricts the reschedules LowriBeck would reject, a zero value disables each
+// of the restrictions.
+type ReschedulePolicy struct {
+	// MinimumNotice is the shortest time before the start of the booked slot at which the booking
+	// can still be rescheduled.
+	MinimumNotice time.Duration
+	// MaxReschedules is the number of times a booking can be rescheduled by the customer.
+	MaxReschedules int
+}
+
+// WithReschedulePolicy makes RescheduleBooking check the policy before calling LowriBeck.
+func (d BookingDomain) WithReschedulePolicy(policy ReschedulePolicy) BookingDomain {
+	d.reschedulePolicy = policy
+	return d
+}
+
+func (d BookingDomain) checkReschedulePolicy(ctx context.Context, booking models.Booking) error {
+	if notice := d.reschedulePolicy.MinimumNotice; notice > 0 {
+		if time.Until(slotStart(booking.Slot)) < notice {
+			return ErrRescheduleNoticeTooShort
+		}
+	}
+
+	if maxReschedules := d.reschedulePolicy.MaxReschedules; maxReschedules > 0 {
+		reschedules, err := d.bookingStore.CountReschedules(ctx, booking.BookingID)
+		if err != nil {
+			return fmt.Errorf("failed to count reschedules of booking %s, %w", booking.BookingID, err)
+		}
+		if reschedules >= maxReschedules {
+			return ErrRescheduleLimitReached
+		}
+	}
+
+	return nil
+}
+
+// slotStart is the time at which the installer's visit window opens.
+func slotStart(slot models.BookingSlot) time.Time {
+	return time.Date(slot.Date.Year(), slot.Date.Month(), slot.Date.Day(), slot.StartTime, 0, 0, 0, ukLocation)
+}
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
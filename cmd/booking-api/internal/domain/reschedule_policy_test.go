@@ -0,0 +1,86 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	bookingv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/booking/v1"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/domain"
+	mocks "github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/domain/mocks"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/repository/gateway"
+)
+
+func Test_RescheduleBooking_WithReschedulePolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	defer ctrl.Finish()
+
+	lbGw := mocks.NewMockLowriBeckGateway(ctrl)
+	bookingSt := mocks.NewMockBookingStore(ctrl)
+	siteSt := mocks.NewMockSiteStore(ctrl)
+
+	myDomain := domain.NewBookingDomain(nil, nil, lbGw, nil, siteSt, bookingSt, nil, nil, nil, nil, false).
+		WithReschedulePolicy(domain.ReschedulePolicy{
+			MinimumNotice:  48 * time.Hour,
+			MaxReschedules: 2,
+		})
+
+	bookedIn := func(days int) models.Booking {
+		return models.Booking{
+			BookingID:        "booking-id-1",
+			AccountID:        "account-id-1",
+			OccupancyID:      "occupancy-id-1",
+			BookingReference: "booking-reference-1",
+			Status:           bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED,
+			Slot: models.BookingSlot{
+				Date:      time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, days),
+				StartTime: 8,
+				EndTime:   12,
+			},
+		}
+	}
+
+	params := domain.RescheduleBookingParams{
+		AccountID:            "account-id-1",
+		BookingID:            "booking-id-1",
+		Slot:                 models.BookingSlot{Date: time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 1, 0), StartTime: 12, EndTime: 16},
+		Source:               bookingv1.BookingSource_BOOKING_SOURCE_PLATFORM_APP,
+		VulnerabilityDetails: &bookingv1.VulnerabilityDetails{},
+	}
+
+	t.Run("should not reschedule a booking within the minimum notice", func(t *testing.T) {
+		bookingSt.EXPECT().GetBookingByBookingID(ctx, "booking-id-1").Return(bookedIn(1), nil)
+
+		if _, err := myDomain.RescheduleBooking(ctx, params); !errors.Is(err, domain.ErrRescheduleNoticeTooShort) {
+			t.Fatalf("expected error %v, got %v", domain.ErrRescheduleNoticeTooShort, err)
+		}
+	})
+
+	t.Run("should not reschedule a booking rescheduled as many times as allowed", func(t *testing.T) {
+		bookingSt.EXPECT().GetBookingByBookingID(ctx, "booking-id-1").Return(bookedIn(10), nil)
+		bookingSt.EXPECT().CountReschedules(ctx, "booking-id-1").Return(2, nil)
+
+		if _, err := myDomain.RescheduleBooking(ctx, params); !errors.Is(err, domain.ErrRescheduleLimitReached) {
+			t.Fatalf("expected error %v, got %v", domain.ErrRescheduleLimitReached, err)
+		}
+	})
+
+	t.Run("should reschedule a booking within the policy", func(t *testing.T) {
+		gomock.InOrder(
+			bookingSt.EXPECT().GetBookingByBookingID(ctx, "booking-id-1").Return(bookedIn(10), nil),
+			bookingSt.EXPECT().CountReschedules(ctx, "booking-id-1").Return(1, nil),
+			siteSt.EXPECT().GetSiteByOccupancyID(ctx, "occupancy-id-1").Return(&models.Site{Postcode: "E2 1ZZ"}, nil),
+			lbGw.EXPECT().CreateBooking(ctx, "E2 1ZZ", "booking-reference-1", params.Slot, params.ContactDetails, gomock.Any(), "").Return(gateway.CreateBookingResponse{Success: true}, nil),
+		)
+
+		if _, err := myDomain.RescheduleBooking(ctx, params); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/repository/store"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
+)
+
+var (
+	ErrSlotUnavailable       = errors.New("slot is held by other customers")
+	ErrSlotHoldsNotSupported = errors.New("slot holds are not enabled")
+)
+
+type HoldSlotParams struct {
+	AccountID string
+	// OccupancyID is optional, the most recent eligible occupancy of the account is used when empty
+	OccupancyID string
+	Slot        models.BookingSlot
+}
+
+type HoldSlotResponse struct {
+	ExpiresAt time.Time
+}
+
+// WithSlotHolds enables holding slots between GetAvailableSlots and CreateBooking, the slots held
+// by as many other customers as their capacity are no longer offered.
+func (d BookingDomain) WithSlotHolds(slotHolds SlotHoldStore) BookingDomain {
+	d.slotHolds = slotHolds
+	return d
+}
+
+// HoldSlot holds a slot for the account until it is booked or the hold expires, an account holds
+// a single slot at a time.
+func (d BookingDomain) HoldSlot(ctx context.Context, params HoldSlotParams) (HoldSlotResponse, error) {
+	if d.slotHolds == nil {
+		return HoldSlotResponse{}, ErrSlotHoldsNotSupported
+	}
+
+	site, _, err := d.findLowriBeckKeys(ctx, params.AccountID, params.OccupancyID)
+	if err != nil {
+		return HoldSlotResponse{}, fmt.Errorf("failed to find postcode and booking reference, %w", err)
+	}
+
+	expiresAt, err := d.slotHolds.Hold(ctx, params.AccountID, site.Postcode, params.Slot)
+	if err != nil {
+		if errors.Is(err, store.ErrSlotFullyHeld) {
+			return HoldSlotResponse{}, ErrSlotUnavailable
+		}
+		return HoldSlotResponse{}, fmt.Errorf("failed to hold slot, %w", err)
+	}
+
+	return HoldSlotResponse{ExpiresAt: expiresAt}, nil
+}
+
+// withoutFullyHeldSlots drops the slots other customers hold up to their capacity. The holds only
+// lower the chances of a booking failing, so the slots are all offered if they can't be checked.
+func (d BookingDomain) withoutFullyHeldSlots(ctx context.Context, accountID, postcode string, slots []models.BookingSlot) []models.BookingSlot {
+	if d.slotHolds == nil || len(slots) == 0 {
+		return slots
+	}
+
+	fullyHeld, err := d.slotHolds.FullyHeld(ctx, accountID, postcode, slots)
+	if err != nil {
+		slog.Warn("failed to check slot holds, offering all the slots", "account_id", accountID, "error", err)
+		return slots
+	}
+
+	available := make([]models.BookingSlot, 0, len(slots))
+	for i, slot := range slots {
+		if !fullyHeld[i] {
+			available = append(available, slot)
+		}
+	}
+
+	return available
+}
+
+// acquireSlot makes sure the account holds the slot it is booking, holding it if it didn't yet.
+func (d BookingDomain) acquireSlot(ctx context.Context, accountID, postcode string, slot models.BookingSlot) error {
+	if d.slotHolds == nil {
+		return nil
+	}
+
+	if _, err := d.slotHolds.Hold(ctx, accountID, postcode, slot); err != nil {
+		if errors.Is(err, store.ErrSlotFullyHeld) {
+			return ErrSlotUnavailable
+		}
+		slog.Warn("failed to hold slot, booking it anyway", "account_id", accountID, "error", err)
+	}
+
+	return nil
+}
+
+// consumeSlot releases the hold of a booked slot, its capacity is now taken by the booking.
+func (d BookingDomain) consumeSlot(ctx context.Context, accountID, postcode string, slot models.BookingSlot) {
+	d.releaseSlot(ctx, accountID, postcode, slot, "booked")
+}
+
+// abandonSlot releases the hold of a slot LowriBeck failed to book, so that it doesn't stop being offered
+// to other customers until the hold expires.
+func (d BookingDomain) abandonSlot(ctx context.Context, accountID, postcode string, slot models.BookingSlot) {
+	d.releaseSlot(ctx, accountID, postcode, slot, "not booked")
+}
+
+func (d BookingDomain) releaseSlot(ctx context.Context, accountID, postcode string, slot models.BookingSlot, outcome string) {
+	if d.slotHolds == nil {
+		return
+	}
+
+	if err := d.slotHolds.Release(ctx, accountID, postcode, slot); err != nil {
+		slog.Warn("failed to release slot hold", "account_id", accountID, "outcome", outcome, "error", err)
+	}
+}
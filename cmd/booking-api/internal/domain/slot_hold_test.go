@@ -0,0 +1,205 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	bookingv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/booking/v1"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/domain"
+	mocks "github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/domain/mocks"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/repository/store"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/repository/gateway"
+	"google.golang.org/genproto/googleapis/type/date"
+)
+
+func expectLowriBeckKeys(ctx context.Context, oSt *mocks.MockOccupancyStore) {
+	oSt.EXPECT().GetSiteExternalReferenceByAccountID(ctx, "account-id-1").Return(
+		&models.Site{
+			Postcode: "E2 1ZZ",
+		},
+		&models.OccupancyEligibility{
+			OccupancyID: "occupancy-id-1",
+			Reference:   "booking-reference-1",
+		}, nil)
+}
+
+func Test_HoldSlot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	defer ctrl.Finish()
+
+	occSt := mocks.NewMockOccupancyStore(ctrl)
+	slotHoldSt := mocks.NewMockSlotHoldStore(ctrl)
+
+	myDomain := domain.NewBookingDomain(nil, nil, nil, occSt, nil, nil, nil, nil, nil, nil, false).WithSlotHolds(slotHoldSt)
+
+	slot := models.BookingSlot{Date: mustDate(t, "2023-12-05"), StartTime: 8, EndTime: 12}
+	expiresAt := time.Date(2023, time.December, 1, 10, 10, 0, 0, time.UTC)
+
+	type outputParams struct {
+		res domain.HoldSlotResponse
+		err error
+	}
+
+	testCases := []struct {
+		description string
+		setup       func(ctx context.Context, oSt *mocks.MockOccupancyStore, sSt *mocks.MockSlotHoldStore)
+		output      outputParams
+	}{
+		{
+			description: "should hold the slot",
+			setup: func(ctx context.Context, oSt *mocks.MockOccupancyStore, sSt *mocks.MockSlotHoldStore) {
+				expectLowriBeckKeys(ctx, oSt)
+				sSt.EXPECT().Hold(ctx, "account-id-1", "E2 1ZZ", slot).Return(expiresAt, nil)
+			},
+			output: outputParams{
+				res: domain.HoldSlotResponse{ExpiresAt: expiresAt},
+			},
+		},
+		{
+			description: "should return ErrSlotUnavailable when other customers hold the slot",
+			setup: func(ctx context.Context, oSt *mocks.MockOccupancyStore, sSt *mocks.MockSlotHoldStore) {
+				expectLowriBeckKeys(ctx, oSt)
+				sSt.EXPECT().Hold(ctx, "account-id-1", "E2 1ZZ", slot).Return(time.Time{}, store.ErrSlotFullyHeld)
+			},
+			output: outputParams{
+				err: domain.ErrSlotUnavailable,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.setup(ctx, occSt, slotHoldSt)
+
+			actual, err := myDomain.HoldSlot(ctx, domain.HoldSlotParams{AccountID: "account-id-1", Slot: slot})
+			if !errors.Is(err, tc.output.err) {
+				t.Fatalf("expected error %v, got %v", tc.output.err, err)
+			}
+
+			if diff := cmp.Diff(tc.output.res, actual); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+
+	_, err := domain.NewBookingDomain(nil, nil, nil, occSt, nil, nil, nil, nil, nil, nil, false).HoldSlot(ctx, domain.HoldSlotParams{AccountID: "account-id-1", Slot: slot})
+	if !errors.Is(err, domain.ErrSlotHoldsNotSupported) {
+		t.Fatalf("expected error %v, got %v", domain.ErrSlotHoldsNotSupported, err)
+	}
+}
+
+func Test_GetAvailableSlots_WithSlotHolds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	defer ctrl.Finish()
+
+	lbGw := mocks.NewMockLowriBeckGateway(ctrl)
+	occSt := mocks.NewMockOccupancyStore(ctrl)
+	slotHoldSt := mocks.NewMockSlotHoldStore(ctrl)
+
+	myDomain := domain.NewBookingDomain(nil, nil, lbGw, occSt, nil, nil, nil, nil, nil, nil, false).WithSlotHolds(slotHoldSt)
+
+	slots := []models.BookingSlot{
+		{Date: mustDate(t, "2023-12-05"), StartTime: 8, EndTime: 12},
+		{Date: mustDate(t, "2023-12-05"), StartTime: 12, EndTime: 16},
+	}
+
+	expectLowriBeckKeys(ctx, occSt)
+	lbGw.EXPECT().GetAvailableSlots(ctx, "E2 1ZZ", "booking-reference-1").Return(gateway.AvailableSlotsResponse{BookingSlots: slots}, nil)
+	slotHoldSt.EXPECT().FullyHeld(ctx, "account-id-1", "E2 1ZZ", slots).Return([]bool{true, false}, nil)
+
+	actual, err := myDomain.GetAvailableSlots(ctx, domain.GetAvailableSlotsParams{
+		AccountID: "account-id-1",
+		From:      &date.Date{Year: 2023, Month: 12, Day: 1},
+		To:        &date.Date{Year: 2023, Month: 12, Day: 30},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(domain.GetAvailableSlotsResponse{Slots: slots[1:]}, actual); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func Test_CreateBooking_WithSlotHolds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	defer ctrl.Finish()
+
+	lbGw := mocks.NewMockLowriBeckGateway(ctrl)
+	occSt := mocks.NewMockOccupancyStore(ctrl)
+	slotHoldSt := mocks.NewMockSlotHoldStore(ctrl)
+
+	myDomain := domain.NewBookingDomain(nil, nil, lbGw, occSt, nil, nil, nil, nil, nil, nil, false).WithSlotHolds(slotHoldSt)
+
+	params := domain.CreateBookingParams{
+		AccountID: "account-id-1",
+		Slot:      models.BookingSlot{Date: mustDate(t, "2023-12-05"), StartTime: 8, EndTime: 12},
+		ContactDetails: models.AccountDetails{
+			FirstName: "John",
+		},
+		Source:               bookingv1.BookingSource_BOOKING_SOURCE_PLATFORM_APP,
+		VulnerabilityDetails: &bookingv1.VulnerabilityDetails{},
+	}
+
+	t.Run("should consume the hold of the booked slot", func(t *testing.T) {
+		expectLowriBeckKeys(ctx, occSt)
+		gomock.InOrder(
+			slotHoldSt.EXPECT().Hold(ctx, "account-id-1", "E2 1ZZ", params.Slot).Return(time.Now(), nil),
+			lbGw.EXPECT().CreateBooking(ctx, "E2 1ZZ", "booking-reference-1", params.Slot, params.ContactDetails, gomock.Any(), "").Return(gateway.CreateBookingResponse{Success: true}, nil),
+			slotHoldSt.EXPECT().Release(ctx, "account-id-1", "E2 1ZZ", params.Slot).Return(nil),
+		)
+
+		if _, err := myDomain.CreateBooking(ctx, params); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("should release the hold of a slot LowriBeck failed to book", func(t *testing.T) {
+		expectLowriBeckKeys(ctx, occSt)
+		gomock.InOrder(
+			slotHoldSt.EXPECT().Hold(ctx, "account-id-1", "E2 1ZZ", params.Slot).Return(time.Now(), nil),
+			lbGw.EXPECT().CreateBooking(ctx, "E2 1ZZ", "booking-reference-1", params.Slot, params.ContactDetails, gomock.Any(), "").Return(gateway.CreateBookingResponse{Success: false}, nil),
+			slotHoldSt.EXPECT().Release(ctx, "account-id-1", "E2 1ZZ", params.Slot).Return(nil),
+		)
+
+		if _, err := myDomain.CreateBooking(ctx, params); !errors.Is(err, domain.ErrUnsuccessfulBooking) {
+			t.Fatalf("expected error %v, got %v", domain.ErrUnsuccessfulBooking, err)
+		}
+	})
+
+	t.Run("should release the hold of a slot when LowriBeck fails", func(t *testing.T) {
+		expectLowriBeckKeys(ctx, occSt)
+		gomock.InOrder(
+			slotHoldSt.EXPECT().Hold(ctx, "account-id-1", "E2 1ZZ", params.Slot).Return(time.Now(), nil),
+			lbGw.EXPECT().CreateBooking(ctx, "E2 1ZZ", "booking-reference-1", params.Slot, params.ContactDetails, gomock.Any(), "").Return(gateway.CreateBookingResponse{}, gateway.ErrInternal),
+			slotHoldSt.EXPECT().Release(ctx, "account-id-1", "E2 1ZZ", params.Slot).Return(nil),
+		)
+
+		if _, err := myDomain.CreateBooking(ctx, params); !errors.Is(err, gateway.ErrInternal) {
+			t.Fatalf("expected error %v, got %v", gateway.ErrInternal, err)
+		}
+	})
+
+	t.Run("should not book a slot other customers hold", func(t *testing.T) {
+		expectLowriBeckKeys(ctx, occSt)
+		slotHoldSt.EXPECT().Hold(ctx, "account-id-1", "E2 1ZZ", params.Slot).Return(time.Time{}, store.ErrSlotFullyHeld)
+
+		if _, err := myDomain.CreateBooking(ctx, params); !errors.Is(err, domain.ErrSlotUnavailable) {
+			t.Fatalf("expected error %v, got %v", domain.ErrSlotUnavailable, err)
+		}
+	})
+}
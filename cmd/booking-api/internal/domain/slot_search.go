@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
+	"google.golang.org/genproto/googleapis/type/date"
+)
+
+// SlotWindow restricts the slots to a part of the day.
+type SlotWindow int
+
+const (
+	SlotWindowAny SlotWindow = iota
+	// SlotWindowAM matches the slots starting before midday
+	SlotWindowAM
+	// SlotWindowPM matches the slots starting at or after midday
+	SlotWindowPM
+)
+
+const middayHour = 12
+
+// SlotFilter narrows down the slots returned by LowriBeck, the zero value
+// doesn't filter anything out.
+type SlotFilter struct {
+	// Weekdays the slots must fall on, any day when empty
+	Weekdays []time.Weekday
+	Window   SlotWindow
+	// Limit returns only the earliest N slots when greater than zero
+	Limit int
+}
+
+func (f SlotFilter) matches(slot models.BookingSlot) bool {
+	if len(f.Weekdays) > 0 && !slices.Contains(f.Weekdays, slot.Date.Weekday()) {
+		return false
+	}
+
+	switch f.Window {
+	case SlotWindowAM:
+		return slot.StartTime < middayHour
+	case SlotWindowPM:
+		return slot.StartTime >= middayHour
+	}
+
+	return true
+}
+
+// SlotDay holds the slots available on a single date.
+type SlotDay struct {
+	Date  time.Time
+	Slots []models.BookingSlot
+}
+
+// Days groups the slots by date, the slots are expected to be sorted already.
+func (r GetAvailableSlotsResponse) Days() []SlotDay {
+	days := []SlotDay{}
+
+	for _, slot := range r.Slots {
+		slotDate := truncateToDate(slot.Date)
+
+		if len(days) == 0 || !days[len(days)-1].Date.Equal(slotDate) {
+			days = append(days, SlotDay{Date: slotDate})
+		}
+		days[len(days)-1].Slots = append(days[len(days)-1].Slots, slot)
+	}
+
+	return days
+}
+
+// searchSlots returns the slots between from and to, both days included, which
+// match the filter. The slots are sorted by date and start time, and then
+// limited to the earliest N if the filter asks for it.
+func searchSlots(slots []models.BookingSlot, from, to *date.Date, filter SlotFilter) []models.BookingSlot {
+	fromAsTime := time.Date(int(from.Year), time.Month(from.Month), int(from.Day), 0, 0, 0, 0, time.UTC)
+	toAsTime := time.Date(int(to.Year), time.Month(to.Month), int(to.Day), 0, 0, 0, 0, time.UTC)
+
+	targetedSlots := []models.BookingSlot{}
+
+	for _, elem := range slots {
+		currentSlotTime := truncateToDate(elem.Date)
+
+		if currentSlotTime.Before(fromAsTime) || currentSlotTime.After(toAsTime) {
+			continue
+		}
+
+		if filter.matches(elem) {
+			targetedSlots = append(targetedSlots, elem)
+		}
+	}
+
+	slices.SortStableFunc(targetedSlots, func(a, b models.BookingSlot) int {
+		if c := truncateToDate(a.Date).Compare(truncateToDate(b.Date)); c != 0 {
+			return c
+		}
+		return a.StartTime - b.StartTime
+	})
+
+	if filter.Limit > 0 && len(targetedSlots) > filter.Limit {
+		targetedSlots = targetedSlots[:filter.Limit]
+	}
+
+	return targetedSlots
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
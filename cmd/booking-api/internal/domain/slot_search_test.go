@@ -0,0 +1,67 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/domain"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
+)
+
+func Test_GetAvailableSlotsResponse_Days(t *testing.T) {
+	response := domain.GetAvailableSlotsResponse{
+		Slots: []models.BookingSlot{
+			{
+				Date:      mustDate(t, "2023-12-01"),
+				StartTime: 8,
+				EndTime:   12,
+			},
+			{
+				Date:      mustDate(t, "2023-12-01"),
+				StartTime: 12,
+				EndTime:   16,
+			},
+			{
+				Date:      mustDate(t, "2023-12-04"),
+				StartTime: 10,
+				EndTime:   14,
+			},
+		},
+	}
+
+	expected := []domain.SlotDay{
+		{
+			Date: mustDate(t, "2023-12-01"),
+			Slots: []models.BookingSlot{
+				{
+					Date:      mustDate(t, "2023-12-01"),
+					StartTime: 8,
+					EndTime:   12,
+				},
+				{
+					Date:      mustDate(t, "2023-12-01"),
+					StartTime: 12,
+					EndTime:   16,
+				},
+			},
+		},
+		{
+			Date: mustDate(t, "2023-12-04"),
+			Slots: []models.BookingSlot{
+				{
+					Date:      mustDate(t, "2023-12-04"),
+					StartTime: 10,
+					EndTime:   14,
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(expected, response.Days()); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if diff := cmp.Diff([]domain.SlotDay{}, domain.GetAvailableSlotsResponse{}.Days()); diff != "" {
+		t.Fatal(diff)
+	}
+}
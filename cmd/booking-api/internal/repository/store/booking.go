@@ -16,6 +16,9 @@ var (
 	ErrBookingNotFound = errors.New("no booking found")
 )
 
+// RescheduledReason is the reason of the status changes made by the customers' reschedules.
+const RescheduledReason = "rescheduled"
+
 type BookingStore struct {
 	pool  *pgxpool.Pool
 	batch pgx.Batch
@@ -86,24 +89,45 @@ func (s *BookingStore) Upsert(booking models.Booking) {
 		booking.BookingType)
 }
 
-func (s *BookingStore) UpdateStatus(bookingID string, newStatus bookingv1.BookingStatus) {
+// UpdateStatus changes the status of a booking unless it was changed by a later event, as the
+// job outcomes may arrive out of order.
+func (s *BookingStore) UpdateStatus(bookingID string, newStatus bookingv1.BookingStatus, at time.Time) {
 	q := `
 	UPDATE booking
 	SET status = $2,
+		status_updated_at = $3,
 		updated_at = now()
-	WHERE booking_id = $1;
+	WHERE booking_id = $1
+		AND (status_updated_at IS NULL OR status_updated_at <= $3);
 	`
-	s.batch.Queue(q, bookingID, newStatus)
+	s.batch.Queue(q, bookingID, newStatus, at.UTC())
 }
 
-func (s *BookingStore) UpdateSchedule(bookingID string, newSchedule *time.Time) {
+// UpdateSchedule changes the slot of a booking unless its status was changed by a later event, it is
+// queued before the UpdateStatus of the same event.
+func (s *BookingStore) UpdateSchedule(bookingID string, bookingSlot models.BookingSlot, at time.Time) {
 	q := `
 	UPDATE booking
 	SET booking_date = $2,
+		booking_start_time = $3,
+		booking_end_time = $4,
 		updated_at = now()
-	WHERE booking_id = $1;
+	WHERE booking_id = $1
+		AND (status_updated_at IS NULL OR status_updated_at <= $5);
+	`
+	s.batch.Queue(q, bookingID, bookingSlot.Date, bookingSlot.StartTime, bookingSlot.EndTime, at.UTC())
+}
+
+// AddStatusChange appends an entry to the status history of a booking, the entry of a redelivered
+// event is only added once.
+func (s *BookingStore) AddStatusChange(bookingID, eventUUID string, change models.BookingStatusChange) {
+	q := `
+	INSERT INTO booking_status_history (booking_id, event_uuid, status, reason, occurred_at)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (booking_id, event_uuid)
+	DO NOTHING;
 	`
-	s.batch.Queue(q, bookingID, *newSchedule)
+	s.batch.Queue(q, bookingID, eventUUID, change.Status, change.Reason, change.OccurredAt.UTC())
 }
 
 func (s *BookingStore) UpdateBookingOnReschedule(bookingID string, contactDetails models.AccountDetails, bookingSlot models.BookingSlot, vulnerabilityDetails models.VulnerabilityDetails) {
@@ -141,6 +165,36 @@ func (s *BookingStore) UpdateBookingOnReschedule(bookingID string, contactDetail
 	)
 }
 
+// UpdateContactDetails changes the contact and vulnerability details of a booking, its slot is left as it is.
+func (s *BookingStore) UpdateContactDetails(bookingID string, contactDetails models.AccountDetails, vulnerabilityDetails models.VulnerabilityDetails) {
+	q := `
+	UPDATE booking
+	SET vulnerabilities_list = $2,
+		vulnerabilities_other = $3,
+		contact_title = $4,
+		contact_first_name = $5,
+		contact_last_name = $6,
+		contact_phone = $7,
+		contact_email = $8,
+		updated_at = now()
+	WHERE booking_id = $1;
+	`
+
+	vulnerabilitiesList := vulnerabilityDetails.Vulnerabilities
+	if vulnerabilitiesList.IsEmpty() {
+		vulnerabilitiesList = models.Vulnerabilities{}
+	}
+	s.batch.Queue(q, bookingID,
+		vulnerabilitiesList,
+		vulnerabilityDetails.Other,
+		contactDetails.Title,
+		contactDetails.FirstName,
+		contactDetails.LastName,
+		contactDetails.Mobile,
+		contactDetails.Email,
+	)
+}
+
 func (s *BookingStore) GetBookingsByAccountID(ctx context.Context, accountID string) ([]models.Booking, error) {
 	q := `
 	SELECT
@@ -265,3 +319,121 @@ func (s *BookingStore) GetBookingByBookingID(ctx context.Context, bookingID stri
 
 	return booking, nil
 }
+
+// GetBookingByReference returns the latest booking which is not cancelled for a Lowri-Beck reference,
+// the bookings of an occupancy share its reference.
+func (s *BookingStore) GetBookingByReference(ctx context.Context, reference string) (models.Booking, error) {
+
+	q := `
+	SELECT
+		booking_id,
+		account_id,
+		status,
+
+		occupancy_id,
+
+		contact_title,
+		contact_first_name,
+		contact_last_name,
+		contact_phone,
+		contact_email,
+
+		booking_date,
+		booking_start_time,
+		booking_end_time,
+
+		vulnerabilities_list,
+		vulnerabilities_other,
+
+		external_reference,
+
+		booking_type
+
+	FROM booking
+	WHERE external_reference = $1 AND status <> $2
+	ORDER BY updated_at DESC
+	LIMIT 1;
+	`
+	row := s.pool.QueryRow(ctx, q, reference, bookingv1.BookingStatus_BOOKING_STATUS_CANCELLED)
+
+	booking := models.Booking{}
+	err := row.Scan(
+		&booking.BookingID,
+		&booking.AccountID,
+		&booking.Status,
+		&booking.OccupancyID,
+		&booking.Contact.Title,
+		&booking.Contact.FirstName,
+		&booking.Contact.LastName,
+		&booking.Contact.Mobile,
+		&booking.Contact.Email,
+		&booking.Slot.Date,
+		&booking.Slot.StartTime,
+		&booking.Slot.EndTime,
+		&booking.VulnerabilityDetails.Vulnerabilities,
+		&booking.VulnerabilityDetails.Other,
+		&booking.BookingReference,
+		&booking.BookingType,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Booking{}, ErrBookingNotFound
+		}
+
+		return models.Booking{}, fmt.Errorf("failed to scan row, %w", err)
+	}
+
+	return booking, nil
+}
+
+// CountReschedules returns the number of times the customer rescheduled a booking, the reschedules
+// made by the installers are not counted. The count comes from the status history, so the reschedules
+// projected before the history was added are not counted either.
+func (s *BookingStore) CountReschedules(ctx context.Context, bookingID string) (int, error) {
+	q := `
+	SELECT count(*)
+	FROM booking_status_history
+	WHERE booking_id = $1
+	AND reason = $2;
+	`
+	var count int
+	if err := s.pool.QueryRow(ctx, q, bookingID, RescheduledReason).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count reschedules, %w", err)
+	}
+
+	return count, nil
+}
+
+// GetStatusHistoryByAccountID returns the status history of the bookings of an account by booking ID,
+// from the oldest entry to the latest.
+func (s *BookingStore) GetStatusHistoryByAccountID(ctx context.Context, accountID string) (map[string][]models.BookingStatusChange, error) {
+	q := `
+	SELECT h.booking_id, h.status, h.reason, h.occurred_at
+	FROM booking_status_history h
+	JOIN booking b ON b.booking_id = h.booking_id
+	WHERE b.account_id = $1
+	ORDER BY h.occurred_at;
+	`
+	rows, err := s.pool.Query(ctx, q, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get booking status history, %w", err)
+	}
+	defer rows.Close()
+
+	history := make(map[string][]models.BookingStatusChange)
+	for rows.Next() {
+		var (
+			bookingID string
+			change    models.BookingStatusChange
+		)
+		if err := rows.Scan(&bookingID, &change.Status, &change.Reason, &change.OccurredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row, %w", err)
+		}
+		history[bookingID] = append(history[bookingID], change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get booking status history, %w", err)
+	}
+
+	return history, nil
+}
@@ -278,6 +278,50 @@ func Test_BookingStore_UpdateBookingOnReschedule(t *testing.T) {
 	}
 }
 
+func Test_BookingStore_UpdateContactDetails(t *testing.T) {
+	ctx, bookingStore := storeInit(t)
+
+	booking := makeDummyBooking(
+		"booking-id-1", "account-id-1", "occupancy-id-1", "booking-reference-1",
+		bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED,
+		makeBookingSlot(t, "2023-09-16", 13, 15),
+		models.Vulnerabilities{})
+
+	bookingStore.Begin()
+	bookingStore.Upsert(booking)
+	must(t, bookingStore.Commit(ctx))
+
+	contactDetails := models.AccountDetails{
+		Title:     "Mrs",
+		FirstName: "Jane",
+		LastName:  "Doe",
+		Email:     "jdoe@example.com",
+		Mobile:    "333-100",
+	}
+	vulnerabilityDetails := models.VulnerabilityDetails{
+		Vulnerabilities: models.Vulnerabilities{
+			bookingv1.Vulnerability_VULNERABILITY_FOREIGN_LANGUAGE_ONLY,
+		},
+		Other: "bad knee",
+	}
+
+	bookingStore.Begin()
+	bookingStore.UpdateContactDetails("booking-id-1", contactDetails, vulnerabilityDetails)
+	must(t, bookingStore.Commit(ctx))
+
+	actual, err := bookingStore.GetBookingByBookingID(ctx, "booking-id-1")
+	must(t, err)
+
+	// the slot of the booking is not changed
+	expected := booking
+	expected.Contact = contactDetails
+	expected.VulnerabilityDetails = vulnerabilityDetails
+
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
 func Test_BookingStore_GetBookingByBookinID(t *testing.T) {
 	ctx := context.Background()
 
@@ -365,3 +409,103 @@ func Test_BookingStore_GetBookingByBookinID(t *testing.T) {
 		})
 	}
 }
+
+func Test_BookingStore_StatusLifecycle(t *testing.T) {
+	ctx, bookingStore := storeInit(t)
+
+	booking := makeDummyBooking(
+		"booking-id-1", "account-id-1", "occupancy-id-1", "booking-reference-1",
+		bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED,
+		makeBookingSlot(t, "2023-09-16", 13, 15),
+		models.Vulnerabilities{})
+
+	scheduledAt := time.Date(2023, time.September, 1, 10, 0, 0, 0, time.UTC)
+	rescheduledAt := time.Date(2023, time.September, 15, 10, 0, 0, 0, time.UTC)
+	completedAt := time.Date(2023, time.September, 20, 15, 0, 0, 0, time.UTC)
+
+	bookingStore.Begin()
+	bookingStore.Upsert(booking)
+	bookingStore.AddStatusChange("booking-id-1", "event-uuid-1", models.BookingStatusChange{
+		Status:     bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED,
+		OccurredAt: scheduledAt,
+	})
+	must(t, bookingStore.Commit(ctx))
+
+	// the completion arrives before the reschedule by the installer which preceded it
+	bookingStore.Begin()
+	bookingStore.UpdateStatus("booking-id-1", bookingv1.BookingStatus_BOOKING_STATUS_COMPLETED, completedAt)
+	bookingStore.AddStatusChange("booking-id-1", "event-uuid-3", models.BookingStatusChange{
+		Status:     bookingv1.BookingStatus_BOOKING_STATUS_COMPLETED,
+		Reason:     "meter installed",
+		OccurredAt: completedAt,
+	})
+	bookingStore.UpdateSchedule("booking-id-1", makeBookingSlot(t, "2023-09-20", 10, 12), rescheduledAt)
+	bookingStore.UpdateStatus("booking-id-1", bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED, rescheduledAt)
+	bookingStore.AddStatusChange("booking-id-1", "event-uuid-2", models.BookingStatusChange{
+		Status:     bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED,
+		Reason:     "rescheduled by installer",
+		OccurredAt: rescheduledAt,
+	})
+	// a redelivered event is recorded once
+	bookingStore.AddStatusChange("booking-id-1", "event-uuid-3", models.BookingStatusChange{
+		Status:     bookingv1.BookingStatus_BOOKING_STATUS_COMPLETED,
+		Reason:     "meter installed",
+		OccurredAt: completedAt,
+	})
+	must(t, bookingStore.Commit(ctx))
+
+	actual, err := bookingStore.GetBookingByReference(ctx, "booking-reference-1")
+	must(t, err)
+
+	// neither the status nor the slot of the earlier reschedule are applied
+	expected := booking
+	expected.Status = bookingv1.BookingStatus_BOOKING_STATUS_COMPLETED
+
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Fatal(diff)
+	}
+
+	history, err := bookingStore.GetStatusHistoryByAccountID(ctx, "account-id-1")
+	must(t, err)
+
+	expectedHistory := map[string][]models.BookingStatusChange{
+		"booking-id-1": {
+			{Status: bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED, OccurredAt: scheduledAt},
+			{Status: bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED, Reason: "rescheduled by installer", OccurredAt: rescheduledAt},
+			{Status: bookingv1.BookingStatus_BOOKING_STATUS_COMPLETED, Reason: "meter installed", OccurredAt: completedAt},
+		},
+	}
+	if diff := cmp.Diff(expectedHistory, history); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// the reschedules by the installer are not counted against the customer
+	reschedules, err := bookingStore.CountReschedules(ctx, "booking-id-1")
+	must(t, err)
+	if reschedules != 0 {
+		t.Fatalf("expected no reschedules, got %d", reschedules)
+	}
+
+	bookingStore.Begin()
+	bookingStore.AddStatusChange("booking-id-1", "event-uuid-4", models.BookingStatusChange{
+		Status:     bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED,
+		Reason:     store.RescheduledReason,
+		OccurredAt: completedAt.Add(-time.Hour),
+	})
+	must(t, bookingStore.Commit(ctx))
+
+	reschedules, err = bookingStore.CountReschedules(ctx, "booking-id-1")
+	must(t, err)
+	if reschedules != 1 {
+		t.Fatalf("expected 1 reschedule, got %d", reschedules)
+	}
+
+	// the cancelled bookings of a reference are not returned
+	bookingStore.Begin()
+	bookingStore.UpdateStatus("booking-id-1", bookingv1.BookingStatus_BOOKING_STATUS_CANCELLED, completedAt.Add(time.Hour))
+	must(t, bookingStore.Commit(ctx))
+
+	if _, err := bookingStore.GetBookingByReference(ctx, "booking-reference-1"); !errors.Is(err, store.ErrBookingNotFound) {
+		t.Fatalf("expected %s, got %v", store.ErrBookingNotFound, err)
+	}
+}
@@ -0,0 +1,153 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrIdempotencyKeyReused        = errors.New("idempotency key was already used for a different request")
+	ErrIdempotentRequestInProgress = errors.New("a request with the same idempotency key is in progress")
+	ErrIdempotentRequestStale      = errors.New("a request with the same idempotency key did not complete, its outcome is unknown")
+	ErrIdempotencyKeyNotReserved   = errors.New("idempotency key was not reserved")
+
+	errIdempotencyKeyReleased = errors.New("idempotency key was released")
+)
+
+// IdempotencyStore remembers which booking a request with an idempotency key resulted in, so that
+// retries of the request within the window return the same booking rather than making a new one.
+// The keys are scoped to an account and an operation.
+//
+// A key which is reserved but not completed within the pending timeout belongs to a request which died
+// or failed to record its booking. Such a request may have booked with LowriBeck, so its key is never
+// handed to a retry: the retries fail until the key expires with the window.
+type IdempotencyStore struct {
+	pool           *pgxpool.Pool
+	window         time.Duration
+	pendingTimeout time.Duration
+	now            func() time.Time
+}
+
+func NewIdempotencyStore(pool *pgxpool.Pool, window, pendingTimeout time.Duration) *IdempotencyStore {
+	return &IdempotencyStore{pool: pool, window: window, pendingTimeout: pendingTimeout, now: time.Now}
+}
+
+// Reserve claims the idempotency key for a request. It returns an empty booking ID when the request
+// has to be processed, and the booking ID of the original request when it is a replay of a completed one.
+// It returns ErrIdempotentRequestInProgress while the original request is pending, and ErrIdempotentRequestStale
+// once it is pending for longer than the pending timeout.
+func (s *IdempotencyStore) Reserve(ctx context.Context, accountID, operation, key, requestHash string) (string, error) {
+	bookingID, err := s.reserve(ctx, accountID, operation, key, requestHash)
+	if errors.Is(err, errIdempotencyKeyReleased) {
+		// the original request failed and released the key in the meantime, so it is free again
+		bookingID, err = s.reserve(ctx, accountID, operation, key, requestHash)
+		if errors.Is(err, errIdempotencyKeyReleased) {
+			return "", ErrIdempotentRequestInProgress
+		}
+	}
+
+	return bookingID, err
+}
+
+func (s *IdempotencyStore) reserve(ctx context.Context, accountID, operation, key, requestHash string) (string, error) {
+	now := s.now().UTC()
+
+	q := `
+	INSERT INTO booking_idempotency_key (account_id, operation, idempotency_key, request_hash, created_at)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (account_id, operation, idempotency_key)
+	DO UPDATE SET
+		request_hash = EXCLUDED.request_hash,
+		booking_id = NULL,
+		created_at = EXCLUDED.created_at,
+		completed_at = NULL
+	WHERE booking_idempotency_key.created_at < $6;`
+
+	tag, err := s.pool.Exec(ctx, q, accountID, operation, key, requestHash, now, now.Add(-s.window))
+	if err != nil {
+		return "", fmt.Errorf("failed to reserve idempotency key %s for account ID %s: %w", key, accountID, err)
+	}
+	if tag.RowsAffected() == 1 {
+		return "", nil
+	}
+
+	var (
+		storedHash string
+		bookingID  *string
+		createdAt  time.Time
+	)
+	q = `
+	SELECT request_hash, booking_id, created_at
+	FROM booking_idempotency_key
+	WHERE account_id = $1 AND operation = $2 AND idempotency_key = $3;`
+
+	if err := s.pool.QueryRow(ctx, q, accountID, operation, key).Scan(&storedHash, &bookingID, &createdAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", errIdempotencyKeyReleased
+		}
+		return "", fmt.Errorf("failed to get idempotency key %s for account ID %s: %w", key, accountID, err)
+	}
+
+	if storedHash != requestHash {
+		return "", ErrIdempotencyKeyReused
+	}
+	if bookingID == nil {
+		if createdAt.Before(now.Add(-s.pendingTimeout)) {
+			return "", ErrIdempotentRequestStale
+		}
+		return "", ErrIdempotentRequestInProgress
+	}
+
+	return *bookingID, nil
+}
+
+// Complete records the booking the request of a reserved idempotency key resulted in, in the transaction
+// of the context if there is one.
+func (s *IdempotencyStore) Complete(ctx context.Context, accountID, operation, key, bookingID string) error {
+	q := `
+	UPDATE booking_idempotency_key
+	SET booking_id = $4, completed_at = $5
+	WHERE account_id = $1 AND operation = $2 AND idempotency_key = $3 AND completed_at IS NULL;`
+
+	tag, err := execerFor(ctx, s.pool).Exec(ctx, q, accountID, operation, key, bookingID, s.now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key %s for account ID %s: %w", key, accountID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrIdempotencyKeyNotReserved
+	}
+
+	return nil
+}
+
+// Release frees an idempotency key whose request failed, so that it can be retried.
+func (s *IdempotencyStore) Release(ctx context.Context, accountID, operation, key string) error {
+	q := `
+	DELETE FROM booking_idempotency_key
+	WHERE account_id = $1 AND operation = $2 AND idempotency_key = $3 AND completed_at IS NULL;`
+
+	if _, err := s.pool.Exec(ctx, q, accountID, operation, key); err != nil {
+		return fmt.Errorf("failed to release idempotency key %s for account ID %s: %w", key, accountID, err)
+	}
+
+	return nil
+}
+
+// DeleteExpired deletes the idempotency keys older than the window and returns how many were deleted.
+func (s *IdempotencyStore) DeleteExpired(ctx context.Context) (int64, error) {
+	q := `
+	DELETE FROM booking_idempotency_key
+	WHERE created_at < $1;`
+
+	tag, err := s.pool.Exec(ctx, q, s.now().UTC().Add(-s.window))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
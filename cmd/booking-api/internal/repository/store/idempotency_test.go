@@ -0,0 +1,108 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/utilitywarehouse/energy-pkg/postgres"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/repository/store"
+)
+
+func Test_IdempotencyStore(t *testing.T) {
+	ctx := context.Background()
+
+	testContainer, err := setupTestContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dsn, err := postgres.GetTestContainerDSN(testContainer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := store.Setup(ctx, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idempotencyStore := store.NewIdempotencyStore(db, time.Hour, time.Hour)
+
+	bookingID, err := idempotencyStore.Reserve(ctx, "account-id-1", "create-booking", "key-1", "hash-1")
+	if err != nil {
+		t.Fatalf("failed to reserve idempotency key: %s", err)
+	}
+	if bookingID != "" {
+		t.Fatalf("expected a new request, got booking ID %s", bookingID)
+	}
+
+	if _, err := idempotencyStore.Reserve(ctx, "account-id-1", "create-booking", "key-1", "hash-1"); !errors.Is(err, store.ErrIdempotentRequestInProgress) {
+		t.Fatalf("expected %s, got %v", store.ErrIdempotentRequestInProgress, err)
+	}
+
+	if err := idempotencyStore.Complete(ctx, "account-id-1", "create-booking", "key-1", "booking-id-1"); err != nil {
+		t.Fatalf("failed to complete idempotency key: %s", err)
+	}
+
+	bookingID, err = idempotencyStore.Reserve(ctx, "account-id-1", "create-booking", "key-1", "hash-1")
+	if err != nil {
+		t.Fatalf("failed to replay idempotency key: %s", err)
+	}
+	if bookingID != "booking-id-1" {
+		t.Fatalf("expected booking ID booking-id-1, got %q", bookingID)
+	}
+
+	if _, err := idempotencyStore.Reserve(ctx, "account-id-1", "create-booking", "key-1", "hash-2"); !errors.Is(err, store.ErrIdempotencyKeyReused) {
+		t.Fatalf("expected %s, got %v", store.ErrIdempotencyKeyReused, err)
+	}
+
+	// the keys are scoped to the account and the operation
+	for _, scope := range [][2]string{{"account-id-2", "create-booking"}, {"account-id-1", "reschedule-booking"}} {
+		bookingID, err := idempotencyStore.Reserve(ctx, scope[0], scope[1], "key-1", "hash-2")
+		if err != nil {
+			t.Fatalf("failed to reserve idempotency key for %v: %s", scope, err)
+		}
+		if bookingID != "" {
+			t.Fatalf("expected a new request for %v, got booking ID %s", scope, bookingID)
+		}
+	}
+
+	// a key pending for longer than the timeout is not handed to the retry
+	if _, err := store.NewIdempotencyStore(db, time.Hour, -time.Minute).Reserve(ctx, "account-id-1", "reschedule-booking", "key-1", "hash-2"); !errors.Is(err, store.ErrIdempotentRequestStale) {
+		t.Fatalf("expected %s, got %v", store.ErrIdempotentRequestStale, err)
+	}
+
+	// a released key can be used again
+	if err := idempotencyStore.Release(ctx, "account-id-2", "create-booking", "key-1"); err != nil {
+		t.Fatalf("failed to release idempotency key: %s", err)
+	}
+	if err := idempotencyStore.Complete(ctx, "account-id-2", "create-booking", "key-1", "booking-id-2"); !errors.Is(err, store.ErrIdempotencyKeyNotReserved) {
+		t.Fatalf("expected %s, got %v", store.ErrIdempotencyKeyNotReserved, err)
+	}
+	if _, err := idempotencyStore.Reserve(ctx, "account-id-2", "create-booking", "key-1", "hash-3"); err != nil {
+		t.Fatalf("failed to reserve released idempotency key: %s", err)
+	}
+
+	// completed keys are not released
+	if err := idempotencyStore.Release(ctx, "account-id-1", "create-booking", "key-1"); err != nil {
+		t.Fatalf("failed to release idempotency key: %s", err)
+	}
+	bookingID, err = idempotencyStore.Reserve(ctx, "account-id-1", "create-booking", "key-1", "hash-1")
+	if err != nil {
+		t.Fatalf("failed to replay idempotency key: %s", err)
+	}
+	if bookingID != "booking-id-1" {
+		t.Fatalf("expected booking ID booking-id-1, got %q", bookingID)
+	}
+
+	// past the window every key expires
+	deleted, err := store.NewIdempotencyStore(db, -time.Minute, time.Hour).DeleteExpired(ctx)
+	if err != nil {
+		t.Fatalf("failed to delete expired idempotency keys: %s", err)
+	}
+	if deleted != 3 {
+		t.Fatalf("expected 3 deleted idempotency keys, got %d", deleted)
+	}
+}
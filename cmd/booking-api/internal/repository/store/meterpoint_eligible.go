@@ -2,16 +2,27 @@ package store
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/go-redis/redis/v8"
+	smart "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart/v1"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/cache"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
 	"github.com/utilitywarehouse/go-operational/op"
 )
 
-const prefixKeyMeterpointEligibility = "mpe"
+// prefixKeyMeterpointEligibility is versioned so that the entries cached before the reasons were added,
+// which only hold a bool, are not read.
+const prefixKeyMeterpointEligibility = "mpe:v2"
+
+type meterpointEligibility struct {
+	Eligible                     bool                     `json:"eligible"`
+	ElectricityIneligibleReasons []smart.IneligibleReason `json:"electricity_ineligible_reasons,omitempty"`
+	GasIneligibleReasons         []smart.IneligibleReason `json:"gas_ineligible_reasons,omitempty"`
+}
 
 type MeterpointEligibleStore struct {
 	r   *redis.Client
@@ -43,17 +54,36 @@ func (s *MeterpointEligibleStore) key(mpan, mprn string) string {
 	return fmt.Sprintf("%s:%s:%s", prefixKeyMeterpointEligibility, mpan, mprn)
 }
 
-func (s *MeterpointEligibleStore) SetEligibilityForMpxn(ctx context.Context, mpan, mprn string, eligible bool) error {
-	return s.r.Set(ctx, s.key(mpan, mprn), eligible, s.ttl).Err()
+func (s *MeterpointEligibleStore) SetEligibilityForMpxn(ctx context.Context, mpan, mprn string, eligibility models.MeterpointEligibility) error {
+	value, err := json.Marshal(meterpointEligibility{
+		Eligible:                     eligibility.Eligible,
+		ElectricityIneligibleReasons: eligibility.ElectricityIneligibleReasons,
+		GasIneligibleReasons:         eligibility.GasIneligibleReasons,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal meterpoint eligibility: %w", err)
+	}
+
+	return s.r.Set(ctx, s.key(mpan, mprn), value, s.ttl).Err()
 }
 
-func (s *MeterpointEligibleStore) GetEligibilityForMpxn(ctx context.Context, mpan, mprn string) (bool, error) {
-	e, err := s.r.Get(ctx, s.key(mpan, mprn)).Bool()
+func (s *MeterpointEligibleStore) GetEligibilityForMpxn(ctx context.Context, mpan, mprn string) (models.MeterpointEligibility, error) {
+	value, err := s.r.Get(ctx, s.key(mpan, mprn)).Bytes()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
-			return false, cache.ErrNotFound
+			return models.MeterpointEligibility{}, cache.ErrNotFound
 		}
-		return false, err
+		return models.MeterpointEligibility{}, err
+	}
+
+	var e meterpointEligibility
+	if err := json.Unmarshal(value, &e); err != nil {
+		return models.MeterpointEligibility{}, fmt.Errorf("failed to unmarshal meterpoint eligibility: %w", err)
 	}
-	return e, nil
+
+	return models.MeterpointEligibility{
+		Eligible:                     e.Eligible,
+		ElectricityIneligibleReasons: e.ElectricityIneligibleReasons,
+		GasIneligibleReasons:         e.GasIneligibleReasons,
+	}, nil
 }
@@ -7,10 +7,13 @@ import (
 	"time"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/google/go-cmp/cmp"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
+	smart "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart/v1"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/cache"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/repository/store"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
 )
 
 func SetupRedisTestContainer(ctx context.Context) (testcontainers.Container, error) {
@@ -61,8 +64,8 @@ func Test_MeterpointEligibleStore_CacheEligibility(t *testing.T) {
 	meterpointEligibleStore := store.NewMeterpointEligible(redis.NewClient(&redis.Options{Addr: containerAddr}), 6*time.Hour)
 
 	type inputParams struct {
-		mpan     string
-		eligible bool
+		mpan        string
+		eligibility models.MeterpointEligibility
 	}
 
 	type testSetup struct {
@@ -75,8 +78,8 @@ func Test_MeterpointEligibleStore_CacheEligibility(t *testing.T) {
 		{
 			description: "should cache a meterpoint eligible record",
 			input: inputParams{
-				mpan:     "mpan-1",
-				eligible: true,
+				mpan:        "mpan-1",
+				eligibility: models.MeterpointEligibility{Eligible: true},
 			},
 			output: nil,
 		},
@@ -84,7 +87,7 @@ func Test_MeterpointEligibleStore_CacheEligibility(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
-			err := meterpointEligibleStore.SetEligibilityForMpxn(ctx, tc.input.mpan, "", tc.input.eligible)
+			err := meterpointEligibleStore.SetEligibilityForMpxn(ctx, tc.input.mpan, "", tc.input.eligibility)
 			if err != tc.output {
 				t.Fatalf("error output does not match, expected: %s | actual: %s", tc.output, err)
 			}
@@ -112,13 +115,14 @@ func Test_MeterpointEligibleStore_GetEligibilityForMpxn(t *testing.T) {
 
 	type inputParams struct {
 		mpan          string
-		eligible      bool
+		mprn          string
+		eligibility   models.MeterpointEligibility
 		skipInsertion bool
 	}
 
 	type testOutput struct {
-		resbool bool
-		reserr  error
+		res    models.MeterpointEligibility
+		reserr error
 	}
 
 	type testSetup struct {
@@ -131,24 +135,50 @@ func Test_MeterpointEligibleStore_GetEligibilityForMpxn(t *testing.T) {
 		{
 			description: "should cache and retrieve a meterpoint eligible record",
 			input: inputParams{
-				mpan:     "mpxn-1",
-				eligible: true,
+				mpan:        "mpxn-1",
+				eligibility: models.MeterpointEligibility{Eligible: true},
 			},
 			output: testOutput{
-				resbool: true,
-				reserr:  nil,
+				res:    models.MeterpointEligibility{Eligible: true},
+				reserr: nil,
+			},
+		},
+		{
+			description: "should cache and retrieve the ineligible reasons of both fuels",
+			input: inputParams{
+				mpan: "mpxn-3",
+				mprn: "mprn-3",
+				eligibility: models.MeterpointEligibility{
+					Eligible: false,
+					ElectricityIneligibleReasons: []smart.IneligibleReason{
+						smart.IneligibleReason_INELIGIBLE_REASON_NO_WAN_COVERAGE,
+						smart.IneligibleReason_INELIGIBLE_REASON_ALT_HAN,
+					},
+					GasIneligibleReasons: []smart.IneligibleReason{smart.IneligibleReason_INELIGIBLE_REASON_METER_LARGE_CAPACITY},
+				},
+			},
+			output: testOutput{
+				res: models.MeterpointEligibility{
+					Eligible: false,
+					ElectricityIneligibleReasons: []smart.IneligibleReason{
+						smart.IneligibleReason_INELIGIBLE_REASON_NO_WAN_COVERAGE,
+						smart.IneligibleReason_INELIGIBLE_REASON_ALT_HAN,
+					},
+					GasIneligibleReasons: []smart.IneligibleReason{smart.IneligibleReason_INELIGIBLE_REASON_METER_LARGE_CAPACITY},
+				},
+				reserr: nil,
 			},
 		},
 		{
 			description: "should not cache and get a NotFound error when attempting retrieval",
 			input: inputParams{
 				mpan:          "mpxn-2",
-				eligible:      true,
+				eligibility:   models.MeterpointEligibility{Eligible: true},
 				skipInsertion: true,
 			},
 			output: testOutput{
-				resbool: false,
-				reserr:  cache.ErrNotFound,
+				res:    models.MeterpointEligibility{},
+				reserr: cache.ErrNotFound,
 			},
 		},
 	}
@@ -156,17 +186,17 @@ func Test_MeterpointEligibleStore_GetEligibilityForMpxn(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
 			if !tc.input.skipInsertion {
-				err := meterpointEligibleStore.SetEligibilityForMpxn(ctx, tc.input.mpan, "", tc.input.eligible)
+				err := meterpointEligibleStore.SetEligibilityForMpxn(ctx, tc.input.mpan, tc.input.mprn, tc.input.eligibility)
 				if err != nil {
 					t.Fatal("error when caching eligibility")
 				}
 			}
-			res, err := meterpointEligibleStore.GetEligibilityForMpxn(ctx, tc.input.mpan, "")
+			res, err := meterpointEligibleStore.GetEligibilityForMpxn(ctx, tc.input.mpan, tc.input.mprn)
 			if err != tc.output.reserr {
 				t.Fatalf("error output does not match, expected: %s | actual: %s", tc.output.reserr, err)
 			}
-			if res != tc.output.resbool {
-				t.Fatalf("eligibility output does not match, expected: %t | actual: %t", tc.output.resbool, res)
+			if !cmp.Equal(res, tc.output.res) {
+				t.Fatalf("eligibility output does not match, expected: %+v | actual: %+v", tc.output.res, res)
 			}
 		})
 	}
@@ -0,0 +1,17 @@
+-- +migrate Up
+-- Postcodes are stored in their canonical form, upper case with a single space before the inward code,
+-- postcodes which aren't valid UK postcodes are stored upper case without whitespace.
+UPDATE site
+SET postcode = n.canonical, updated_at = now()
+FROM (
+    SELECT site_id,
+        CASE WHEN compact ~ '^(GIR0AA|[A-Z]{1,2}[0-9][A-Z0-9]?[0-9][A-Z]{2})$'
+            THEN LEFT(compact, -3) || ' ' || RIGHT(compact, 3)
+            ELSE compact
+        END AS canonical
+    FROM (SELECT site_id, UPPER(REGEXP_REPLACE(postcode, '\s', '', 'g')) AS compact FROM site WHERE postcode IS NOT NULL) c
+) n
+WHERE site.site_id = n.site_id AND site.postcode <> n.canonical;
+
+-- +migrate Down
+-- the original form of the postcodes is not kept, so they are left normalised
@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS booking_idempotency_key (
+    account_id             TEXT NOT NULL,
+    operation              TEXT NOT NULL,
+    idempotency_key        TEXT NOT NULL,
+    request_hash           TEXT NOT NULL,
+    booking_id             TEXT,
+    created_at             TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at           TIMESTAMP WITHOUT TIME ZONE,
+
+    PRIMARY KEY (account_id, operation, idempotency_key)
+);
+
+CREATE INDEX IF NOT EXISTS booking_idempotency_key_created_at_idx ON booking_idempotency_key (created_at);
+
+-- +migrate Down
+DROP TABLE IF EXISTS booking_idempotency_key;
@@ -0,0 +1,20 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS booking_outbox (
+    id                     BIGSERIAL PRIMARY KEY,
+    sink                   TEXT NOT NULL,
+    booking_id             TEXT,
+    event                  BYTEA NOT NULL,
+    occurred_at            TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    created_at             TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    attempts               INT NOT NULL DEFAULT 0,
+    next_attempt_at        TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error             TEXT,
+    dead_lettered_at       TIMESTAMP WITHOUT TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS booking_outbox_pending_idx ON booking_outbox (next_attempt_at) WHERE dead_lettered_at IS NULL;
+CREATE INDEX IF NOT EXISTS booking_outbox_booking_id_idx ON booking_outbox (booking_id, id) WHERE dead_lettered_at IS NULL;
+
+-- +migrate Down
+DROP TABLE IF EXISTS booking_outbox;
@@ -0,0 +1,19 @@
+-- +migrate Up
+ALTER TABLE IF EXISTS booking ADD COLUMN IF NOT EXISTS status_updated_at TIMESTAMP WITHOUT TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS booking_external_reference_idx ON booking (external_reference);
+
+CREATE TABLE IF NOT EXISTS booking_status_history (
+    booking_id             TEXT NOT NULL,
+    event_uuid             TEXT NOT NULL,
+    status                 INT NOT NULL,
+    reason                 TEXT NOT NULL DEFAULT '',
+    occurred_at            TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+
+    PRIMARY KEY (booking_id, event_uuid)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS booking_status_history;
+DROP INDEX IF EXISTS booking_external_reference_idx;
+ALTER TABLE IF EXISTS booking DROP COLUMN IF EXISTS status_updated_at;
@@ -143,3 +143,121 @@ func (s *OccupancyStore) GetSiteExternalReferenceByAccountID(ctx context.Context
 
 	return &site, &occupancyEligibility, nil
 }
+
+// GetSiteExternalReferenceByOccupancyID returns the site and booking reference of an occupancy, if it is eligible.
+func (s *OccupancyStore) GetSiteExternalReferenceByOccupancyID(ctx context.Context, occupancyID string) (*models.Site, *models.OccupancyEligibility, error) {
+	q := `
+	SELECT
+		si.site_id,
+		si.postcode,
+		si.uprn,
+		si.building_name_number,
+		si.dependent_thoroughfare,
+		si.thoroughfare,
+		si.double_dependent_locality,
+		si.dependent_locality,
+		si.locality,
+		si.county,
+		si.town,
+		si.department,
+		si.organisation,
+		si.po_box,
+		si.delivery_point_suffix,
+		si.sub_building_name_number,
+		oe.occupancy_id,
+		oe.reference
+
+		FROM occupancy_eligible oe
+		JOIN occupancy o ON o.occupancy_id = oe.occupancy_id
+		JOIN site si ON si.site_id = o.site_id
+
+		WHERE o.occupancy_id = $1
+		AND oe.deleted_at IS NULL;`
+
+	eligibleOccupancy, err := scanEligibleOccupancy(s.pool.QueryRow(ctx, q, occupancyID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrNoEligibleOccupancyFound
+		}
+		return nil, nil, err
+	}
+
+	return &eligibleOccupancy.Site, &eligibleOccupancy.Eligibility, nil
+}
+
+// GetEligibleOccupanciesByAccountID returns the eligible occupancies of an account with their sites, the most recent first.
+func (s *OccupancyStore) GetEligibleOccupanciesByAccountID(ctx context.Context, accountID string) ([]models.EligibleOccupancy, error) {
+	q := `
+	SELECT
+		si.site_id,
+		si.postcode,
+		si.uprn,
+		si.building_name_number,
+		si.dependent_thoroughfare,
+		si.thoroughfare,
+		si.double_dependent_locality,
+		si.dependent_locality,
+		si.locality,
+		si.county,
+		si.town,
+		si.department,
+		si.organisation,
+		si.po_box,
+		si.delivery_point_suffix,
+		si.sub_building_name_number,
+		oe.occupancy_id,
+		oe.reference
+
+		FROM occupancy_eligible oe
+		JOIN occupancy o ON o.occupancy_id = oe.occupancy_id
+		JOIN site si ON si.site_id = o.site_id
+
+		WHERE o.account_id = $1
+		AND oe.deleted_at IS NULL
+		ORDER BY
+			o.created_at DESC;`
+
+	rows, err := s.pool.Query(ctx, q, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	eligibleOccupancies := make([]models.EligibleOccupancy, 0)
+	for rows.Next() {
+		eligibleOccupancy, err := scanEligibleOccupancy(rows)
+		if err != nil {
+			return nil, err
+		}
+		eligibleOccupancies = append(eligibleOccupancies, eligibleOccupancy)
+	}
+
+	return eligibleOccupancies, rows.Err()
+}
+
+func scanEligibleOccupancy(row pgx.Row) (models.EligibleOccupancy, error) {
+	var eligibleOccupancy models.EligibleOccupancy
+	site := &eligibleOccupancy.Site
+
+	err := row.Scan(&site.SiteID,
+		&site.Postcode,
+		&site.UPRN,
+		&site.BuildingNameNumber,
+		&site.DependentThoroughfare,
+		&site.Thoroughfare,
+		&site.DoubleDependentLocality,
+		&site.DependentLocality,
+		&site.Locality,
+		&site.County,
+		&site.Town,
+		&site.Department,
+		&site.Organisation,
+		&site.PoBox,
+		&site.DeliveryPointSuffix,
+		&site.SubBuildingNameNumber,
+		&eligibleOccupancy.Eligibility.OccupancyID,
+		&eligibleOccupancy.Eligibility.Reference,
+	)
+
+	return eligibleOccupancy, err
+}
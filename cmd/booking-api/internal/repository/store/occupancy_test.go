@@ -335,3 +335,93 @@ func Test_OccupancyStore_GetSiteExternalReferenceByAccountID(t *testing.T) {
 
 	}
 }
+
+func Test_OccupancyStore_GetEligibleOccupancies(t *testing.T) {
+	ctx := context.Background()
+
+	testContainer, err := setupTestContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dsn, err := postgres.GetTestContainerDSN(testContainer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := store.Setup(ctx, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = populateDB(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	occupancyStore := store.NewOccupancy(db)
+
+	expectedSite := models.Site{
+		SiteID:                  "site-id-a",
+		Postcode:                "post-code-1",
+		UPRN:                    "uprn",
+		BuildingNameNumber:      "building-name-number",
+		DependentThoroughfare:   "dependent-thoroughfare",
+		Thoroughfare:            "thoroughfare",
+		DoubleDependentLocality: "double-dependent-locality",
+		DependentLocality:       "dependent-locality",
+		Locality:                "locality",
+		County:                  "county",
+		Town:                    "town",
+		Department:              "department",
+		Organisation:            "organisation",
+		PoBox:                   "po-box",
+		DeliveryPointSuffix:     "deliver-point-suffix",
+		SubBuildingNameNumber:   "sub-building-name-number",
+	}
+	expectedEligibility := models.OccupancyEligibility{
+		OccupancyID: "occupancy-id-#1",
+		Reference:   "ref##1",
+	}
+
+	t.Run("should get the site and the external reference by occupancy-id", func(t *testing.T) {
+		site, occupancyEligibility, err := occupancyStore.GetSiteExternalReferenceByOccupancyID(ctx, "occupancy-id-#1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(&expectedSite, site); diff != "" {
+			t.Fatal(diff)
+		}
+		if diff := cmp.Diff(&expectedEligibility, occupancyEligibility); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("should not find an occupancy which is not eligible", func(t *testing.T) {
+		_, _, err := occupancyStore.GetSiteExternalReferenceByOccupancyID(ctx, "occupancy-id-A")
+		if diff := cmp.Diff(store.ErrNoEligibleOccupancyFound, err, cmpopts.EquateErrors()); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("should list the eligible occupancies of an account", func(t *testing.T) {
+		eligibleOccupancies, err := occupancyStore.GetEligibleOccupanciesByAccountID(ctx, "account-id-#1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []models.EligibleOccupancy{{Site: expectedSite, Eligibility: expectedEligibility}}
+		if diff := cmp.Diff(expected, eligibleOccupancies); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("should list no occupancies for an account without eligible occupancies", func(t *testing.T) {
+		eligibleOccupancies, err := occupancyStore.GetEligibleOccupanciesByAccountID(ctx, "account-id-sorted")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]models.EligibleOccupancy{}, eligibleOccupancies); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
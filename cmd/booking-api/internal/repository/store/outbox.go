@@ -0,0 +1,200 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// The sinks the outbox events are published to.
+const (
+	OutboxSinkBooking         = "booking"
+	OutboxSinkComms           = "comms"
+	OutboxSinkRescheduleComms = "reschedule-comms"
+	OutboxSinkCommentCode     = "comment-code"
+)
+
+// OutboxStore keeps the events of booking-api until they are published to Kafka by the outbox relay,
+// so that an event is not lost when Kafka is unavailable while the request is served.
+type OutboxStore struct {
+	pool *pgxpool.Pool
+	now  func() time.Time
+}
+
+func NewOutbox(pool *pgxpool.Pool) *OutboxStore {
+	return &OutboxStore{pool: pool, now: time.Now}
+}
+
+// Add stores an event to be published to the sink, the events of a booking are published in the
+// order they were added. The event is added in the transaction of the context if there is one.
+func (s *OutboxStore) Add(ctx context.Context, sink, bookingID string, event proto.Message, occurredAt time.Time) error {
+	anyEvent, err := anypb.New(event)
+	if err != nil {
+		return fmt.Errorf("failed to wrap outbox event: %w", err)
+	}
+
+	marshalledEvent, err := proto.Marshal(anyEvent)
+	if err != nil {
+		return fmt.Errorf("failed to marshal outbox event: %w", err)
+	}
+
+	q := `
+	INSERT INTO booking_outbox (sink, booking_id, event, occurred_at, created_at, next_attempt_at)
+	VALUES ($1, $2, $3, $4, $5, $5);`
+
+	if _, err := execerFor(ctx, s.pool).Exec(ctx, q, sink, sql.NullString{String: bookingID, Valid: bookingID != ""}, marshalledEvent, occurredAt.UTC(), s.now().UTC()); err != nil {
+		return fmt.Errorf("failed to insert outbox event for booking ID %s: %w", bookingID, err)
+	}
+
+	return nil
+}
+
+// Claim returns up to limit events which are due to be published and hides them from other relays
+// for the lease. An event is only due once the earlier events of its booking were published or
+// dead-lettered, so the events of a booking are published in order.
+func (s *OutboxStore) Claim(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEvent, error) {
+	now := s.now().UTC()
+
+	q := `
+	UPDATE booking_outbox
+	SET attempts = attempts + 1, next_attempt_at = $2
+	WHERE id IN (
+		SELECT o.id
+		FROM booking_outbox o
+		WHERE o.dead_lettered_at IS NULL
+			AND o.next_attempt_at <= $1
+			AND (o.booking_id IS NULL OR NOT EXISTS (
+				SELECT 1
+				FROM booking_outbox p
+				WHERE p.booking_id = o.booking_id AND p.id < o.id AND p.dead_lettered_at IS NULL
+			))
+		ORDER BY o.id
+		LIMIT $3
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING id, sink, booking_id, event, occurred_at, created_at, attempts;`
+
+	rows, err := s.pool.Query(ctx, q, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events, %w", err)
+	}
+	defer rows.Close()
+
+	events := []models.OutboxEvent{}
+	for rows.Next() {
+		var (
+			event     models.OutboxEvent
+			bookingID sql.NullString
+			payload   []byte
+		)
+		if err := rows.Scan(&event.ID, &event.Sink, &bookingID, &payload, &event.OccurredAt, &event.CreatedAt, &event.Attempts); err != nil {
+			return nil, fmt.Errorf("failed to scan row, %w", err)
+		}
+
+		event.BookingID = bookingID.String
+		event.Event = &anypb.Any{}
+		if err := proto.Unmarshal(payload, event.Event); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal outbox event %d, %w", event.ID, err)
+		}
+
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events, %w", err)
+	}
+
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+
+	return events, nil
+}
+
+// MarkPublished removes a published event from the outbox.
+func (s *OutboxStore) MarkPublished(ctx context.Context, id int64) error {
+	q := `DELETE FROM booking_outbox WHERE id = $1;`
+
+	if _, err := s.pool.Exec(ctx, q, id); err != nil {
+		return fmt.Errorf("failed to delete published outbox event %d, %w", id, err)
+	}
+
+	return nil
+}
+
+// MarkFailed records why an event failed to be published and when it is to be retried.
+func (s *OutboxStore) MarkFailed(ctx context.Context, id int64, nextAttemptAt time.Time, reason string) error {
+	q := `UPDATE booking_outbox SET next_attempt_at = $2, last_error = $3 WHERE id = $1;`
+
+	if _, err := s.pool.Exec(ctx, q, id, nextAttemptAt.UTC(), reason); err != nil {
+		return fmt.Errorf("failed to mark outbox event %d as failed, %w", id, err)
+	}
+
+	return nil
+}
+
+// MarkDeadLettered stops retrying an event, it is kept in the outbox to be looked into.
+func (s *OutboxStore) MarkDeadLettered(ctx context.Context, id int64, reason string) error {
+	q := `UPDATE booking_outbox SET dead_lettered_at = $2, last_error = $3 WHERE id = $1;`
+
+	if _, err := s.pool.Exec(ctx, q, id, s.now().UTC(), reason); err != nil {
+		return fmt.Errorf("failed to mark outbox event %d as dead-lettered, %w", id, err)
+	}
+
+	return nil
+}
+
+// Stats counts the pending and dead-lettered events of the outbox.
+func (s *OutboxStore) Stats(ctx context.Context) (models.OutboxStats, error) {
+	var (
+		stats         models.OutboxStats
+		oldestPending sql.NullTime
+	)
+
+	q := `
+	SELECT
+		COUNT(*) FILTER (WHERE dead_lettered_at IS NULL),
+		COUNT(*) FILTER (WHERE dead_lettered_at IS NOT NULL),
+		MIN(created_at) FILTER (WHERE dead_lettered_at IS NULL)
+	FROM booking_outbox;`
+
+	if err := s.pool.QueryRow(ctx, q).Scan(&stats.Pending, &stats.DeadLettered, &oldestPending); err != nil {
+		return models.OutboxStats{}, fmt.Errorf("failed to get outbox stats, %w", err)
+	}
+
+	if oldestPending.Valid {
+		stats.OldestPendingAt = &oldestPending.Time
+	}
+
+	return stats, nil
+}
+
+// OutboxSink adds the events sunk to it to the outbox for one of the sinks, in place of publishing
+// them to Kafka straight away.
+type OutboxSink struct {
+	outbox *OutboxStore
+	sink   string
+}
+
+func NewOutboxSink(outbox *OutboxStore, sink string) *OutboxSink {
+	return &OutboxSink{outbox: outbox, sink: sink}
+}
+
+func (s *OutboxSink) Sink(ctx context.Context, event proto.Message, at time.Time) error {
+	return s.outbox.Add(ctx, s.sink, outboxBookingID(event), event, at)
+}
+
+// outboxBookingID returns the booking ID of the events which have one.
+func outboxBookingID(event proto.Message) string {
+	msg := event.ProtoReflect()
+	fd := msg.Descriptor().Fields().ByName("booking_id")
+	if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() {
+		return ""
+	}
+	return msg.Get(fd).String()
+}
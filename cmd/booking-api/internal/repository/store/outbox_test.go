@@ -0,0 +1,134 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	bookingv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/booking/v1"
+	"github.com/utilitywarehouse/energy-pkg/postgres"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/repository/store"
+	"google.golang.org/protobuf/proto"
+)
+
+func Test_OutboxStore(t *testing.T) {
+	ctx := context.Background()
+
+	testContainer, err := setupTestContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dsn, err := postgres.GetTestContainerDSN(testContainer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := store.Setup(ctx, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outboxStore := store.NewOutbox(db)
+	occurredAt := time.Date(2023, time.December, 1, 10, 0, 0, 0, time.UTC)
+
+	created := &bookingv1.BookingCreatedEvent{BookingId: "booking-id-1"}
+	rescheduled := &bookingv1.BookingRescheduledEvent{BookingId: "booking-id-1"}
+	cancelled := &bookingv1.BookingCancelledEvent{BookingId: "booking-id-2"}
+
+	if err := store.NewOutboxSink(outboxStore, store.OutboxSinkBooking).Sink(ctx, created, occurredAt); err != nil {
+		t.Fatalf("failed to add outbox event: %s", err)
+	}
+	if err := store.NewOutboxSink(outboxStore, store.OutboxSinkBooking).Sink(ctx, rescheduled, occurredAt); err != nil {
+		t.Fatalf("failed to add outbox event: %s", err)
+	}
+	if err := store.NewOutboxSink(outboxStore, store.OutboxSinkBooking).Sink(ctx, cancelled, occurredAt); err != nil {
+		t.Fatalf("failed to add outbox event: %s", err)
+	}
+
+	claimed := claimOutboxEvents(ctx, t, outboxStore)
+	// the reschedule waits for the creation of its booking to be published
+	expected := []claimedOutboxEvent{
+		{BookingID: "booking-id-1", Event: created, Attempts: 1},
+		{BookingID: "booking-id-2", Event: cancelled, Attempts: 1},
+	}
+	if diff := cmp.Diff(expected, claimed.events, cmp.Comparer(proto.Equal)); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// the claimed events are hidden from other relays
+	if events := claimOutboxEvents(ctx, t, outboxStore); len(events.events) != 0 {
+		t.Fatalf("expected no events to be due, got %d", len(events.events))
+	}
+
+	if err := outboxStore.MarkPublished(ctx, claimed.ids[0]); err != nil {
+		t.Fatalf("failed to mark outbox event as published: %s", err)
+	}
+	if err := outboxStore.MarkDeadLettered(ctx, claimed.ids[1], "kafka is down"); err != nil {
+		t.Fatalf("failed to mark outbox event as dead-lettered: %s", err)
+	}
+
+	claimed = claimOutboxEvents(ctx, t, outboxStore)
+	expected = []claimedOutboxEvent{
+		{BookingID: "booking-id-1", Event: rescheduled, Attempts: 1},
+	}
+	if diff := cmp.Diff(expected, claimed.events, cmp.Comparer(proto.Equal)); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if err := outboxStore.MarkFailed(ctx, claimed.ids[0], time.Now().Add(-time.Second), "kafka is down"); err != nil {
+		t.Fatalf("failed to mark outbox event as failed: %s", err)
+	}
+
+	claimed = claimOutboxEvents(ctx, t, outboxStore)
+	expected = []claimedOutboxEvent{
+		{BookingID: "booking-id-1", Event: rescheduled, Attempts: 2},
+	}
+	if diff := cmp.Diff(expected, claimed.events, cmp.Comparer(proto.Equal)); diff != "" {
+		t.Fatal(diff)
+	}
+
+	stats, err := outboxStore.Stats(ctx)
+	if err != nil {
+		t.Fatalf("failed to get outbox stats: %s", err)
+	}
+	if stats.Pending != 1 || stats.DeadLettered != 1 || stats.OldestPendingAt == nil {
+		t.Fatalf("unexpected outbox stats: %+v", stats)
+	}
+}
+
+type claimedOutboxEvent struct {
+	BookingID string
+	Event     proto.Message
+	Attempts  int
+}
+
+type claimedOutboxEvents struct {
+	ids    []int64
+	events []claimedOutboxEvent
+}
+
+func claimOutboxEvents(ctx context.Context, t *testing.T, outboxStore *store.OutboxStore) claimedOutboxEvents {
+	t.Helper()
+
+	events, err := outboxStore.Claim(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatalf("failed to claim outbox events: %s", err)
+	}
+
+	claimed := claimedOutboxEvents{events: []claimedOutboxEvent{}}
+	for _, event := range events {
+		msg, err := event.Event.UnmarshalNew()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if event.Sink != store.OutboxSinkBooking {
+			t.Fatalf("expected sink %s, got %s", store.OutboxSinkBooking, event.Sink)
+		}
+		claimed.ids = append(claimed.ids, event.ID)
+		claimed.events = append(claimed.events, claimedOutboxEvent{BookingID: event.BookingID, Event: msg, Attempts: event.Attempts})
+	}
+
+	return claimed
+}
@@ -0,0 +1,134 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
+)
+
+const prefixKeySlotHold = "slot-hold"
+
+var ErrSlotFullyHeld = errors.New("slot is held by as many customers as its capacity")
+
+// holdSlotScript holds a slot for an account unless other accounts already hold as many as the capacity.
+// The holds of a slot are a sorted set of accounts scored by when their hold expires, and each account
+// points at the slot it holds so that holding another slot releases the previous one.
+//
+// KEYS[1] slot key, KEYS[2] account key
+// ARGV[1] now, ARGV[2] hold expiry (both unix ms), ARGV[3] capacity, ARGV[4] ttl in ms, ARGV[5] account id
+var holdSlotScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now)
+if not redis.call('ZSCORE', KEYS[1], ARGV[5]) and redis.call('ZCARD', KEYS[1]) >= tonumber(ARGV[3]) then
+	return 0
+end
+local previous = redis.call('GET', KEYS[2])
+if previous and previous ~= KEYS[1] then
+	redis.call('ZREM', previous, ARGV[5])
+end
+redis.call('ZADD', KEYS[1], ARGV[2], ARGV[5])
+redis.call('PEXPIRE', KEYS[1], ARGV[4])
+redis.call('SET', KEYS[2], KEYS[1], 'PX', ARGV[4])
+return 1
+`)
+
+// releaseSlotScript removes the hold of an account on a slot.
+//
+// KEYS[1] slot key, KEYS[2] account key
+// ARGV[1] account id
+var releaseSlotScript = redis.NewScript(`
+redis.call('ZREM', KEYS[1], ARGV[1])
+if redis.call('GET', KEYS[2]) == KEYS[1] then
+	redis.call('DEL', KEYS[2])
+end
+return 1
+`)
+
+// SlotHoldStore keeps short lived holds on LowriBeck slots so that a slot chosen by a customer
+// is not offered to more customers than it can take while their bookings are being made.
+// LowriBeck engineers cover areas wider than a postcode, so the slots are identified by the
+// outward code of the postcode along with their date and times.
+type SlotHoldStore struct {
+	r        *redis.Client
+	ttl      time.Duration
+	capacity int
+	now      func() time.Time
+}
+
+func NewSlotHoldStore(r *redis.Client, ttl time.Duration, capacity int) *SlotHoldStore {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &SlotHoldStore{r: r, ttl: ttl, capacity: capacity, now: time.Now}
+}
+
+func (s *SlotHoldStore) slotKey(postcode string, slot models.BookingSlot) string {
+	outwardCode, _, _ := strings.Cut(strings.TrimSpace(postcode), " ")
+	return fmt.Sprintf("%s:%s:%s:%d-%d", prefixKeySlotHold, strings.ToUpper(outwardCode), slot.Date.Format(time.DateOnly), slot.StartTime, slot.EndTime)
+}
+
+func (s *SlotHoldStore) accountKey(accountID string) string {
+	return fmt.Sprintf("%s:account:%s", prefixKeySlotHold, accountID)
+}
+
+// Hold holds the slot for the account, replacing any other slot it held, and returns when the hold
+// expires. Holding a slot again extends the hold.
+func (s *SlotHoldStore) Hold(ctx context.Context, accountID, postcode string, slot models.BookingSlot) (time.Time, error) {
+	now := s.now()
+	expiresAt := now.Add(s.ttl)
+
+	held, err := holdSlotScript.Run(ctx, s.r,
+		[]string{s.slotKey(postcode, slot), s.accountKey(accountID)},
+		now.UnixMilli(), expiresAt.UnixMilli(), s.capacity, s.ttl.Milliseconds(), accountID,
+	).Int()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to hold slot: %w", err)
+	}
+	if held == 0 {
+		return time.Time{}, ErrSlotFullyHeld
+	}
+
+	return expiresAt, nil
+}
+
+// Release removes the hold of the account on the slot, e.g. once it was booked.
+func (s *SlotHoldStore) Release(ctx context.Context, accountID, postcode string, slot models.BookingSlot) error {
+	err := releaseSlotScript.Run(ctx, s.r, []string{s.slotKey(postcode, slot), s.accountKey(accountID)}, accountID).Err()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("failed to release slot: %w", err)
+	}
+	return nil
+}
+
+// FullyHeld reports for each slot whether accounts other than the given one hold it up to its capacity.
+func (s *SlotHoldStore) FullyHeld(ctx context.Context, accountID, postcode string, slots []models.BookingSlot) ([]bool, error) {
+	now := s.now().UnixMilli()
+
+	pipe := s.r.Pipeline()
+	counts := make([]*redis.IntCmd, len(slots))
+	own := make([]*redis.FloatCmd, len(slots))
+	for i, slot := range slots {
+		key := s.slotKey(postcode, slot)
+		counts[i] = pipe.ZCount(ctx, key, fmt.Sprintf("(%d", now), "+inf")
+		own[i] = pipe.ZScore(ctx, key, accountID)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("failed to count slot holds: %w", err)
+	}
+
+	fullyHeld := make([]bool, len(slots))
+	for i := range slots {
+		others := counts[i].Val()
+		if score, err := own[i].Result(); err == nil && int64(score) > now {
+			others--
+		}
+		fullyHeld[i] = others >= int64(s.capacity)
+	}
+
+	return fullyHeld, nil
+}
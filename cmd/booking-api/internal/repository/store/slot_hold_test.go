@@ -0,0 +1,85 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/go-cmp/cmp"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/repository/store"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
+)
+
+func Test_SlotHoldStore(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := SetupRedisTestContainer(ctx)
+	if err != nil {
+		t.Fatalf("could not set up redis test container: %s", err.Error())
+	}
+	defer func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	containerAddr, err := GetRedisTestContainerAddr(ctx, container)
+	if err != nil {
+		t.Fatalf("could not get redis test container address: %s", err.Error())
+	}
+	slotHoldStore := store.NewSlotHoldStore(redis.NewClient(&redis.Options{Addr: containerAddr}), time.Minute, 1)
+
+	morning := models.BookingSlot{Date: time.Date(2023, time.December, 5, 0, 0, 0, 0, time.UTC), StartTime: 8, EndTime: 12}
+	afternoon := models.BookingSlot{Date: time.Date(2023, time.December, 5, 0, 0, 0, 0, time.UTC), StartTime: 12, EndTime: 16}
+
+	if _, err := slotHoldStore.Hold(ctx, "account-id-1", "E2 1ZZ", morning); err != nil {
+		t.Fatalf("failed to hold slot: %s", err)
+	}
+
+	// holding again extends the hold
+	if _, err := slotHoldStore.Hold(ctx, "account-id-1", "E2 1ZZ", morning); err != nil {
+		t.Fatalf("failed to hold slot again: %s", err)
+	}
+
+	// the slot is held up to its capacity in the whole outward code
+	if _, err := slotHoldStore.Hold(ctx, "account-id-2", "E2 7AA", morning); !errors.Is(err, store.ErrSlotFullyHeld) {
+		t.Fatalf("expected %s, got %v", store.ErrSlotFullyHeld, err)
+	}
+
+	fullyHeld, err := slotHoldStore.FullyHeld(ctx, "account-id-2", "E2 1ZZ", []models.BookingSlot{morning, afternoon})
+	if err != nil {
+		t.Fatalf("failed to check slot holds: %s", err)
+	}
+	if diff := cmp.Diff([]bool{true, false}, fullyHeld); diff != "" {
+		t.Fatal(diff)
+	}
+
+	fullyHeld, err = slotHoldStore.FullyHeld(ctx, "account-id-1", "E2 1ZZ", []models.BookingSlot{morning, afternoon})
+	if err != nil {
+		t.Fatalf("failed to check slot holds: %s", err)
+	}
+	if diff := cmp.Diff([]bool{false, false}, fullyHeld); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// holding another slot releases the previous one
+	if _, err := slotHoldStore.Hold(ctx, "account-id-1", "E2 1ZZ", afternoon); err != nil {
+		t.Fatalf("failed to hold slot: %s", err)
+	}
+	if _, err := slotHoldStore.Hold(ctx, "account-id-2", "E2 1ZZ", morning); err != nil {
+		t.Fatalf("failed to hold released slot: %s", err)
+	}
+
+	if err := slotHoldStore.Release(ctx, "account-id-1", "E2 1ZZ", afternoon); err != nil {
+		t.Fatalf("failed to release slot: %s", err)
+	}
+	fullyHeld, err = slotHoldStore.FullyHeld(ctx, "account-id-2", "E2 1ZZ", []models.BookingSlot{afternoon})
+	if err != nil {
+		t.Fatalf("failed to check slot holds: %s", err)
+	}
+	if diff := cmp.Diff([]bool{false}, fullyHeld); diff != "" {
+		t.Fatal(diff)
+	}
+}
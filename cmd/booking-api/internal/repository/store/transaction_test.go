@@ -0,0 +1,92 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	bookingv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/booking/v1"
+	"github.com/utilitywarehouse/energy-pkg/postgres"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/repository/store"
+	"google.golang.org/protobuf/proto"
+)
+
+func Test_Transactor(t *testing.T) {
+	ctx := context.Background()
+
+	testContainer, err := setupTestContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dsn, err := postgres.GetTestContainerDSN(testContainer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := store.Setup(ctx, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transactor := store.NewTransactor(db)
+	outboxStore := store.NewOutbox(db)
+	bookingSink := store.NewOutboxSink(outboxStore, store.OutboxSinkBooking)
+	idempotencyStore := store.NewIdempotencyStore(db, time.Hour, time.Hour)
+	occurredAt := time.Date(2023, time.December, 1, 10, 0, 0, 0, time.UTC)
+	rescheduled := &bookingv1.BookingRescheduledEvent{BookingId: "booking-id-1"}
+	errCommsSink := errors.New("comms sink failed")
+
+	if _, err := idempotencyStore.Reserve(ctx, "account-id-1", "reschedule-booking", "key-1", "hash-1"); err != nil {
+		t.Fatalf("failed to reserve idempotency key: %s", err)
+	}
+
+	// the booking event is rolled back with the idempotency key when the comms event fails
+	err = transactor.InTx(ctx, func(ctx context.Context) error {
+		if err := bookingSink.Sink(ctx, rescheduled, occurredAt); err != nil {
+			return err
+		}
+		if err := idempotencyStore.Complete(ctx, "account-id-1", "reschedule-booking", "key-1", "booking-id-1"); err != nil {
+			return err
+		}
+		return errCommsSink
+	})
+	if !errors.Is(err, errCommsSink) {
+		t.Fatalf("expected %s, got %v", errCommsSink, err)
+	}
+
+	if events := claimOutboxEvents(ctx, t, outboxStore); len(events.events) != 0 {
+		t.Fatalf("expected no events to be added, got %d", len(events.events))
+	}
+	if _, err := idempotencyStore.Reserve(ctx, "account-id-1", "reschedule-booking", "key-1", "hash-1"); !errors.Is(err, store.ErrIdempotentRequestInProgress) {
+		t.Fatalf("expected %s, got %v", store.ErrIdempotentRequestInProgress, err)
+	}
+
+	err = transactor.InTx(ctx, func(ctx context.Context) error {
+		if err := bookingSink.Sink(ctx, rescheduled, occurredAt); err != nil {
+			return err
+		}
+		return idempotencyStore.Complete(ctx, "account-id-1", "reschedule-booking", "key-1", "booking-id-1")
+	})
+	if err != nil {
+		t.Fatalf("failed to commit transaction: %s", err)
+	}
+
+	claimed := claimOutboxEvents(ctx, t, outboxStore)
+	expected := []claimedOutboxEvent{
+		{BookingID: "booking-id-1", Event: rescheduled, Attempts: 1},
+	}
+	if diff := cmp.Diff(expected, claimed.events, cmp.Comparer(proto.Equal)); diff != "" {
+		t.Fatal(diff)
+	}
+
+	bookingID, err := idempotencyStore.Reserve(ctx, "account-id-1", "reschedule-booking", "key-1", "hash-1")
+	if err != nil {
+		t.Fatalf("failed to reserve idempotency key: %s", err)
+	}
+	if bookingID != "booking-id-1" {
+		t.Fatalf("expected booking ID booking-id-1, got %s", bookingID)
+	}
+}
@@ -0,0 +1,153 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
+)
+
+const (
+	outboxBatchSize   = 100
+	outboxClaimLease  = time.Minute
+	outboxBaseBackoff = time.Second
+	outboxMaxBackoff  = 10 * time.Minute
+)
+
+var outboxEventsMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "booking_outbox_events_total",
+	Help: "the count of outbox events by sink and result of their publishing attempt",
+}, []string{"sink", "result"})
+
+var outboxPendingEventsMetric = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "booking_outbox_pending_events",
+	Help: "the count of outbox events waiting to be published",
+})
+
+var outboxDeadLetteredEventsMetric = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "booking_outbox_dead_lettered_events",
+	Help: "the count of outbox events which could not be published and are no longer retried",
+})
+
+var outboxOldestPendingEventAgeMetric = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "booking_outbox_oldest_pending_event_age_seconds",
+	Help: "the age of the oldest outbox event waiting to be published",
+})
+
+const (
+	OutboxPublished    = "published"
+	OutboxRetried      = "retried"
+	OutboxDeadLettered = "dead_lettered"
+)
+
+type OutboxStore interface {
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEvent, error)
+	MarkPublished(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64, nextAttemptAt time.Time, reason string) error
+	MarkDeadLettered(ctx context.Context, id int64, reason string) error
+	Stats(ctx context.Context) (models.OutboxStats, error)
+}
+
+// OutboxRelay publishes the events of the outbox to their sinks, retrying with an exponential
+// backoff the ones which fail until they are dead-lettered after maxAttempts.
+type OutboxRelay struct {
+	outboxStore OutboxStore
+	publishers  map[string]BookingPublisher
+	maxAttempts int
+}
+
+func NewOutboxRelay(outboxStore OutboxStore, publishers map[string]BookingPublisher, maxAttempts int) *OutboxRelay {
+	return &OutboxRelay{outboxStore, publishers, maxAttempts}
+}
+
+// Run publishes the events which are due, it returns whether there may be more of them.
+func (r OutboxRelay) Run(ctx context.Context) (bool, error) {
+	events, err := r.outboxStore.Claim(ctx, outboxBatchSize, outboxClaimLease)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim outbox events, %w", err)
+	}
+
+	for _, event := range events {
+		if err := r.publish(ctx, event); err != nil {
+			return false, err
+		}
+	}
+
+	if err := r.updateStats(ctx); err != nil {
+		slog.Warn("failed to update outbox stats", "error", err)
+	}
+
+	return len(events) == outboxBatchSize, nil
+}
+
+func (r OutboxRelay) publish(ctx context.Context, event models.OutboxEvent) error {
+	publishErr := r.sink(ctx, event)
+	if publishErr == nil {
+		if err := r.outboxStore.MarkPublished(ctx, event.ID); err != nil {
+			return fmt.Errorf("failed to mark outbox event %d as published, %w", event.ID, err)
+		}
+		outboxEventsMetric.WithLabelValues(event.Sink, OutboxPublished).Inc()
+		return nil
+	}
+
+	if event.Attempts >= r.maxAttempts {
+		slog.Error("dead-lettering outbox event", "error", publishErr, "outbox_event_id", event.ID, "sink", event.Sink, "booking_id", event.BookingID, "attempts", event.Attempts)
+		if err := r.outboxStore.MarkDeadLettered(ctx, event.ID, publishErr.Error()); err != nil {
+			return fmt.Errorf("failed to mark outbox event %d as dead-lettered, %w", event.ID, err)
+		}
+		outboxEventsMetric.WithLabelValues(event.Sink, OutboxDeadLettered).Inc()
+		return nil
+	}
+
+	slog.Warn("failed to publish outbox event, retrying", "error", publishErr, "outbox_event_id", event.ID, "sink", event.Sink, "booking_id", event.BookingID, "attempts", event.Attempts)
+	if err := r.outboxStore.MarkFailed(ctx, event.ID, time.Now().Add(outboxBackoff(event.Attempts)), publishErr.Error()); err != nil {
+		return fmt.Errorf("failed to mark outbox event %d as failed, %w", event.ID, err)
+	}
+	outboxEventsMetric.WithLabelValues(event.Sink, OutboxRetried).Inc()
+
+	return nil
+}
+
+func (r OutboxRelay) sink(ctx context.Context, event models.OutboxEvent) error {
+	publisher, ok := r.publishers[event.Sink]
+	if !ok {
+		return fmt.Errorf("unknown outbox sink %s", event.Sink)
+	}
+
+	msg, err := event.Event.UnmarshalNew()
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal outbox event, %w", err)
+	}
+
+	return publisher.Sink(ctx, msg, event.OccurredAt)
+}
+
+func (r OutboxRelay) updateStats(ctx context.Context) error {
+	stats, err := r.outboxStore.Stats(ctx)
+	if err != nil {
+		return err
+	}
+
+	outboxPendingEventsMetric.Set(float64(stats.Pending))
+	outboxDeadLetteredEventsMetric.Set(float64(stats.DeadLettered))
+	if stats.OldestPendingAt != nil {
+		outboxOldestPendingEventAgeMetric.Set(time.Since(*stats.OldestPendingAt).Seconds())
+	} else {
+		outboxOldestPendingEventAgeMetric.Set(0)
+	}
+
+	return nil
+}
+
+// outboxBackoff doubles the wait before retrying an event with every failed attempt.
+func outboxBackoff(attempts int) time.Duration {
+	backoff := outboxBaseBackoff
+	for i := 1; i < attempts && backoff < outboxMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, outboxMaxBackoff)
+}
@@ -0,0 +1,99 @@
+//go:generate mockgen -source=outbox_relay.go -destination ./mocks/outbox_relay_mocks.go
+
+package workers_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	bookingv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/booking/v1"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/workers"
+	mocks "github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/workers/mocks"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func mustAny(t *testing.T, event *bookingv1.BookingCreatedEvent) *anypb.Any {
+	t.Helper()
+
+	a, err := anypb.New(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func Test_OutboxRelay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	defer ctrl.Finish()
+
+	mockOutboxStore := mocks.NewMockOutboxStore(ctrl)
+	mockBookingPublisher := mocks.NewMockBookingPublisher(ctrl)
+	mockCommsPublisher := mocks.NewMockBookingPublisher(ctrl)
+
+	relay := workers.NewOutboxRelay(mockOutboxStore, map[string]workers.BookingPublisher{
+		"booking": mockBookingPublisher,
+		"comms":   mockCommsPublisher,
+	}, 3)
+
+	occurredAt := time.Date(2023, time.December, 1, 10, 0, 0, 0, time.UTC)
+	event1 := &bookingv1.BookingCreatedEvent{BookingId: "booking-id-1"}
+	event2 := &bookingv1.BookingCreatedEvent{BookingId: "booking-id-2"}
+	event3 := &bookingv1.BookingCreatedEvent{BookingId: "booking-id-3"}
+
+	mockOutboxStore.EXPECT().Claim(ctx, 100, time.Minute).Return([]models.OutboxEvent{
+		{ID: 1, Sink: "booking", BookingID: "booking-id-1", Event: mustAny(t, event1), OccurredAt: occurredAt, Attempts: 1},
+		{ID: 2, Sink: "comms", BookingID: "booking-id-2", Event: mustAny(t, event2), OccurredAt: occurredAt, Attempts: 1},
+		{ID: 3, Sink: "booking", BookingID: "booking-id-3", Event: mustAny(t, event3), OccurredAt: occurredAt, Attempts: 3},
+		{ID: 4, Sink: "unknown", Event: mustAny(t, event1), OccurredAt: occurredAt, Attempts: 3},
+	}, nil)
+
+	gomock.InOrder(
+		mockBookingPublisher.EXPECT().Sink(ctx, protoMatcher{event1}, occurredAt).Return(nil),
+		mockOutboxStore.EXPECT().MarkPublished(ctx, int64(1)).Return(nil),
+	)
+
+	gomock.InOrder(
+		mockCommsPublisher.EXPECT().Sink(ctx, protoMatcher{event2}, occurredAt).Return(errors.New("kafka is down")),
+		mockOutboxStore.EXPECT().MarkFailed(ctx, int64(2), gomock.Any(), "kafka is down").Return(nil),
+	)
+
+	gomock.InOrder(
+		mockBookingPublisher.EXPECT().Sink(ctx, protoMatcher{event3}, occurredAt).Return(errors.New("kafka is down")),
+		mockOutboxStore.EXPECT().MarkDeadLettered(ctx, int64(3), "kafka is down").Return(nil),
+	)
+
+	mockOutboxStore.EXPECT().MarkDeadLettered(ctx, int64(4), "unknown outbox sink unknown").Return(nil)
+
+	mockOutboxStore.EXPECT().Stats(ctx).Return(models.OutboxStats{Pending: 1, DeadLettered: 2}, nil)
+
+	more, err := relay.Run(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if more {
+		t.Fatal("expected no more events to be due")
+	}
+}
+
+// protoMatcher matches the events which were unmarshalled from the outbox.
+type protoMatcher struct {
+	expected proto.Message
+}
+
+func (m protoMatcher) Matches(x interface{}) bool {
+	actual, ok := x.(proto.Message)
+	return ok && proto.Equal(m.expected, actual)
+}
+
+func (m protoMatcher) String() string {
+	return fmt.Sprintf("is equal to %v", m.expected)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/urfave/cli/v2"
+	"github.com/utilitywarehouse/energy-pkg/app"
+	"github.com/utilitywarehouse/energy-pkg/substratemessage/v2"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/consumer"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/repository/store"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/publisher"
+	"github.com/utilitywarehouse/go-ops-health-checks/pkg/sqlhealth"
+	"github.com/utilitywarehouse/go-ops-health-checks/v3/pkg/substratehealth"
+	"github.com/uw-labs/substrate"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	commandNameJobOutcomeWorker  = "job-outcome-worker"
+	commandUsageJobOutcomeWorker = "a consumer that turns the Lowri-Beck job outcomes into booking status changes"
+
+	flagJobOutcomeTopic = "job-outcome-topic"
+)
+
+func init() {
+	application.Commands = append(application.Commands, &cli.Command{
+		Name:   commandNameJobOutcomeWorker,
+		Usage:  commandUsageJobOutcomeWorker,
+		Action: jobOutcomeWorkerAction,
+		Flags: app.DefaultFlags().WithCustom(
+			&cli.StringFlag{
+				Name:     flagPostgresDSN,
+				EnvVars:  []string{"POSTGRES_DSN"},
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     flagJobOutcomeTopic,
+				EnvVars:  []string{"JOB_OUTCOME_TOPIC"},
+				Required: true,
+			},
+			&cli.IntFlag{
+				Name:    flagBatchSize,
+				EnvVars: []string{"BATCH_SIZE"},
+				Value:   1,
+			},
+		),
+	})
+}
+
+func jobOutcomeWorkerAction(c *cli.Context) error {
+	slog.Info("starting app", "git_hash", gitHash, "command", commandNameJobOutcomeWorker)
+
+	opsServer := makeOps(c)
+
+	ctx, cancel := context.WithCancel(c.Context)
+	defer cancel()
+
+	pool, err := store.Setup(ctx, c.String(flagPostgresDSN))
+	if err != nil {
+		return err
+	}
+	opsServer.Add("pool", sqlhealth.NewCheck(stdlib.OpenDB(*pool.Config().ConnConfig), "unable to connect to the DB"))
+
+	jobOutcomeSource, err := app.GetKafkaSource(c, c.String(app.KafkaConsumerGroup), c.String(flagJobOutcomeTopic))
+	if err != nil {
+		return fmt.Errorf("unable to connect to job outcome [%s] kafka source: %w", c.String(flagJobOutcomeTopic), err)
+	}
+	defer jobOutcomeSource.Close()
+	opsServer.Add("job-outcome-source", substratehealth.NewCheck(jobOutcomeSource, "unable to consume job outcome events"))
+
+	bookingSink, err := app.GetKafkaSinkWithBroker(c.String(flagBookingTopic), c.String(app.KafkaVersion), c.StringSlice(app.KafkaBrokers))
+	if err != nil {
+		return fmt.Errorf("unable to connect to booking [%s] kafka sink: %w", c.String(flagBookingTopic), err)
+	}
+	defer bookingSink.Close()
+	opsServer.Add("booking-sink", substratehealth.NewCheck(bookingSink, "unable to sink booking events"))
+
+	syncBookingPublisher := publisher.NewSyncPublisher(substrate.NewSynchronousMessageSink(bookingSink), c.App.Name)
+
+	jobOutcomeHandler := consumer.HandleJobOutcome(store.NewBooking(pool), syncBookingPublisher)
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		defer slog.Info("ops server finished")
+		return opsServer.Start(ctx)
+	})
+
+	g.Go(func() error {
+		defer slog.Info("job outcome consumer finished")
+		return substratemessage.BatchConsumer(ctx, c.Int(flagBatchSize), time.Second, jobOutcomeSource, jobOutcomeHandler)
+	})
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	g.Go(func() error {
+		defer slog.Info("signal handler finished")
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-sigChan:
+			cancel()
+		}
+		return nil
+	})
+
+	return g.Wait()
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/cache"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/domain"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/repository/store"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/booking-api/internal/workers"
 	"github.com/utilitywarehouse/energy-smart-booking/internal/auth"
 	"github.com/utilitywarehouse/energy-smart-booking/internal/publisher"
 	"github.com/utilitywarehouse/energy-smart-booking/internal/repository/gateway"
@@ -61,6 +62,18 @@ var (
 	flagChannel               = "flag-channel"
 
 	flagCommentCodeTopic = "comment-code-topic"
+
+	flagSlotHoldTTL      = "slot-hold-ttl"
+	flagSlotHoldCapacity = "slot-hold-capacity"
+
+	flagIdempotencyWindow         = "idempotency-window"
+	flagIdempotencyPendingTimeout = "idempotency-pending-timeout"
+
+	flagRescheduleMinimumNotice = "reschedule-minimum-notice"
+	flagMaxReschedules          = "max-reschedules"
+
+	flagOutboxPollInterval = "outbox-poll-interval"
+	flagOutboxMaxAttempts  = "outbox-max-attempts"
 )
 
 func init() {
@@ -95,6 +108,51 @@ func init() {
 				Required: true,
 				Value:    6,
 			},
+			&cli.DurationFlag{
+				Name:    flagSlotHoldTTL,
+				Usage:   "How long a slot chosen by a customer is held for their booking, 0 disables slot holds",
+				EnvVars: []string{"SLOT_HOLD_TTL"},
+			},
+			&cli.IntFlag{
+				Name:    flagSlotHoldCapacity,
+				Usage:   "The number of customers who can hold the same slot before it stops being offered",
+				EnvVars: []string{"SLOT_HOLD_CAPACITY"},
+				Value:   1,
+			},
+			&cli.DurationFlag{
+				Name:    flagRescheduleMinimumNotice,
+				Usage:   "How long before the booked slot a booking can no longer be rescheduled, 0 disables the check",
+				EnvVars: []string{"RESCHEDULE_MINIMUM_NOTICE"},
+			},
+			&cli.IntFlag{
+				Name:    flagMaxReschedules,
+				Usage:   "How many times a customer can reschedule a booking, 0 disables the check",
+				EnvVars: []string{"MAX_RESCHEDULES"},
+			},
+			&cli.DurationFlag{
+				Name:    flagIdempotencyWindow,
+				Usage:   "How long retries of a booking request with the same idempotency key return the original booking",
+				EnvVars: []string{"IDEMPOTENCY_WINDOW"},
+				Value:   24 * time.Hour,
+			},
+			&cli.DurationFlag{
+				Name:    flagIdempotencyPendingTimeout,
+				Usage:   "How long a booking request can hold its idempotency key before its retries fail as Unavailable rather than Aborted, it must be longer than the LowriBeck calls",
+				EnvVars: []string{"IDEMPOTENCY_PENDING_TIMEOUT"},
+				Value:   5 * time.Minute,
+			},
+			&cli.DurationFlag{
+				Name:    flagOutboxPollInterval,
+				Usage:   "How often the outbox relay looks for events to publish",
+				EnvVars: []string{"OUTBOX_POLL_INTERVAL"},
+				Value:   time.Second,
+			},
+			&cli.IntFlag{
+				Name:    flagOutboxMaxAttempts,
+				Usage:   "The number of attempts to publish an outbox event before it is dead-lettered",
+				EnvVars: []string{"OUTBOX_MAX_ATTEMPTS"},
+				Value:   15,
+			},
 			&cli.StringFlag{
 				Name:    flagPartialBookingCron,
 				EnvVars: []string{"PARTIAL_BOOKING_CRON"},
@@ -304,6 +362,8 @@ func serverAction(c *cli.Context) error {
 	bookingStore := store.NewBooking(pool)
 	partialBookingStore := store.NewPartialBooking(pool)
 	smartMeterInterestStore := store.NewSmartMeterInterestStore(pool)
+	idempotencyStore := store.NewIdempotencyStore(pool, c.Duration(flagIdempotencyWindow), c.Duration(flagIdempotencyPendingTimeout))
+	outboxStore := store.NewOutbox(pool)
 
 	// DOMAIN //
 	bookingDomain := domain.NewBookingDomain(
@@ -319,6 +379,13 @@ func serverAction(c *cli.Context) error {
 		clickGw,
 		true,
 	)
+	if ttl := c.Duration(flagSlotHoldTTL); ttl > 0 {
+		bookingDomain = bookingDomain.WithSlotHolds(store.NewSlotHoldStore(redis, ttl, c.Int(flagSlotHoldCapacity)))
+	}
+	bookingDomain = bookingDomain.WithReschedulePolicy(domain.ReschedulePolicy{
+		MinimumNotice:  c.Duration(flagRescheduleMinimumNotice),
+		MaxReschedules: c.Int(flagMaxReschedules),
+	})
 
 	interestDomain := domain.NewSmartMeterInterestDomain(
 		accountNumberGw,
@@ -328,15 +395,23 @@ func serverAction(c *cli.Context) error {
 	bookingAPI := api.New(
 		bookingDomain,
 		interestDomain,
-		syncBookingPublisher,
-		syncCommsPublisher,
-		syncRescheduleCommsPublisher,
-		syncBillCommentCodePublisher,
+		store.NewOutboxSink(outboxStore, store.OutboxSinkBooking),
+		store.NewOutboxSink(outboxStore, store.OutboxSinkComms),
+		store.NewOutboxSink(outboxStore, store.OutboxSinkRescheduleComms),
+		store.NewOutboxSink(outboxStore, store.OutboxSinkCommentCode),
 		auth,
 		true,
-	)
+	).WithIdempotency(idempotencyStore).WithTransactions(store.NewTransactor(pool))
 	bookingv1.RegisterBookingAPIServer(grpcServer, bookingAPI)
 
+	// WORKERS //
+	outboxRelay := workers.NewOutboxRelay(outboxStore, map[string]workers.BookingPublisher{
+		store.OutboxSinkBooking:         syncBookingPublisher,
+		store.OutboxSinkComms:           syncCommsPublisher,
+		store.OutboxSinkRescheduleComms: syncRescheduleCommsPublisher,
+		store.OutboxSinkCommentCode:     syncBillCommentCodePublisher,
+	}, c.Int(flagOutboxMaxAttempts))
+
 	g.Go(func() error {
 		defer slog.Info("ops server finished")
 		return opsServer.Start(ctx)
@@ -347,6 +422,44 @@ func serverAction(c *cli.Context) error {
 		return grpcServer.Serve(listen)
 	})
 
+	g.Go(func() error {
+		defer slog.Info("outbox relay finished")
+		ticker := time.NewTicker(c.Duration(flagOutboxPollInterval))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				for more := true; more && ctx.Err() == nil; {
+					var err error
+					if more, err = outboxRelay.Run(ctx); err != nil {
+						slog.Error("failed to relay outbox events", "error", err)
+					}
+				}
+			}
+		}
+	})
+
+	g.Go(func() error {
+		defer slog.Info("idempotency key cleanup finished")
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				deleted, err := idempotencyStore.DeleteExpired(ctx)
+				if err != nil {
+					slog.Error("failed to delete expired idempotency keys", "error", err)
+					continue
+				}
+				slog.Info("deleted expired idempotency keys", "count", deleted)
+			}
+		}
+	})
+
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 	g.Go(func() error {
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/evaluation"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/store"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/store/inmemory"
+)
+
+// runDryRun evaluates all live occupancies against the current and the candidate rule sets and meter
+// catalogues and writes a report of the differences, nothing is published or stored.
+func runDryRun(c *cli.Context) error {
+	ctx := c.Context
+	start := time.Now()
+
+	pool, err := store.Setup(ctx, c.String(postgresDSN))
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	meterStore := store.NewMeter(pool)
+	occupancyStore := store.NewOccupancy(pool)
+	serviceStore := store.NewService(pool)
+
+	msnExceptionStore, err := inmemory.NewMeterSerialNumber(c.String(msnExceptionFilePath))
+	if err != nil {
+		return fmt.Errorf("failed to load file with meter serial number with smart meter exclusion, %w", err)
+	}
+	psrCodeStore := inmemory.NewPSRCodes(c.StringSlice(psrSpecialistVisitCodes))
+	meterCatalogue, err := inmemory.NewMeterCatalogue(c.String(unsupportedSSCFilePath), c.String(standardMeterCapacityFilePath))
+	if err != nil {
+		return fmt.Errorf("failed to load meter catalogue, %w", err)
+	}
+
+	baseline, err := loadRuleSet(c)
+	if err != nil {
+		return err
+	}
+	candidate := baseline
+	if path := c.String(candidateRulesFilePath); path != "" {
+		candidate, err = evaluation.LoadRuleSet(path)
+		if err != nil {
+			return fmt.Errorf("failed to load candidate rule set, %w", err)
+		}
+	}
+
+	candidateCatalogue, err := loadCandidateMeterCatalogue(c)
+	if err != nil {
+		return err
+	}
+	if c.String(candidateRulesFilePath) == "" && candidateCatalogue == nil {
+		return fmt.Errorf("nothing to compare, provide a candidate rule set or meter catalogue")
+	}
+
+	// no publishers are needed as dry runs never publish the evaluation results
+	evaluator := evaluation.NewEvaluator(occupancyStore, serviceStore, meterStore, nil, nil, nil, nil, msnExceptionStore, psrCodeStore, meterCatalogue, baseline)
+
+	occupancyIDs, err := occupancyStore.GetLiveOccupancies(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get live occupancies to evaluate: %w", err)
+	}
+	slog.Info("starting dry run", "occupancies", len(occupancyIDs), "baseline_version", baseline.Version, "candidate_version", candidate.Version)
+
+	report := evaluation.NewDryRunReport(baseline.Version, candidate.Version)
+
+	channel := make(chan string, len(occupancyIDs))
+	for _, id := range occupancyIDs {
+		channel <- id
+	}
+	close(channel)
+
+	var wg sync.WaitGroup
+	for i := 0; i < c.Int(dryRunWorkers); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range channel {
+				result, err := evaluator.DryRun(ctx, id, candidate, candidateCatalogue)
+				if err != nil {
+					slog.Error("failed to run dry run evaluation", "occupancy_id", id, "error", err)
+					report.AddFailure()
+					continue
+				}
+				report.Add(result)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := writeFile(c.String(dryRunReportFilePath), report.WriteSummary); err != nil {
+		return fmt.Errorf("failed to write dry run report: %w", err)
+	}
+	if err := writeFile(c.String(dryRunChangesFilePath), report.WriteChangesCSV); err != nil {
+		return fmt.Errorf("failed to write dry run changes: %w", err)
+	}
+
+	slog.Info("dry run completed",
+		"evaluated", report.Evaluated,
+		"failed", report.Failed,
+		"changed", report.Changed,
+		"report", c.String(dryRunReportFilePath),
+		"changes", c.String(dryRunChangesFilePath),
+		"elapsed", time.Since(start).String())
+
+	return nil
+}
+
+// loadCandidateMeterCatalogue loads the candidate meter catalogue, if any of its files is provided. The
+// file which isn't provided is the one in use.
+func loadCandidateMeterCatalogue(c *cli.Context) (evaluation.MeterCatalogue, error) {
+	sscFilePath := c.String(candidateUnsupportedSSCFilePath)
+	capacityFilePath := c.String(candidateStandardMeterCapacityFilePath)
+	if sscFilePath == "" && capacityFilePath == "" {
+		return nil, nil
+	}
+
+	if sscFilePath == "" {
+		sscFilePath = c.String(unsupportedSSCFilePath)
+	}
+	if capacityFilePath == "" {
+		capacityFilePath = c.String(standardMeterCapacityFilePath)
+	}
+
+	catalogue, err := inmemory.NewMeterCatalogue(sscFilePath, capacityFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load candidate meter catalogue, %w", err)
+	}
+
+	ssc, capacity := catalogue.Versions()
+	slog.Info("loaded candidate meter catalogue", "unsupported_ssc_version", ssc.String(), "standard_capacity_version", capacity.String())
+
+	return catalogue, nil
+}
+
+func writeFile(path string, write func(w io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -51,6 +51,7 @@ func runEvaluator(c *cli.Context) error {
 
 	accountStore := store.NewAccount(pool)
 	bookingRefStore := store.NewBookingRef(pool)
+	bookingStore := store.NewBooking(pool)
 	meterStore := store.NewMeter(pool)
 	meterpointStore := store.NewMeterpoint(pool)
 	occupancyStore := store.NewOccupancy(pool)
@@ -62,6 +63,18 @@ func runEvaluator(c *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to load file with meter serial number with smart meter exclusion, %w", err)
 	}
+	opsServer.Add("msn-exceptions", msnExceptionStore.NewHealthCheck())
+	psrCodeStore := inmemory.NewPSRCodes(c.StringSlice(psrSpecialistVisitCodes))
+	meterCatalogue, err := inmemory.NewMeterCatalogue(c.String(unsupportedSSCFilePath), c.String(standardMeterCapacityFilePath))
+	if err != nil {
+		return fmt.Errorf("failed to load meter catalogue, %w", err)
+	}
+	opsServer.Add("meter-catalogue", meterCatalogue.NewHealthCheck())
+
+	ruleSet, err := loadRuleSet(c)
+	if err != nil {
+		return err
+	}
 
 	altHanSource, err := app.GetKafkaSource(c, c.String(app.KafkaConsumerGroup), c.String(altHanTopic))
 	if err != nil {
@@ -159,8 +172,21 @@ func runEvaluator(c *cli.Context) error {
 		campaignabilitySyncPublisher,
 		bookingEligibilitySyncPublisher,
 		msnExceptionStore,
+		psrCodeStore,
+		meterCatalogue,
+		ruleSet,
 	)
 
+	g.Go(func() error {
+		defer slog.Info("meter serial number exceptions watcher finished")
+		return msnExceptionStore.Watch(ctx, c.Duration(msnExceptionReloadInterval), func(ctx context.Context, msns []string) error {
+			return evaluator.ReevaluateMeterSerialNumbers(ctx, occupancyStore, msns)
+		})
+	})
+	g.Go(func() error {
+		defer slog.Info("meter catalogue watcher finished")
+		return meterCatalogue.Watch(ctx, c.Duration(meterCatalogueReloadInterval))
+	})
 	g.Go(func() error {
 		defer slog.Info("alt han events consumer finished")
 		return substratemessage.BatchConsumer(ctx, c.Int(batchSize), time.Second, altHanSource, consumer.HandleAltHan(meterpointStore, occupancyStore, evaluator, c.Bool(stateRebuild)))
@@ -169,10 +195,42 @@ func runEvaluator(c *cli.Context) error {
 		defer slog.Info("opt out events consumer finished")
 		return substratemessage.BatchConsumer(ctx, c.Int(batchSize), time.Second, optOutSource, consumer.HandleAccountOptOut(accountStore, occupancyStore, evaluator, c.Bool(stateRebuild)))
 	})
+	// the psr events are optional until the topic is configured in every environment
+	if c.String(psrTopic) != "" {
+		psrSource, err := app.GetKafkaSource(c, c.String(app.KafkaConsumerGroup), c.String(psrTopic))
+		if err != nil {
+			return fmt.Errorf("unable to create psr events source [%s]: %w", c.String(psrTopic), err)
+		}
+		defer psrSource.Close()
+		opsServer.Add("psr-source", substratehealth.NewCheck(psrSource, "unable to consume psr events"))
+
+		g.Go(func() error {
+			defer slog.Info("psr events consumer finished")
+			return substratemessage.BatchConsumer(ctx, c.Int(batchSize), time.Second, psrSource, consumer.HandleAccountPSR(accountStore, occupancyStore, evaluator, c.Bool(stateRebuild)))
+		})
+	} else {
+		slog.Warn("psr events topic not set, the psr events are not consumed")
+	}
 	g.Go(func() error {
 		defer slog.Info("booking ref events consumer finished")
 		return substratemessage.BatchConsumer(ctx, c.Int(batchSize), time.Second, bookingRefSource, consumer.HandleBookingRef(bookingRefStore, occupancyStore, evaluator, c.Bool(stateRebuild)))
 	})
+	// the booking events are optional until the topic is configured in every environment
+	if c.String(bookingTopic) != "" {
+		bookingSource, err := app.GetKafkaSource(c, c.String(app.KafkaConsumerGroup), c.String(bookingTopic))
+		if err != nil {
+			return fmt.Errorf("unable to create booking events source [%s]: %w", c.String(bookingTopic), err)
+		}
+		defer bookingSource.Close()
+		opsServer.Add("booking-source", substratehealth.NewCheck(bookingSource, "unable to consume booking events"))
+
+		g.Go(func() error {
+			defer slog.Info("booking events consumer finished")
+			return substratemessage.BatchConsumer(ctx, c.Int(batchSize), time.Second, bookingSource, consumer.HandleBooking(bookingStore, evaluator, c.Bool(stateRebuild)))
+		})
+	} else {
+		slog.Warn("booking events topic not set, the booking events are not consumed")
+	}
 	g.Go(func() error {
 		defer slog.Info("meter events consumer finished")
 		return substratemessage.BatchConsumer(ctx, c.Int(batchSize), time.Second, meterSource, consumer.HandleMeter(meterStore, occupancyStore, evaluator, c.Bool(stateRebuild)))
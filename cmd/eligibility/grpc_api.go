@@ -11,6 +11,8 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/go-redis/redis/v8"
+	"github.com/gorilla/mux"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/urfave/cli/v2"
@@ -22,8 +24,10 @@ import (
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/api"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/evaluation"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/store"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/store/inmemory"
 	"github.com/utilitywarehouse/energy-smart-booking/internal/auth"
 	"github.com/utilitywarehouse/energy-smart-booking/internal/repository/gateway"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/resilience"
 	grpchealth "github.com/utilitywarehouse/go-ops-health-checks/pkg/grpchealth"
 	"github.com/utilitywarehouse/go-ops-health-checks/v3/pkg/sqlhealth"
 	uwgrpc "github.com/utilitywarehouse/uwos-go/grpc"
@@ -37,6 +41,9 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
+// maxThirdPartyRetryBackoff caps the wait between retries of the ECOES and Xoserve calls.
+const maxThirdPartyRetryBackoff = 2 * time.Second
+
 func runGRPCApi(c *cli.Context) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -83,8 +90,37 @@ func runGRPCApi(c *cli.Context) error {
 	defer xoserveConn.Close()
 
 	// GATEWAYS //
-	ecoesGateway := gateway.NewEcoesGateway(ecoesv2.NewEcoesServiceClient(ecoesConn))
-	xoserveGateway := gateway.NewXOServeGateway(xoservev1.NewXoserveAPIClient(xoserveConn))
+	retryPolicy := resilience.RetryPolicy{
+		MaxRetries:     c.Int(thirdPartyMaxRetries),
+		InitialBackoff: c.Duration(thirdPartyRetryBackoff),
+		MaxBackoff:     maxThirdPartyRetryBackoff,
+	}
+	ecoesBreaker := resilience.NewCircuitBreaker("ecoes", c.Int(circuitBreakerFailureThreshold), c.Duration(circuitBreakerOpenDuration))
+	opsServer.Add("ecoes-circuit-breaker", ecoesBreaker.NewHealthCheck())
+	xoserveBreaker := resilience.NewCircuitBreaker("xoserve", c.Int(circuitBreakerFailureThreshold), c.Duration(circuitBreakerOpenDuration))
+	opsServer.Add("xoserve-circuit-breaker", xoserveBreaker.NewHealthCheck())
+
+	thirdPartyCache := gateway.NewReadThroughCache(gateway.NewLRUCache(c.Int(thirdPartyCacheSize)), nil)
+	if addr := c.String(thirdPartyCacheRedisAddr); addr != "" {
+		redisClient := redis.NewClient(&redis.Options{Addr: addr})
+		defer redisClient.Close()
+		redisCache := gateway.NewRedisCache(redisClient, "eligibility-gw")
+		thirdPartyCache = gateway.NewReadThroughCache(gateway.NewLRUCache(c.Int(thirdPartyCacheSize)), redisCache).WithInvalidationBus(redisCache)
+	}
+
+	ecoesGateway := gateway.NewCachedEcoesGateway(
+		gateway.NewResilientEcoesGateway(gateway.NewEcoesGateway(ecoesv2.NewEcoesServiceClient(ecoesConn)), retryPolicy, ecoesBreaker),
+		thirdPartyCache,
+		gateway.EcoesCacheTTLs{
+			TechnicalDetails: gateway.CacheTTL{Found: c.Duration(technicalDetailsCacheTTL), NotFound: c.Duration(notFoundCacheTTL)},
+			RelatedMPANs:     gateway.CacheTTL{Found: c.Duration(relatedMPANsCacheTTL), NotFound: c.Duration(notFoundCacheTTL)},
+		},
+	)
+	xoserveGateway := gateway.NewCachedXOServeGateway(
+		gateway.NewResilientXOServeGateway(gateway.NewXOServeGateway(xoservev1.NewXoserveAPIClient(xoserveConn)), retryPolicy, xoserveBreaker),
+		thirdPartyCache,
+		gateway.CacheTTL{Found: c.Duration(technicalDetailsCacheTTL), NotFound: c.Duration(notFoundCacheTTL)},
+	)
 
 	eligibilityStore := store.NewEligibility(pg)
 	suppliabilityStore := store.NewSuppliability(pg)
@@ -93,6 +129,13 @@ func runGRPCApi(c *cli.Context) error {
 	serviceStore := store.NewService(pg)
 	meterpointStore := store.NewMeterpoint(pg)
 	postcodeStore := store.NewPostCode(pg)
+	evaluationHistoryStore := store.NewEvaluationHistory(pg)
+
+	meterCatalogue, err := inmemory.NewMeterCatalogue(c.String(unsupportedSSCFilePath), c.String(standardMeterCapacityFilePath))
+	if err != nil {
+		return fmt.Errorf("failed to load meter catalogue, %w", err)
+	}
+	opsServer.Add("meter-catalogue", meterCatalogue.NewHealthCheck())
 
 	closer, err := telemetry.Register(ctx,
 		telemetry.WithServiceName(appName),
@@ -115,12 +158,19 @@ func runGRPCApi(c *cli.Context) error {
 			occupancyStore,
 			accountStore,
 			serviceStore,
+			evaluationHistoryStore,
 			auth,
 			evaluation.NewMeterpointEvaluator(
 				postcodeStore,
 				meterpointStore,
 				ecoesGateway,
 				xoserveGateway,
+				meterCatalogue,
+				evaluation.MeterpointTimeouts{
+					Ecoes:   c.Duration(ecoesTimeout),
+					Xoserve: c.Duration(xoserveTimeout),
+					Stores:  c.Duration(meterpointStoreTimeout),
+				},
 			),
 		)
 		smart_booking.RegisterEligiblityAPIServer(grpcServer, eligibilityAPI)
@@ -150,20 +200,44 @@ func runGRPCApi(c *cli.Context) error {
 		return err
 	}
 
+	internalRouter := mux.NewRouter()
+	api.RegisterCacheInvalidation(internalRouter, ecoesGateway, xoserveGateway)
+	internalHTTPServer := &http.Server{
+		Addr:              fmt.Sprintf(":%d", c.Int(internalHTTPPort)),
+		Handler:           internalRouter,
+		ReadHeaderTimeout: 3 * time.Second,
+	}
+
 	g.Go(func() error {
 		return httpServer.ListenAndServe()
 	})
 
+	g.Go(func() error {
+		defer slog.Info("internal http server exited")
+		return internalHTTPServer.ListenAndServe()
+	})
+
+	g.Go(func() error {
+		defer slog.Info("gateway cache invalidation watcher finished")
+		return thirdPartyCache.WatchInvalidations(ctx)
+	})
+
 	g.Go(func() error {
 		return opsServer.Start(ctx)
 	})
 
+	g.Go(func() error {
+		defer slog.Info("meter catalogue watcher finished")
+		return meterCatalogue.Watch(ctx, c.Duration(meterCatalogueReloadInterval))
+	})
+
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 	g.Go(func() error {
 		select {
 		case <-ctx.Done():
 			httpServer.Close()
+			internalHTTPServer.Close()
 			return ctx.Err()
 		case <-sigChan:
 			slog.Info("cancelling context")
@@ -17,6 +17,7 @@ import (
 	"github.com/utilitywarehouse/energy-pkg/ops"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/api"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/evaluation"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/jobs"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/store"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/store/inmemory"
 	"github.com/utilitywarehouse/energy-smart-booking/internal/publisher"
@@ -49,6 +50,18 @@ func runHTTPApi(c *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to load file with meter serial number with smart meter exclusion, %w", err)
 	}
+	opsServer.Add("msn-exceptions", msnExceptionStore.NewHealthCheck())
+	psrCodeStore := inmemory.NewPSRCodes(c.StringSlice(psrSpecialistVisitCodes))
+	meterCatalogue, err := inmemory.NewMeterCatalogue(c.String(unsupportedSSCFilePath), c.String(standardMeterCapacityFilePath))
+	if err != nil {
+		return fmt.Errorf("failed to load meter catalogue, %w", err)
+	}
+	opsServer.Add("meter-catalogue", meterCatalogue.NewHealthCheck())
+
+	ruleSet, err := loadRuleSet(c)
+	if err != nil {
+		return err
+	}
 
 	eligibilitySink, err := app.GetKafkaSink(c, c.String(eligibilityTopic))
 	if err != nil {
@@ -83,10 +96,14 @@ func runHTTPApi(c *cli.Context) error {
 		campaignabilitySyncPublisher,
 		bookingEligibilitySyncPublisher,
 		msnExceptionStore,
+		psrCodeStore,
+		meterCatalogue,
+		ruleSet,
 	)
 
 	router := mux.NewRouter()
-	apiHandler := api.NewHandler(occupancyStore, evaluator)
+	jobRunner := jobs.NewRunner(ctx, store.NewEvaluationJob(pool), evaluator, c.Int(evaluationJobWorkers), c.Float64(evaluationJobRateLimit))
+	apiHandler := api.NewHandler(occupancyStore, evaluator, jobRunner)
 	apiHandler.Register(ctx, router)
 
 	httpServer := &http.Server{
@@ -102,6 +119,21 @@ func runHTTPApi(c *cli.Context) error {
 		return opsServer.Start(ctx)
 	})
 
+	g.Go(func() error {
+		defer slog.Info("meter serial number exceptions watcher finished")
+		return msnExceptionStore.Watch(ctx, c.Duration(msnExceptionReloadInterval), nil)
+	})
+
+	g.Go(func() error {
+		defer slog.Info("meter catalogue watcher finished")
+		return meterCatalogue.Watch(ctx, c.Duration(meterCatalogueReloadInterval))
+	})
+
+	g.Go(func() error {
+		defer slog.Info("stale evaluation jobs watcher finished")
+		return jobRunner.WatchStale(ctx)
+	})
+
 	g.Go(func() error {
 		defer slog.Info("server exited")
 		return httpServer.ListenAndServe()
@@ -117,10 +149,14 @@ func runHTTPApi(c *cli.Context) error {
 		select {
 		case <-ctx.Done():
 			httpServer.Close()
+			jobRunner.Wait()
 			return ctx.Err()
 		case <-sigChan:
 			cancel()
-			if err = httpServer.Shutdown(shutdownCtx); err != nil {
+			err = httpServer.Shutdown(shutdownCtx)
+			// the running jobs are stopped by the cancelled context, wait for them to be marked as failed
+			jobRunner.Wait()
+			if err != nil {
 				return err
 			}
 		}
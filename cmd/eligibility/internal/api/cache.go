@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type MPANCache interface {
+	InvalidateMPAN(ctx context.Context, mpan string) error
+}
+
+type MPRNCache interface {
+	InvalidateMPRN(ctx context.Context, mprn string) error
+}
+
+const (
+	endpointInvalidateMPAN = "/cache/mpans/{mpan}"
+	endpointInvalidateMPRN = "/cache/mprns/{mprn}"
+)
+
+// RegisterCacheInvalidation adds the endpoints dropping the cached third party lookups of a meterpoint,
+// e.g. after ECOES or Xoserve data was corrected. The endpoints aren't authenticated, so the router
+// must only be served internally.
+func RegisterCacheInvalidation(router *mux.Router, mpanCache MPANCache, mprnCache MPRNCache) {
+	router.HandleFunc(endpointInvalidateMPAN, func(w http.ResponseWriter, r *http.Request) {
+		mpan := mux.Vars(r)["mpan"]
+		invalidate(w, "mpan", mpan, func() error {
+			return mpanCache.InvalidateMPAN(r.Context(), mpan)
+		})
+	}).Methods(http.MethodDelete)
+
+	router.HandleFunc(endpointInvalidateMPRN, func(w http.ResponseWriter, r *http.Request) {
+		mprn := mux.Vars(r)["mprn"]
+		invalidate(w, "mprn", mprn, func() error {
+			return mprnCache.InvalidateMPRN(r.Context(), mprn)
+		})
+	}).Methods(http.MethodDelete)
+}
+
+func invalidate(w http.ResponseWriter, kind, mpxn string, fn func() error) {
+	if err := fn(); err != nil {
+		slog.Error("failed to invalidate cached meterpoint lookups", kind, mpxn, "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	slog.Info("invalidated cached meterpoint lookups", kind, mpxn)
+	w.WriteHeader(http.StatusNoContent)
+}
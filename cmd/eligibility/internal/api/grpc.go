@@ -6,17 +6,23 @@ import (
 	"fmt"
 	"log/slog"
 
+	smart "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart/v1"
 	smart_booking "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/eligibility/v1"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/domain"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/evaluation"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/store"
 	"github.com/utilitywarehouse/energy-smart-booking/internal/auth"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
 	"github.com/utilitywarehouse/energy-smart-booking/internal/repository/helpers"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/resilience"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/validation"
 	"github.com/utilitywarehouse/uwos-go/telemetry/tracing"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const resourceID = "booking-eligibility-grpc-api"
@@ -49,15 +55,20 @@ type ServiceStore interface {
 	GetLiveServicesWithBookingRef(ctx context.Context, occupancyID string) ([]store.ServiceBookingRef, error)
 }
 
+type EvaluationHistoryStore interface {
+	Get(ctx context.Context, occupancyID, accountID string) ([]domain.EvaluationHistoryEntry, error)
+}
+
 type EligibilityGRPCApi struct {
 	smart_booking.UnimplementedEligiblityAPIServer
-	eligibilityStore    EligibilityStore
-	suppliabilityStore  SuppliabilityStore
-	occupancyStore      OccupancyStore
-	accountStore        AccountStore
-	serviceStore        ServiceStore
-	auth                Auth
-	meterpointEvaluator *evaluation.MeterpointEvaluator
+	eligibilityStore       EligibilityStore
+	suppliabilityStore     SuppliabilityStore
+	occupancyStore         OccupancyStore
+	accountStore           AccountStore
+	serviceStore           ServiceStore
+	evaluationHistoryStore EvaluationHistoryStore
+	auth                   Auth
+	meterpointEvaluator    *evaluation.MeterpointEvaluator
 }
 
 func NewEligibilityGRPCApi(
@@ -66,17 +77,19 @@ func NewEligibilityGRPCApi(
 	occupancyStore OccupancyStore,
 	accountStore AccountStore,
 	serviceStore ServiceStore,
+	evaluationHistoryStore EvaluationHistoryStore,
 	auth Auth,
 	meterpointEvaluator *evaluation.MeterpointEvaluator,
 ) *EligibilityGRPCApi {
 	return &EligibilityGRPCApi{
-		eligibilityStore:    eligibilityStore,
-		suppliabilityStore:  suppliabilityStore,
-		occupancyStore:      occupancyStore,
-		accountStore:        accountStore,
-		serviceStore:        serviceStore,
-		auth:                auth,
-		meterpointEvaluator: meterpointEvaluator,
+		eligibilityStore:       eligibilityStore,
+		suppliabilityStore:     suppliabilityStore,
+		occupancyStore:         occupancyStore,
+		accountStore:           accountStore,
+		serviceStore:           serviceStore,
+		evaluationHistoryStore: evaluationHistoryStore,
+		auth:                   auth,
+		meterpointEvaluator:    meterpointEvaluator,
 	}
 }
 
@@ -109,17 +122,9 @@ func (a *EligibilityGRPCApi) GetAccountEligibleForSmartBooking(ctx context.Conte
 
 	// an account which has opted out of smart booking should not be considered eligible to go through the journey
 	if account.OptOut {
-		return &smart_booking.GetAccountEligibleForSmartBookingResponse{
-			AccountId: req.AccountId,
-			Eligible:  false,
-		}, nil
+		return a.accountIneligible(req.AccountId, domain.IneligibleReasons{domain.IneligibleReasonBookingOptOut}, nil)
 	}
 
-	var (
-		eligible bool
-		reasons  domain.IneligibleReasons
-	)
-
 	occupancyIDs, err := a.occupancyStore.GetLiveOccupanciesIDsByAccountID(ctx, req.AccountId)
 	if err != nil {
 		slog.Debug("failed to get live occupancies for account ID", "account_id", req.GetAccountId(), "error", err.Error())
@@ -130,77 +135,59 @@ func (a *EligibilityGRPCApi) GetAccountEligibleForSmartBooking(ctx context.Conte
 	// if there are no live occupancies for given account
 	// customer is not eligible to go through smart booking journey
 	if len(occupancyIDs) == 0 {
-		reasons = domain.IneligibleReasons{domain.IneligibleReasonNoActiveService}
-	} else {
-		for _, occupancyID := range occupancyIDs {
-			eligibility, err := a.eligibilityStore.Get(ctx, occupancyID, req.AccountId)
-			if err != nil {
-				if errors.Is(err, store.ErrEligibilityNotFound) {
-					slog.Debug("eligibility not computed", "account_id", req.AccountId, "occupancy_id", occupancyID)
-					return nil, status.Errorf(codes.NotFound, "eligibility not found for account %s", req.AccountId)
-				}
-				slog.Debug("failed to get eligibility", "account_id", req.AccountId, "error", err.Error())
-				return nil, status.Errorf(codes.Internal, "failed to get eligibility for account %s", req.AccountId)
-			}
+		return a.accountIneligible(req.AccountId, domain.IneligibleReasons{domain.IneligibleReasonNoActiveService}, nil)
+	}
 
-			suppliability, err := a.suppliabilityStore.Get(ctx, occupancyID, req.AccountId)
-			if err != nil {
-				if errors.Is(err, store.ErrSuppliabilityNotFound) {
-					slog.Debug("suppliability not computed", "account_id", req.AccountId, "occupancy_id", occupancyID)
-					return nil, status.Errorf(codes.NotFound, "suppliability not found for account %s", req.AccountId)
-				}
-				slog.Debug("failed to get suppliability", "account_id", req.AccountId, "error", err.Error())
-				return nil, status.Errorf(codes.Internal, "failed to get suppliability for account %s", req.AccountId)
-			}
+	var (
+		reasons            domain.IneligibleReasons
+		occupancyReasons   = make([]*smart_booking.OccupancyIneligibleReasons, 0, len(occupancyIDs))
+		occupancyReasonsBy = make(map[string]domain.IneligibleReasons, len(occupancyIDs))
+	)
+	for _, occupancyID := range occupancyIDs {
+		ineligibleReasons, err := a.getOccupancyIneligibleReasons(ctx, span, req.AccountId, occupancyID)
+		if err != nil {
+			return nil, err
+		}
 
-			eligible = len(eligibility.Reasons) == 0 && len(suppliability.Reasons) == 0
-			if eligible {
-				// check it has booking references assigned
-				serviceBookingRef, err := a.serviceStore.GetLiveServicesWithBookingRef(ctx, occupancyID)
-				if err != nil {
-					slog.Debug("failed to get service booking references", "account_id", req.AccountId, "occupancy_id", occupancyID, "error", err.Error())
-					return nil, status.Errorf(codes.Internal, "failed to check service booking references for account %s", req.AccountId)
-				}
-				serviceBookingRefAttr := helpers.CreateSpanAttribute(serviceBookingRef, "get-live-services", span)
-				span.AddEvent("service-booking-references", trace.WithAttributes(serviceBookingRefAttr))
-
-				hasBookingRef := len(serviceBookingRef) > 0
-				for _, s := range serviceBookingRef {
-					if s.BookingRef == "" || s.DeletedAt != nil {
-						hasBookingRef = false
-						break
-					}
-				}
-				if hasBookingRef {
-					span.AddEvent("get-eligibility", trace.WithAttributes(
-						attribute.String("reasons", fmt.Sprintf("%v", reasons)),
-						attribute.Bool("eligible", true)))
-					return &smart_booking.GetAccountEligibleForSmartBookingResponse{AccountId: req.AccountId, Eligible: true}, nil
-				}
-			}
+		// the account is eligible if any of its occupancies is
+		if len(ineligibleReasons) == 0 {
+			span.AddEvent("get-eligibility", trace.WithAttributes(
+				attribute.String("occupancy.id", occupancyID),
+				attribute.Bool("eligible", true)))
+			return &smart_booking.GetAccountEligibleForSmartBookingResponse{AccountId: req.AccountId, Eligible: true}, nil
+		}
 
-			// If none of the occupancies is eligible, we want to return the reasons for the first occupancy
-			if len(reasons) == 0 {
-				reasons = append(reasons, eligibility.Reasons...)
-				reasons = append(reasons, suppliability.Reasons...)
-			}
+		occupancyReasonsBy[occupancyID] = ineligibleReasons
+		reasons = append(reasons, ineligibleReasons...)
+	}
+
+	for _, occupancyID := range occupancyIDs {
+		protoReasons, err := occupancyReasonsBy[occupancyID].MapToProto()
+		if err != nil {
+			slog.Debug("failed to map reason to proto", "account_id", req.AccountId, "occupancy_id", occupancyID, "error", err.Error())
+			return nil, status.Errorf(codes.Internal, "failed to map reason to proto %s", req.AccountId)
 		}
+		occupancyReasons = append(occupancyReasons, &smart_booking.OccupancyIneligibleReasons{
+			OccupancyId:       occupancyID,
+			IneligibleReasons: protoReasons,
+		})
 	}
 
-	span.AddEvent("get-eligibility", trace.WithAttributes(
-		attribute.String("reasons", fmt.Sprintf("%v", reasons)),
-		attribute.Bool("eligible", false)))
+	return a.accountIneligible(req.AccountId, reasons.Unique(), occupancyReasons)
+}
 
+func (a *EligibilityGRPCApi) accountIneligible(accountID string, reasons domain.IneligibleReasons, occupancyReasons []*smart_booking.OccupancyIneligibleReasons) (*smart_booking.GetAccountEligibleForSmartBookingResponse, error) {
 	protoReasons, err := reasons.MapToProto()
 	if err != nil {
-		slog.Debug("failed to map reason to proto", "account_id", req.AccountId, "error", err.Error())
-		return nil, status.Errorf(codes.Internal, "failed to map reason to proto %s", req.AccountId)
+		slog.Debug("failed to map reason to proto", "account_id", accountID, "error", err.Error())
+		return nil, status.Errorf(codes.Internal, "failed to map reason to proto %s", accountID)
 	}
 
 	return &smart_booking.GetAccountEligibleForSmartBookingResponse{
-		AccountId:         req.AccountId,
-		Eligible:          false,
-		IneligibleReasons: protoReasons,
+		AccountId:                  accountID,
+		Eligible:                   false,
+		IneligibleReasons:          protoReasons,
+		OccupancyIneligibleReasons: occupancyReasons,
 	}, nil
 }
 
@@ -232,64 +219,89 @@ func (a *EligibilityGRPCApi) GetAccountOccupancyEligibleForSmartBooking(ctx cont
 
 	span.AddEvent("get-account", trace.WithAttributes(attribute.Bool("opt.out", account.OptOut), attribute.String("psr.codes", fmt.Sprintf("%v", account.PSRCodes))))
 
+	var reasons domain.IneligibleReasons
+
 	// an account which has opted out of smart booking should not be considered eligible to go through the journey
 	if account.OptOut {
-		return &smart_booking.GetAccountOccupancyEligibleForSmartBookingResponse{
-			AccountId:   req.AccountId,
-			OccupancyId: req.OccupancyId,
-			Eligible:    false,
-		}, nil
+		reasons = domain.IneligibleReasons{domain.IneligibleReasonBookingOptOut}
+	} else {
+		reasons, err = a.getOccupancyIneligibleReasons(ctx, span, req.AccountId, req.OccupancyId)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	eligibility, err := a.eligibilityStore.Get(ctx, req.OccupancyId, req.AccountId)
+	protoReasons, err := reasons.MapToProto()
 	if err != nil {
-		if errors.Is(err, store.ErrEligibilityNotFound) {
-			slog.Debug("eligibility not computed", "account_id", req.AccountId, "occupancy_id", req.OccupancyId)
-			return nil, status.Errorf(codes.NotFound, "eligibility not found for account %s", req.AccountId)
+		slog.Debug("failed to map reason to proto", "account_id", req.AccountId, "occupancy_id", req.OccupancyId, "error", err.Error())
+		return nil, status.Errorf(codes.Internal, "failed to map reason to proto %s", req.AccountId)
+	}
+
+	return &smart_booking.GetAccountOccupancyEligibleForSmartBookingResponse{
+		AccountId:         req.AccountId,
+		OccupancyId:       req.OccupancyId,
+		Eligible:          len(reasons) == 0,
+		IneligibleReasons: protoReasons,
+	}, nil
+}
 
+// getOccupancyIneligibleReasons returns the de-duplicated eligibility and suppliability reasons of an occupancy,
+// and BookingReferenceMissing if the occupancy is otherwise eligible but not all its live services have a booking reference.
+// The errors returned are gRPC status errors.
+func (a *EligibilityGRPCApi) getOccupancyIneligibleReasons(ctx context.Context, span trace.Span, accountID, occupancyID string) (domain.IneligibleReasons, error) {
+	eligibility, err := a.eligibilityStore.Get(ctx, occupancyID, accountID)
+	if err != nil {
+		if errors.Is(err, store.ErrEligibilityNotFound) {
+			slog.Debug("eligibility not computed", "account_id", accountID, "occupancy_id", occupancyID)
+			return nil, status.Errorf(codes.NotFound, "eligibility not found for account %s", accountID)
 		}
-		slog.Debug("failed to get eligibility", "account_id", req.AccountId, "occupancy_id", req.OccupancyId, "error", err.Error())
-		return nil, status.Errorf(codes.Internal, "failed to get eligibility for account %s", req.AccountId)
+		slog.Debug("failed to get eligibility", "account_id", accountID, "occupancy_id", occupancyID, "error", err.Error())
+		return nil, status.Errorf(codes.Internal, "failed to get eligibility for account %s", accountID)
 	}
-	suppliability, err := a.suppliabilityStore.Get(ctx, req.OccupancyId, req.AccountId)
+
+	suppliability, err := a.suppliabilityStore.Get(ctx, occupancyID, accountID)
 	if err != nil {
-		if errors.Is(err, store.ErrEligibilityNotFound) {
-			slog.Debug("suppliability not computed", "account_id", req.AccountId, "occupancy_id", req.OccupancyId)
-			return nil, status.Errorf(codes.NotFound, "suppliability not found for account %s", req.AccountId)
+		if errors.Is(err, store.ErrSuppliabilityNotFound) {
+			slog.Debug("suppliability not computed", "account_id", accountID, "occupancy_id", occupancyID)
+			return nil, status.Errorf(codes.NotFound, "suppliability not found for account %s", accountID)
 		}
-		slog.Debug("failed to get suppliability", "account_id", req.AccountId, "occupancy_id", req.OccupancyId, "error", err.Error())
-		return nil, status.Errorf(codes.Internal, "failed to get suppliability for account %s", req.AccountId)
+		slog.Debug("failed to get suppliability", "account_id", accountID, "occupancy_id", occupancyID, "error", err.Error())
+		return nil, status.Errorf(codes.Internal, "failed to get suppliability for account %s", accountID)
 	}
 
-	eligible := len(eligibility.Reasons) == 0 && len(suppliability.Reasons) == 0
-	if eligible {
+	var reasons domain.IneligibleReasons
+	reasons = append(reasons, eligibility.Reasons...)
+	reasons = append(reasons, suppliability.Reasons...)
+
+	if len(reasons) == 0 {
 		// check it has booking references assigned
-		serviceBookingRef, err := a.serviceStore.GetLiveServicesWithBookingRef(ctx, req.OccupancyId)
+		serviceBookingRef, err := a.serviceStore.GetLiveServicesWithBookingRef(ctx, occupancyID)
 		if err != nil {
-			slog.Debug("failed to get service booking references", "account_id", req.AccountId, "occupancy_id", req.OccupancyId, "error", err.Error())
-			return nil, status.Errorf(codes.Internal, "failed to check service booking references for account %s", req.AccountId)
+			slog.Debug("failed to get service booking references", "account_id", accountID, "occupancy_id", occupancyID, "error", err.Error())
+			return nil, status.Errorf(codes.Internal, "failed to check service booking references for account %s", accountID)
 		}
 		serviceBookingRefAttr := helpers.CreateSpanAttribute(serviceBookingRef, "get-live-services", span)
 		span.AddEvent("service-booking-references", trace.WithAttributes(serviceBookingRefAttr))
 
+		hasBookingRef := len(serviceBookingRef) > 0
 		for _, s := range serviceBookingRef {
 			if s.BookingRef == "" || s.DeletedAt != nil {
-				eligible = false
+				hasBookingRef = false
 				break
 			}
 		}
+		if !hasBookingRef {
+			reasons = append(reasons, domain.IneligibleReasonBookingReferenceMissing)
+		}
 	}
 
 	span.AddEvent("get-eligibility", trace.WithAttributes(
+		attribute.String("occupancy.id", occupancyID),
 		attribute.String("eligibility.reasons", fmt.Sprintf("%v", eligibility.Reasons)),
 		attribute.String("suppliability.reasons", fmt.Sprintf("%v", suppliability.Reasons)),
-		attribute.Bool("eligible", eligible)))
+		attribute.Bool("eligible", len(reasons) == 0)))
 
-	return &smart_booking.GetAccountOccupancyEligibleForSmartBookingResponse{
-		AccountId:   req.AccountId,
-		OccupancyId: req.OccupancyId,
-		Eligible:    eligible,
-	}, nil
+	return reasons.Unique(), nil
 }
 
 func (a *EligibilityGRPCApi) GetMeterpointEligibility(ctx context.Context, req *smart_booking.GetMeterpointEligibilityRequest) (_ *smart_booking.GetMeterpointEligibilityResponse, err error) {
@@ -308,6 +320,21 @@ func (a *EligibilityGRPCApi) GetMeterpointEligibility(ctx context.Context, req *
 	if req.GetPostcode() == "" {
 		return nil, status.Error(codes.InvalidArgument, "no postcode provided")
 	}
+	mpan, err := validation.MPAN(req.GetMpan())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	postcode, err := models.ParsePostcode(req.GetPostcode())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var mprn string
+	if req.GetMprn() != "" {
+		mprn, err = validation.MPRN(req.GetMprn())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
 
 	err = a.validateCredentials(ctx, auth.GetAction, auth.EligibilityResource, resourceID)
 	if err != nil {
@@ -319,31 +346,111 @@ func (a *EligibilityGRPCApi) GetMeterpointEligibility(ctx context.Context, req *
 		}
 	}
 
-	if req.GetMprn() != "" {
-		result, err := a.meterpointEvaluator.GetGasMeterpointEligibility(ctx, req.GetMprn())
-		if err != nil {
-			return nil, status.Error(codes.Internal, err.Error())
+	// both fuels are evaluated, concurrently, so that all the reasons the customer can't book are returned
+	var (
+		electricity evaluation.MeterpointEligible
+		gas         = evaluation.MeterpointEligible{Eligible: true}
+	)
+	g, gCtx := errgroup.WithContext(ctx)
+	g.Go(func() (err error) {
+		electricity, err = a.meterpointEvaluator.GetElectricityMeterpointEligibility(gCtx, mpan, postcode)
+		return err
+	})
+	if mprn != "" {
+		g.Go(func() (err error) {
+			gas, err = a.meterpointEvaluator.GetGasMeterpointEligibility(gCtx, mprn)
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		if errors.Is(err, resilience.ErrCircuitOpen) {
+			return nil, status.Error(codes.Unavailable, err.Error())
 		}
-		if !result.Eligible {
-			span.AddEvent("response", trace.WithAttributes(attribute.Bool("eligible", result.Eligible), attribute.String("reason", string(result.Reason))))
-			return &smart_booking.GetMeterpointEligibilityResponse{
-				Eligible: false,
-			}, nil
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	span.AddEvent("electricity", trace.WithAttributes(attribute.Bool("eligible", electricity.Eligible), attribute.String("reasons", fmt.Sprintf("%v", electricity.Reasons))))
+	span.AddEvent("gas", trace.WithAttributes(attribute.Bool("eligible", gas.Eligible), attribute.String("reasons", fmt.Sprintf("%v", gas.Reasons))))
+
+	return &smart_booking.GetMeterpointEligibilityResponse{
+		Eligible:                     electricity.Eligible && gas.Eligible,
+		ElectricityIneligibleReasons: mapMeterpointReasonsToProto(electricity.Reasons),
+		GasIneligibleReasons:         mapMeterpointReasonsToProto(gas.Reasons),
+	}, nil
+}
+
+// GetOccupancyEligibilityHistory returns the timeline of the evaluation results published for an occupancy of the account.
+func (a *EligibilityGRPCApi) GetOccupancyEligibilityHistory(ctx context.Context, req *smart_booking.GetOccupancyEligibilityHistoryRequest) (_ *smart_booking.GetOccupancyEligibilityHistoryResponse, err error) {
+	ctx, span := tracing.Start(ctx, "EligibilityAPI.GetOccupancyEligibilityHistory",
+		trace.WithAttributes(attribute.String("account.id", req.GetAccountId())),
+		trace.WithAttributes(attribute.String("occupancy.id", req.GetOccupancyId())),
+	)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	if req.GetAccountId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "no account id provided")
+	}
+	if req.GetOccupancyId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "no occupancy id provided")
+	}
+
+	err = a.validateCredentials(ctx, auth.GetAction, auth.EligibilityResource, req.AccountId)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrUserUnauthorised):
+			return nil, status.Errorf(codes.PermissionDenied, "user does not have access to this action, %s", err)
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to validate credentials")
 		}
 	}
 
-	result, err := a.meterpointEvaluator.GetElectricityMeterpointEligibility(ctx, req.Mpan, req.GetPostcode())
+	history, err := a.evaluationHistoryStore.Get(ctx, req.OccupancyId, req.AccountId)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		slog.Debug("failed to get evaluation history", "account_id", req.AccountId, "occupancy_id", req.OccupancyId, "error", err.Error())
+		return nil, status.Errorf(codes.Internal, "failed to get eligibility history for account %s", req.AccountId)
 	}
 
-	span.AddEvent("response", trace.WithAttributes(attribute.Bool("eligible", result.Eligible), attribute.String("reason", string(result.Reason))))
+	entries := make([]*smart_booking.EligibilityHistoryEntry, 0, len(history))
+	for _, entry := range history {
+		protoReasons, err := entry.Reasons.MapToProto()
+		if err != nil {
+			slog.Debug("failed to map reason to proto", "account_id", req.AccountId, "occupancy_id", req.OccupancyId, "error", err.Error())
+			return nil, status.Errorf(codes.Internal, "failed to map reason to proto %s", req.AccountId)
+		}
 
-	return &smart_booking.GetMeterpointEligibilityResponse{
-		Eligible: result.Eligible,
+		entries = append(entries, &smart_booking.EligibilityHistoryEntry{
+			EvaluationType:    mapEvaluationTypeToProto(entry.EvaluationType),
+			Eligible:          len(entry.Reasons) == 0,
+			IneligibleReasons: protoReasons,
+			EventType:         entry.EventType,
+			EventId:           entry.EventUUID,
+			OccurredAt:        timestamppb.New(entry.OccurredAt),
+		})
+	}
+
+	span.AddEvent("get-eligibility-history", trace.WithAttributes(attribute.Int("entries", len(entries))))
+
+	return &smart_booking.GetOccupancyEligibilityHistoryResponse{
+		AccountId:   req.AccountId,
+		OccupancyId: req.OccupancyId,
+		Entries:     entries,
 	}, nil
 }
 
+func mapEvaluationTypeToProto(evaluationType domain.EvaluationType) smart_booking.EvaluationType {
+	switch evaluationType {
+	case domain.EvaluationTypeEligibility:
+		return smart_booking.EvaluationType_EVALUATION_TYPE_ELIGIBILITY
+	case domain.EvaluationTypeSuppliability:
+		return smart_booking.EvaluationType_EVALUATION_TYPE_SUPPLIABILITY
+	case domain.EvaluationTypeCampaignability:
+		return smart_booking.EvaluationType_EVALUATION_TYPE_CAMPAIGNABILITY
+	}
+	return smart_booking.EvaluationType_EVALUATION_TYPE_UNKNOWN
+}
+
 func (a *EligibilityGRPCApi) validateCredentials(ctx context.Context, action, resource, requestAccountID string) error {
 
 	authorised, err := a.auth.Authorize(ctx, &auth.PolicyParams{
@@ -361,3 +468,30 @@ func (a *EligibilityGRPCApi) validateCredentials(ctx context.Context, action, re
 
 	return nil
 }
+
+func mapMeterpointReasonsToProto(reasons []evaluation.MeterpointIneligibleReason) []smart.IneligibleReason {
+	protoReasons := make([]smart.IneligibleReason, 0, len(reasons))
+	for _, reason := range reasons {
+		protoReasons = append(protoReasons, mapMeterpointReasonToProto(reason))
+	}
+	return protoReasons
+}
+
+func mapMeterpointReasonToProto(reason evaluation.MeterpointIneligibleReason) smart.IneligibleReason {
+	switch reason {
+	case evaluation.MeterpointIneligibleReasonAlreadySmart:
+		return smart.IneligibleReason_INELIGIBLE_REASON_ALREADY_SMART
+	case evaluation.MeterpointIneligibleReasonNoWan:
+		return smart.IneligibleReason_INELIGIBLE_REASON_NO_WAN_COVERAGE
+	case evaluation.MeterpointIneligibleReasonAltHan:
+		return smart.IneligibleReason_INELIGIBLE_REASON_ALT_HAN
+	case evaluation.MeterpointIneligibleReasonRelatedMeterpoints:
+		return smart.IneligibleReason_INELIGIBLE_REASON_RELATED_MPAN
+	case evaluation.MeterpointIneligibleReasonComplexSSC:
+		return smart.IneligibleReason_INELIGIBLE_REASON_COMPLEX_TARIFF
+	case evaluation.MeterpointIneligibleReasonLargeCapacity:
+		return smart.IneligibleReason_INELIGIBLE_REASON_METER_LARGE_CAPACITY
+	default:
+		return smart.IneligibleReason_INELIGIBLE_REASON_UNKNOWN
+	}
+}
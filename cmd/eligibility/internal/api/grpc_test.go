@@ -0,0 +1,560 @@
+//go:generate mockgen -source=grpc.go -destination ./mocks/api_mocks.go
+
+package api_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	smart "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart/v1"
+	smart_booking "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/eligibility/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/api"
+	mocks "github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/api/mocks"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/domain"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/store"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/auth"
+)
+
+type grpcMocks struct {
+	eligibilityStore   *mocks.MockEligibilityStore
+	suppliabilityStore *mocks.MockSuppliabilityStore
+	occupancyStore     *mocks.MockOccupancyStore
+	accountStore       *mocks.MockAccountStore
+	serviceStore       *mocks.MockServiceStore
+	historyStore       *mocks.MockEvaluationHistoryStore
+	auth               *mocks.MockAuth
+}
+
+func newGRPCApi(ctrl *gomock.Controller) (*api.EligibilityGRPCApi, grpcMocks) {
+	m := grpcMocks{
+		eligibilityStore:   mocks.NewMockEligibilityStore(ctrl),
+		suppliabilityStore: mocks.NewMockSuppliabilityStore(ctrl),
+		occupancyStore:     mocks.NewMockOccupancyStore(ctrl),
+		accountStore:       mocks.NewMockAccountStore(ctrl),
+		serviceStore:       mocks.NewMockServiceStore(ctrl),
+		historyStore:       mocks.NewMockEvaluationHistoryStore(ctrl),
+		auth:               mocks.NewMockAuth(ctrl),
+	}
+
+	return api.NewEligibilityGRPCApi(
+		m.eligibilityStore,
+		m.suppliabilityStore,
+		m.occupancyStore,
+		m.accountStore,
+		m.serviceStore,
+		m.historyStore,
+		m.auth,
+		nil,
+	), m
+}
+
+func expectAuthorised(m grpcMocks, authorised bool) {
+	m.auth.EXPECT().Authorize(gomock.Any(), &auth.PolicyParams{
+		Action:     "get",
+		Resource:   "uw.energy.v1.account.smart-meter-booking-eligibility",
+		ResourceID: "account-id-1",
+	}).Return(authorised, nil)
+}
+
+func expectOccupancy(m grpcMocks, occupancyID string, eligibility, suppliability domain.IneligibleReasons) {
+	m.eligibilityStore.EXPECT().Get(gomock.Any(), occupancyID, "account-id-1").Return(store.Eligibility{
+		OccupancyID: occupancyID,
+		AccountID:   "account-id-1",
+		Reasons:     eligibility,
+	}, nil)
+	m.suppliabilityStore.EXPECT().Get(gomock.Any(), occupancyID, "account-id-1").Return(store.Suppliability{
+		OccupancyID: occupancyID,
+		AccountID:   "account-id-1",
+		Reasons:     suppliability,
+	}, nil)
+}
+
+func Test_GetAccountEligibleForSmartBooking(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	defer ctrl.Finish()
+
+	myAPIHandler, apiMocks := newGRPCApi(ctrl)
+
+	type inputParams struct {
+		req *smart_booking.GetAccountEligibleForSmartBookingRequest
+	}
+
+	type outputParams struct {
+		res *smart_booking.GetAccountEligibleForSmartBookingResponse
+		err error
+	}
+
+	type testSetup struct {
+		description string
+		setup       func(m grpcMocks)
+		input       inputParams
+		output      outputParams
+	}
+
+	req := &smart_booking.GetAccountEligibleForSmartBookingRequest{AccountId: "account-id-1"}
+
+	testCases := []testSetup{
+		{
+			description: "should return eligible if any of the occupancies is eligible",
+			input:       inputParams{req: req},
+			setup: func(m grpcMocks) {
+				expectAuthorised(m, true)
+				m.accountStore.EXPECT().GetAccount(gomock.Any(), "account-id-1").Return(store.Account{ID: "account-id-1"}, nil)
+				m.occupancyStore.EXPECT().GetLiveOccupanciesIDsByAccountID(gomock.Any(), "account-id-1").Return([]string{"occupancy-id-1", "occupancy-id-2"}, nil)
+
+				expectOccupancy(m, "occupancy-id-1", domain.IneligibleReasons{domain.IneligibleReasonAlreadySmart}, nil)
+				expectOccupancy(m, "occupancy-id-2", nil, nil)
+				m.serviceStore.EXPECT().GetLiveServicesWithBookingRef(gomock.Any(), "occupancy-id-2").Return([]store.ServiceBookingRef{
+					{ServiceID: "service-id-1", BookingRef: "booking-ref-1"},
+				}, nil)
+			},
+			output: outputParams{
+				res: &smart_booking.GetAccountEligibleForSmartBookingResponse{
+					AccountId: "account-id-1",
+					Eligible:  true,
+				},
+			},
+		},
+		{
+			description: "should return the de-duplicated reasons of the account and of each occupancy",
+			input:       inputParams{req: req},
+			setup: func(m grpcMocks) {
+				expectAuthorised(m, true)
+				m.accountStore.EXPECT().GetAccount(gomock.Any(), "account-id-1").Return(store.Account{ID: "account-id-1"}, nil)
+				m.occupancyStore.EXPECT().GetLiveOccupanciesIDsByAccountID(gomock.Any(), "account-id-1").Return([]string{"occupancy-id-1", "occupancy-id-2"}, nil)
+
+				expectOccupancy(m, "occupancy-id-1",
+					domain.IneligibleReasons{domain.IneligibleReasonAlreadySmart},
+					domain.IneligibleReasons{domain.IneligibleReasonAlreadySmart})
+				expectOccupancy(m, "occupancy-id-2",
+					domain.IneligibleReasons{domain.IneligibleReasonNoWanCoverage},
+					domain.IneligibleReasons{domain.IneligibleReasonAlreadySmart})
+			},
+			output: outputParams{
+				res: &smart_booking.GetAccountEligibleForSmartBookingResponse{
+					AccountId: "account-id-1",
+					Eligible:  false,
+					IneligibleReasons: []smart.IneligibleReason{
+						smart.IneligibleReason_INELIGIBLE_REASON_ALREADY_SMART,
+						smart.IneligibleReason_INELIGIBLE_REASON_NO_WAN_COVERAGE,
+					},
+					OccupancyIneligibleReasons: []*smart_booking.OccupancyIneligibleReasons{
+						{
+							OccupancyId:       "occupancy-id-1",
+							IneligibleReasons: []smart.IneligibleReason{smart.IneligibleReason_INELIGIBLE_REASON_ALREADY_SMART},
+						},
+						{
+							OccupancyId: "occupancy-id-2",
+							IneligibleReasons: []smart.IneligibleReason{
+								smart.IneligibleReason_INELIGIBLE_REASON_NO_WAN_COVERAGE,
+								smart.IneligibleReason_INELIGIBLE_REASON_ALREADY_SMART,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "should return booking reference missing if an otherwise eligible occupancy has no booking reference",
+			input:       inputParams{req: req},
+			setup: func(m grpcMocks) {
+				expectAuthorised(m, true)
+				m.accountStore.EXPECT().GetAccount(gomock.Any(), "account-id-1").Return(store.Account{ID: "account-id-1"}, nil)
+				m.occupancyStore.EXPECT().GetLiveOccupanciesIDsByAccountID(gomock.Any(), "account-id-1").Return([]string{"occupancy-id-1"}, nil)
+
+				expectOccupancy(m, "occupancy-id-1", nil, nil)
+				m.serviceStore.EXPECT().GetLiveServicesWithBookingRef(gomock.Any(), "occupancy-id-1").Return([]store.ServiceBookingRef{
+					{ServiceID: "service-id-1", BookingRef: "booking-ref-1"},
+					{ServiceID: "service-id-2"},
+				}, nil)
+			},
+			output: outputParams{
+				res: &smart_booking.GetAccountEligibleForSmartBookingResponse{
+					AccountId:         "account-id-1",
+					Eligible:          false,
+					IneligibleReasons: []smart.IneligibleReason{smart.IneligibleReason_INELIGIBLE_REASON_BOOKING_REFERENCE_MISSING},
+					OccupancyIneligibleReasons: []*smart_booking.OccupancyIneligibleReasons{
+						{
+							OccupancyId:       "occupancy-id-1",
+							IneligibleReasons: []smart.IneligibleReason{smart.IneligibleReason_INELIGIBLE_REASON_BOOKING_REFERENCE_MISSING},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "should return opt out for an account which opted out of smart booking",
+			input:       inputParams{req: req},
+			setup: func(m grpcMocks) {
+				expectAuthorised(m, true)
+				m.accountStore.EXPECT().GetAccount(gomock.Any(), "account-id-1").Return(store.Account{ID: "account-id-1", OptOut: true}, nil)
+			},
+			output: outputParams{
+				res: &smart_booking.GetAccountEligibleForSmartBookingResponse{
+					AccountId:         "account-id-1",
+					Eligible:          false,
+					IneligibleReasons: []smart.IneligibleReason{smart.IneligibleReason_INELIGIBLE_REASON_SMART_BOOKING_OPT_OUT},
+				},
+			},
+		},
+		{
+			description: "should return not active for an account without live occupancies",
+			input:       inputParams{req: req},
+			setup: func(m grpcMocks) {
+				expectAuthorised(m, true)
+				m.accountStore.EXPECT().GetAccount(gomock.Any(), "account-id-1").Return(store.Account{}, store.ErrAccountNotFound)
+				m.occupancyStore.EXPECT().GetLiveOccupanciesIDsByAccountID(gomock.Any(), "account-id-1").Return(nil, nil)
+			},
+			output: outputParams{
+				res: &smart_booking.GetAccountEligibleForSmartBookingResponse{
+					AccountId:         "account-id-1",
+					Eligible:          false,
+					IneligibleReasons: []smart.IneligibleReason{smart.IneligibleReason_INELIGIBLE_REASON_NOT_ACTIVE},
+				},
+			},
+		},
+		{
+			description: "should fail when the eligibility of an occupancy is not found",
+			input:       inputParams{req: req},
+			setup: func(m grpcMocks) {
+				expectAuthorised(m, true)
+				m.accountStore.EXPECT().GetAccount(gomock.Any(), "account-id-1").Return(store.Account{ID: "account-id-1"}, nil)
+				m.occupancyStore.EXPECT().GetLiveOccupanciesIDsByAccountID(gomock.Any(), "account-id-1").Return([]string{"occupancy-id-1"}, nil)
+				m.eligibilityStore.EXPECT().Get(gomock.Any(), "occupancy-id-1", "account-id-1").Return(store.Eligibility{}, store.ErrEligibilityNotFound)
+			},
+			output: outputParams{
+				res: nil,
+				err: status.Errorf(codes.NotFound, "eligibility not found for account %s", "account-id-1"),
+			},
+		},
+		{
+			description: "should fail because user is unauthorised",
+			input:       inputParams{req: req},
+			setup: func(m grpcMocks) {
+				expectAuthorised(m, false)
+			},
+			output: outputParams{
+				res: nil,
+				err: status.Errorf(codes.PermissionDenied, "user does not have access to this action, %s", api.ErrUserUnauthorised),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+
+			tc.setup(apiMocks)
+
+			expected, err := myAPIHandler.GetAccountEligibleForSmartBooking(ctx, tc.input.req)
+			if tc.output.err != nil {
+				if diff := cmp.Diff(err.Error(), tc.output.err.Error()); diff != "" {
+					t.Fatal(diff)
+				}
+			}
+
+			if diff := cmp.Diff(expected, tc.output.res, cmpopts.IgnoreUnexported(
+				smart_booking.GetAccountEligibleForSmartBookingResponse{},
+				smart_booking.OccupancyIneligibleReasons{},
+			)); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func Test_GetAccountOccupancyEligibleForSmartBooking(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	defer ctrl.Finish()
+
+	myAPIHandler, apiMocks := newGRPCApi(ctrl)
+
+	type inputParams struct {
+		req *smart_booking.GetAccountOccupancyEligibleForSmartBookingRequest
+	}
+
+	type outputParams struct {
+		res *smart_booking.GetAccountOccupancyEligibleForSmartBookingResponse
+		err error
+	}
+
+	type testSetup struct {
+		description string
+		setup       func(m grpcMocks)
+		input       inputParams
+		output      outputParams
+	}
+
+	req := &smart_booking.GetAccountOccupancyEligibleForSmartBookingRequest{
+		AccountId:   "account-id-1",
+		OccupancyId: "occupancy-id-1",
+	}
+
+	testCases := []testSetup{
+		{
+			description: "should return eligible for an occupancy without reasons and with booking references",
+			input:       inputParams{req: req},
+			setup: func(m grpcMocks) {
+				expectAuthorised(m, true)
+				m.accountStore.EXPECT().GetAccount(gomock.Any(), "account-id-1").Return(store.Account{ID: "account-id-1"}, nil)
+
+				expectOccupancy(m, "occupancy-id-1", nil, nil)
+				m.serviceStore.EXPECT().GetLiveServicesWithBookingRef(gomock.Any(), "occupancy-id-1").Return([]store.ServiceBookingRef{
+					{ServiceID: "service-id-1", BookingRef: "booking-ref-1"},
+				}, nil)
+			},
+			output: outputParams{
+				res: &smart_booking.GetAccountOccupancyEligibleForSmartBookingResponse{
+					AccountId:         "account-id-1",
+					OccupancyId:       "occupancy-id-1",
+					Eligible:          true,
+					IneligibleReasons: []smart.IneligibleReason{},
+				},
+			},
+		},
+		{
+			description: "should return the de-duplicated eligibility and suppliability reasons",
+			input:       inputParams{req: req},
+			setup: func(m grpcMocks) {
+				expectAuthorised(m, true)
+				m.accountStore.EXPECT().GetAccount(gomock.Any(), "account-id-1").Return(store.Account{ID: "account-id-1"}, nil)
+
+				expectOccupancy(m, "occupancy-id-1",
+					domain.IneligibleReasons{domain.IneligibleReasonAlreadySmart, domain.IneligibleReasonNoWanCoverage},
+					domain.IneligibleReasons{domain.IneligibleReasonMeterLargeCapacity, domain.IneligibleReasonAlreadySmart})
+			},
+			output: outputParams{
+				res: &smart_booking.GetAccountOccupancyEligibleForSmartBookingResponse{
+					AccountId:   "account-id-1",
+					OccupancyId: "occupancy-id-1",
+					Eligible:    false,
+					IneligibleReasons: []smart.IneligibleReason{
+						smart.IneligibleReason_INELIGIBLE_REASON_ALREADY_SMART,
+						smart.IneligibleReason_INELIGIBLE_REASON_NO_WAN_COVERAGE,
+						smart.IneligibleReason_INELIGIBLE_REASON_METER_LARGE_CAPACITY,
+					},
+				},
+			},
+		},
+		{
+			description: "should return opt out for an account which opted out of smart booking",
+			input:       inputParams{req: req},
+			setup: func(m grpcMocks) {
+				expectAuthorised(m, true)
+				m.accountStore.EXPECT().GetAccount(gomock.Any(), "account-id-1").Return(store.Account{ID: "account-id-1", OptOut: true}, nil)
+			},
+			output: outputParams{
+				res: &smart_booking.GetAccountOccupancyEligibleForSmartBookingResponse{
+					AccountId:         "account-id-1",
+					OccupancyId:       "occupancy-id-1",
+					Eligible:          false,
+					IneligibleReasons: []smart.IneligibleReason{smart.IneligibleReason_INELIGIBLE_REASON_SMART_BOOKING_OPT_OUT},
+				},
+			},
+		},
+		{
+			description: "should fail when the suppliability of the occupancy is not found",
+			input:       inputParams{req: req},
+			setup: func(m grpcMocks) {
+				expectAuthorised(m, true)
+				m.accountStore.EXPECT().GetAccount(gomock.Any(), "account-id-1").Return(store.Account{ID: "account-id-1"}, nil)
+				m.eligibilityStore.EXPECT().Get(gomock.Any(), "occupancy-id-1", "account-id-1").Return(store.Eligibility{}, nil)
+				m.suppliabilityStore.EXPECT().Get(gomock.Any(), "occupancy-id-1", "account-id-1").Return(store.Suppliability{}, store.ErrSuppliabilityNotFound)
+			},
+			output: outputParams{
+				res: nil,
+				err: status.Errorf(codes.NotFound, "suppliability not found for account %s", "account-id-1"),
+			},
+		},
+		{
+			description: "should fail because user is unauthorised",
+			input:       inputParams{req: req},
+			setup: func(m grpcMocks) {
+				expectAuthorised(m, false)
+			},
+			output: outputParams{
+				res: nil,
+				err: status.Errorf(codes.PermissionDenied, "user does not have access to this action, %s", api.ErrUserUnauthorised),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+
+			tc.setup(apiMocks)
+
+			expected, err := myAPIHandler.GetAccountOccupancyEligibleForSmartBooking(ctx, tc.input.req)
+			if tc.output.err != nil {
+				if diff := cmp.Diff(err.Error(), tc.output.err.Error()); diff != "" {
+					t.Fatal(diff)
+				}
+			}
+
+			if diff := cmp.Diff(expected, tc.output.res, cmpopts.IgnoreUnexported(smart_booking.GetAccountOccupancyEligibleForSmartBookingResponse{})); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func Test_GetOccupancyEligibilityHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	defer ctrl.Finish()
+
+	myAPIHandler, apiMocks := newGRPCApi(ctrl)
+
+	type inputParams struct {
+		req *smart_booking.GetOccupancyEligibilityHistoryRequest
+	}
+
+	type outputParams struct {
+		res *smart_booking.GetOccupancyEligibilityHistoryResponse
+		err error
+	}
+
+	type testSetup struct {
+		description string
+		setup       func(m grpcMocks)
+		input       inputParams
+		output      outputParams
+	}
+
+	req := &smart_booking.GetOccupancyEligibilityHistoryRequest{
+		AccountId:   "account-id-1",
+		OccupancyId: "occupancy-id-1",
+	}
+
+	at := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []testSetup{
+		{
+			description: "should return the history of the occupancy of the account, oldest first",
+			input:       inputParams{req: req},
+			setup: func(m grpcMocks) {
+				expectAuthorised(m, true)
+				m.historyStore.EXPECT().Get(gomock.Any(), "occupancy-id-1", "account-id-1").Return([]domain.EvaluationHistoryEntry{
+					{
+						OccupancyID:    "occupancy-id-1",
+						AccountID:      "account-id-1",
+						EvaluationType: domain.EvaluationTypeEligibility,
+						Reasons:        domain.IneligibleReasons{domain.IneligibleReasonAlreadySmart},
+						EventType:      "EligibleOccupancyRemovedEvent",
+						EventUUID:      "uuid1",
+						OccurredAt:     at,
+					},
+					{
+						OccupancyID:    "occupancy-id-1",
+						AccountID:      "account-id-1",
+						EvaluationType: domain.EvaluationTypeSuppliability,
+						EventType:      "SuppliableOccupancyAddedEvent",
+						EventUUID:      "uuid2",
+						OccurredAt:     at.Add(time.Hour),
+					},
+				}, nil)
+			},
+			output: outputParams{
+				res: &smart_booking.GetOccupancyEligibilityHistoryResponse{
+					AccountId:   "account-id-1",
+					OccupancyId: "occupancy-id-1",
+					Entries: []*smart_booking.EligibilityHistoryEntry{
+						{
+							EvaluationType:    smart_booking.EvaluationType_EVALUATION_TYPE_ELIGIBILITY,
+							Eligible:          false,
+							IneligibleReasons: []smart.IneligibleReason{smart.IneligibleReason_INELIGIBLE_REASON_ALREADY_SMART},
+							EventType:         "EligibleOccupancyRemovedEvent",
+							EventId:           "uuid1",
+							OccurredAt:        timestamppb.New(at),
+						},
+						{
+							EvaluationType: smart_booking.EvaluationType_EVALUATION_TYPE_SUPPLIABILITY,
+							Eligible:       true,
+							EventType:      "SuppliableOccupancyAddedEvent",
+							EventId:        "uuid2",
+							OccurredAt:     timestamppb.New(at.Add(time.Hour)),
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "should return an empty history for an occupancy without evaluations",
+			input:       inputParams{req: req},
+			setup: func(m grpcMocks) {
+				expectAuthorised(m, true)
+				m.historyStore.EXPECT().Get(gomock.Any(), "occupancy-id-1", "account-id-1").Return([]domain.EvaluationHistoryEntry{}, nil)
+			},
+			output: outputParams{
+				res: &smart_booking.GetOccupancyEligibilityHistoryResponse{
+					AccountId:   "account-id-1",
+					OccupancyId: "occupancy-id-1",
+					Entries:     []*smart_booking.EligibilityHistoryEntry{},
+				},
+			},
+		},
+		{
+			description: "should fail when the history cannot be read",
+			input:       inputParams{req: req},
+			setup: func(m grpcMocks) {
+				expectAuthorised(m, true)
+				m.historyStore.EXPECT().Get(gomock.Any(), "occupancy-id-1", "account-id-1").Return(nil, errors.New("oops"))
+			},
+			output: outputParams{
+				res: nil,
+				err: status.Errorf(codes.Internal, "failed to get eligibility history for account %s", "account-id-1"),
+			},
+		},
+		{
+			description: "should fail because user is unauthorised",
+			input:       inputParams{req: req},
+			setup: func(m grpcMocks) {
+				expectAuthorised(m, false)
+			},
+			output: outputParams{
+				res: nil,
+				err: status.Errorf(codes.PermissionDenied, "user does not have access to this action, %s", api.ErrUserUnauthorised),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+
+			tc.setup(apiMocks)
+
+			expected, err := myAPIHandler.GetOccupancyEligibilityHistory(ctx, tc.input.req)
+			if tc.output.err != nil {
+				if diff := cmp.Diff(err.Error(), tc.output.err.Error()); diff != "" {
+					t.Fatal(diff)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(expected, tc.output.res, cmpopts.EquateEmpty(), cmpopts.IgnoreUnexported(
+				smart_booking.GetOccupancyEligibilityHistoryResponse{},
+				smart_booking.EligibilityHistoryEntry{},
+				timestamppb.Timestamp{},
+			)); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
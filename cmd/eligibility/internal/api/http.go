@@ -2,107 +2,171 @@ package api
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
-	"sync"
-	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/evaluation"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/store"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
 )
 
 type occupancyStore interface {
 	GetLiveOccupanciesPendingEvaluation(ctx context.Context) ([]string, error)
 	GetLiveOccupancies(ctx context.Context) ([]string, error)
+	GetLiveOccupanciesIDsByAccountID(ctx context.Context, accountID string) ([]string, error)
+	GetIDsByPostcode(ctx context.Context, postCode models.Postcode) ([]string, error)
+	GetIDsByMPXN(ctx context.Context, mpxn string) ([]string, error)
 }
 
 type evaluator interface {
-	RunFull(ctx context.Context, occupancyID string) error
+	Explain(ctx context.Context, occupancyID string) (*evaluation.Explanation, error)
+}
+
+type jobRunner interface {
+	Start(ctx context.Context, selector string, occupancyIDs []string) (store.EvaluationJob, error)
+	Get(ctx context.Context, id string) (store.EvaluationJob, error)
+	Cancel(ctx context.Context, id string) error
 }
 
 type Handler struct {
 	occupancyStore occupancyStore
 
 	evaluator evaluator
+	jobRunner jobRunner
 }
 
 func NewHandler(
 	occupancyStore occupancyStore,
-	evaluator evaluator) *Handler {
+	evaluator evaluator,
+	jobRunner jobRunner) *Handler {
 	return &Handler{
 		occupancyStore: occupancyStore,
 		evaluator:      evaluator,
+		jobRunner:      jobRunner,
 	}
 }
 
 const (
-	endpointFullEvaluation  = "/evaluation"
-	endpointRerunEvaluation = "/rerunEvaluation"
+	endpointFullEvaluation   = "/evaluation"
+	endpointRerunEvaluation  = "/rerunEvaluation"
+	endpointExplainOccupancy = "/explain/occupancies/{id}"
+	endpointExplainAccount   = "/explain/accounts/{id}"
+
+	endpointEvaluatePending     = "/jobs/evaluate/pending"
+	endpointEvaluateAll         = "/jobs/evaluate/all"
+	endpointEvaluateAccount     = "/jobs/evaluate/accounts/{id}"
+	endpointEvaluatePostcode    = "/jobs/evaluate/postcodes/{postcode}"
+	endpointEvaluateMPXN        = "/jobs/evaluate/mpxns/{mpxn}"
+	endpointEvaluateOccupancies = "/jobs/evaluate/occupancies"
+	endpointJob                 = "/jobs/{id}"
+	endpointCancelJob           = "/jobs/{id}/cancel"
 )
 
 // Register registers the http handler in a http router.
 func (s *Handler) Register(ctx context.Context, router *mux.Router) {
 	router.Handle(endpointFullEvaluation, s.runFullEvaluation(ctx)).Methods(http.MethodPatch)
 	router.Handle(endpointRerunEvaluation, s.rerunFullEvaluation(ctx)).Methods(http.MethodPatch)
+	router.Handle(endpointExplainOccupancy, s.explainOccupancy()).Methods(http.MethodGet)
+	router.Handle(endpointExplainAccount, s.explainAccount()).Methods(http.MethodGet)
+
+	router.Handle(endpointEvaluatePending, s.startJob(s.selectPending)).Methods(http.MethodPost)
+	router.Handle(endpointEvaluateAll, s.startJob(s.selectAll)).Methods(http.MethodPost)
+	router.Handle(endpointEvaluateAccount, s.evaluateAccount()).Methods(http.MethodPost)
+	router.Handle(endpointEvaluatePostcode, s.evaluatePostcode()).Methods(http.MethodPost)
+	router.Handle(endpointEvaluateMPXN, s.evaluateMPXN()).Methods(http.MethodPost)
+	router.Handle(endpointEvaluateOccupancies, s.evaluateOccupancies()).Methods(http.MethodPost)
+	router.Handle(endpointJob, s.getJob()).Methods(http.MethodGet)
+	router.Handle(endpointCancelJob, s.cancelJob()).Methods(http.MethodPost)
 }
 
 func (s *Handler) runFullEvaluation(_ context.Context) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		go func() {
-			start := time.Now()
-			jobCtx := context.Background()
-			occupancies, err := s.occupancyStore.GetLiveOccupanciesPendingEvaluation(jobCtx)
-			if err != nil {
-				slog.Error("failed to get live occupancies to evaluate", "error", err)
+	return s.startFullEvaluation(s.selectPending)
+}
+
+func (s *Handler) rerunFullEvaluation(_ context.Context) http.Handler {
+	return s.startFullEvaluation(s.selectAll)
+}
+
+// selectPending selects the live occupancies which are not evaluated.
+func (s *Handler) selectPending(r *http.Request) (string, []string, error) {
+	occupancies, err := s.occupancyStore.GetLiveOccupanciesPendingEvaluation(r.Context())
+	return "pending", occupancies, err
+}
+
+// selectAll selects all the live occupancies.
+func (s *Handler) selectAll(r *http.Request) (string, []string, error) {
+	occupancies, err := s.occupancyStore.GetLiveOccupancies(r.Context())
+	return "all", occupancies, err
+}
+
+// explainOccupancy re-runs the evaluation of an occupancy without publishing the results
+// and returns the trace of every rule evaluated.
+func (s *Handler) explainOccupancy() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		occupancyID := mux.Vars(r)["id"]
+
+		explanation, err := s.evaluator.Explain(r.Context(), occupancyID)
+		if err != nil {
+			if errors.Is(err, store.ErrOccupancyNotFound) {
+				w.WriteHeader(http.StatusNotFound)
 				return
 			}
-			s.runEvaluation(jobCtx, occupancies)
-			slog.Info("full evaluation process completed", "elapsed", time.Since(start).String())
-		}()
+			slog.Error("failed to explain evaluation", "occupancy_id", occupancyID, "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
 
-		w.Write([]byte("full evaluation started"))
+		writeJSON(w, explanation)
 	})
 }
 
-func (s *Handler) rerunFullEvaluation(_ context.Context) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		go func() {
-			start := time.Now()
-			jobCtx := context.Background()
-			occupancies, err := s.occupancyStore.GetLiveOccupancies(jobCtx)
+// explainAccount returns the evaluation trace of every live occupancy of an account.
+func (s *Handler) explainAccount() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accountID := mux.Vars(r)["id"]
+
+		occupancyIDs, err := s.occupancyStore.GetLiveOccupanciesIDsByAccountID(r.Context(), accountID)
+		if err != nil {
+			slog.Error("failed to get live occupancies for account", "account_id", accountID, "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if len(occupancyIDs) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		explanations := make([]*evaluation.Explanation, 0, len(occupancyIDs))
+		for _, occupancyID := range occupancyIDs {
+			explanation, err := s.evaluator.Explain(r.Context(), occupancyID)
 			if err != nil {
-				slog.Error("failed to get live occupancies to evaluate", "error", err)
+				slog.Error("failed to explain evaluation", "account_id", accountID, "occupancy_id", occupancyID, "error", err)
+				w.WriteHeader(http.StatusInternalServerError)
 				return
 			}
-			s.runEvaluation(jobCtx, occupancies)
-			slog.Info("full evaluation process completed", "elapsed", time.Since(start).String())
-		}()
+			explanations = append(explanations, explanation)
+		}
 
-		w.Write([]byte("full evaluation started"))
+		writeJSON(w, explanations)
 	})
 }
 
-func (s *Handler) runEvaluation(ctx context.Context, ids []string) {
-	channel := make(chan string, len(ids))
-	for _, o := range ids {
-		channel <- o
-	}
-	close(channel)
-
-	var wg sync.WaitGroup
-	for i := 0; i < 10; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for id := range channel {
-				now := time.Now()
-				err := s.evaluator.RunFull(ctx, id)
-				if err != nil {
-					slog.Error("failed to run evaluation", "occupancy_id", id, "error", err)
-				} else {
-					slog.Debug("evaluation successfully ran", "occupancy_id", id, "elapsed", time.Since(now).String())
-				}
-			}
-		}()
+func writeJSON(w http.ResponseWriter, v any) {
+	writeJSONStatus(w, http.StatusOK, v)
+}
+
+func writeJSONStatus(w http.ResponseWriter, status int, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		slog.Error("failed to marshal response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
 	}
-	wg.Wait()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
 }
@@ -0,0 +1,210 @@
+package api
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/jobs"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/store"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
+)
+
+// maxUploadedIDs limits the size of the occupancy ID lists uploaded to start a job.
+const maxUploadedIDs = 100_000
+
+var errInvalidIDList = errors.New("invalid occupancy ID list")
+
+type jobError struct {
+	OccupancyID string `json:"occupancy_id"`
+	Error       string `json:"error"`
+}
+
+type jobResponse struct {
+	ID          string     `json:"id"`
+	Selector    string     `json:"selector"`
+	Status      string     `json:"status"`
+	Total       int        `json:"total"`
+	Processed   int        `json:"processed"`
+	Failed      int        `json:"failed"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	Errors      []jobError `json:"errors"`
+}
+
+func toJobResponse(job store.EvaluationJob) jobResponse {
+	errs := make([]jobError, 0, len(job.Errors))
+	for _, e := range job.Errors {
+		errs = append(errs, jobError{OccupancyID: e.OccupancyID, Error: e.Error})
+	}
+
+	return jobResponse{
+		ID:          job.ID,
+		Selector:    job.Selector,
+		Status:      string(job.Status),
+		Total:       job.Total,
+		Processed:   job.Processed,
+		Failed:      job.Failed,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.UpdatedAt,
+		CompletedAt: job.CompletedAt,
+		Errors:      errs,
+	}
+}
+
+// selectOccupancies returns the job selector and the occupancies to evaluate for a request.
+type selectOccupancies func(r *http.Request) (string, []string, error)
+
+// evaluateAccount starts a job evaluating the live occupancies of an account.
+func (s *Handler) evaluateAccount() http.Handler {
+	return s.startJob(func(r *http.Request) (string, []string, error) {
+		accountID := mux.Vars(r)["id"]
+		occupancies, err := s.occupancyStore.GetLiveOccupanciesIDsByAccountID(r.Context(), accountID)
+		return fmt.Sprintf("account:%s", accountID), occupancies, err
+	})
+}
+
+// evaluatePostcode starts a job evaluating the occupancies at a postcode.
+func (s *Handler) evaluatePostcode() http.Handler {
+	return s.startJob(func(r *http.Request) (string, []string, error) {
+		// postcodes which don't parse are still looked up, they are stored in the same fallback form
+		postcode, _ := models.ParsePostcode(mux.Vars(r)["postcode"])
+		occupancies, err := s.occupancyStore.GetIDsByPostcode(r.Context(), postcode)
+		return fmt.Sprintf("postcode:%s", postcode), occupancies, err
+	})
+}
+
+// evaluateMPXN starts a job evaluating the live occupancies supplied through a meterpoint.
+func (s *Handler) evaluateMPXN() http.Handler {
+	return s.startJob(func(r *http.Request) (string, []string, error) {
+		mpxn := mux.Vars(r)["mpxn"]
+		occupancies, err := s.occupancyStore.GetIDsByMPXN(r.Context(), mpxn)
+		return fmt.Sprintf("mpxn:%s", mpxn), occupancies, err
+	})
+}
+
+// evaluateOccupancies starts a job evaluating the uploaded occupancy IDs, one per line.
+func (s *Handler) evaluateOccupancies() http.Handler {
+	return s.startJob(func(r *http.Request) (string, []string, error) {
+		seen := map[string]struct{}{}
+		occupancies := make([]string, 0)
+
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			id := strings.TrimSpace(scanner.Text())
+			if id == "" {
+				continue
+			}
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			if len(occupancies) == maxUploadedIDs {
+				return "", nil, fmt.Errorf("%w: more than %d IDs", errInvalidIDList, maxUploadedIDs)
+			}
+			seen[id] = struct{}{}
+			occupancies = append(occupancies, id)
+		}
+		if err := scanner.Err(); err != nil {
+			return "", nil, fmt.Errorf("%w: %w", errInvalidIDList, err)
+		}
+
+		return "occupancies", occupancies, nil
+	})
+}
+
+func (s *Handler) startJob(selectOccupancies selectOccupancies) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		selector, occupancies, err := selectOccupancies(r)
+		if err != nil {
+			if errors.Is(err, errInvalidIDList) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			slog.Error("failed to get occupancies to evaluate", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if len(occupancies) == 0 {
+			http.Error(w, fmt.Sprintf("no occupancies to evaluate for %s", selector), http.StatusNotFound)
+			return
+		}
+
+		job, err := s.jobRunner.Start(r.Context(), selector, occupancies)
+		if err != nil {
+			slog.Error("failed to start evaluation job", "selector", selector, "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		writeJSONStatus(w, http.StatusAccepted, toJobResponse(job))
+	})
+}
+
+// startFullEvaluation starts a job like startJob, but keeps the plain text response the full evaluation
+// endpoints had before the evaluations ran as jobs. The job started is linked in the Location header.
+func (s *Handler) startFullEvaluation(selectOccupancies selectOccupancies) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		selector, occupancies, err := selectOccupancies(r)
+		if err != nil {
+			slog.Error("failed to get occupancies to evaluate", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if len(occupancies) > 0 {
+			job, err := s.jobRunner.Start(r.Context(), selector, occupancies)
+			if err != nil {
+				slog.Error("failed to start evaluation job", "selector", selector, "error", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Location", strings.Replace(endpointJob, "{id}", job.ID, 1))
+		}
+
+		_, _ = w.Write([]byte("full evaluation started"))
+	})
+}
+
+func (s *Handler) getJob() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobID := mux.Vars(r)["id"]
+
+		job, err := s.jobRunner.Get(r.Context(), jobID)
+		if err != nil {
+			if errors.Is(err, store.ErrEvaluationJobNotFound) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			slog.Error("failed to get evaluation job", "job_id", jobID, "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, toJobResponse(job))
+	})
+}
+
+func (s *Handler) cancelJob() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobID := mux.Vars(r)["id"]
+
+		err := s.jobRunner.Cancel(r.Context(), jobID)
+		switch {
+		case err == nil:
+			w.WriteHeader(http.StatusAccepted)
+		case errors.Is(err, store.ErrEvaluationJobNotFound):
+			w.WriteHeader(http.StatusNotFound)
+		case errors.Is(err, jobs.ErrJobNotRunning):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			slog.Error("failed to cancel evaluation job", "job_id", jobID, "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
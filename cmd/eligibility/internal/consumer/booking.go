@@ -0,0 +1,91 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	energy_contracts "github.com/utilitywarehouse/energy-contracts/pkg/generated"
+	bookingv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/booking/v1"
+	"github.com/utilitywarehouse/energy-pkg/metrics"
+	"github.com/utilitywarehouse/energy-pkg/substratemessage"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/store"
+	"github.com/uw-labs/substrate"
+	"google.golang.org/protobuf/proto"
+)
+
+type BookingStore interface {
+	Add(ctx context.Context, bookingID, occupancyID string, status bookingv1.BookingStatus, at time.Time) error
+	GetOccupancyID(ctx context.Context, bookingID string) (string, error)
+}
+
+// HandleBooking keeps the status of the bookings of each occupancy, so that the
+// outcome of the bookings is taken into account by the campaignability rules.
+func HandleBooking(s BookingStore, evaluator CampaignableEvaluator, stateRebuild bool) substratemessage.BatchHandlerFunc {
+	return func(ctx context.Context, messages []substrate.Message) error {
+		for _, msg := range messages {
+			var env energy_contracts.Envelope
+			if err := proto.Unmarshal(msg.Data(), &env); err != nil {
+				return err
+			}
+
+			if env.Message == nil {
+				slog.Info("skipping empty booking message")
+				metrics.SkippedMessageCounter.WithLabelValues("empty_message").Inc()
+				continue
+			}
+
+			inner, err := env.Message.UnmarshalNew()
+			if err != nil {
+				return fmt.Errorf("error unmarshaling booking event [%s] %s: %w", env.GetUuid(), env.GetMessage().GetTypeUrl(), err)
+			}
+
+			occurredAt := time.Now()
+			if env.GetOccurredAt() != nil {
+				occurredAt = env.GetOccurredAt().AsTime()
+			}
+
+			var bookingID, occupancyID string
+			var status bookingv1.BookingStatus
+			switch x := inner.(type) {
+			case *bookingv1.BookingCreatedEvent:
+				bookingID, occupancyID, status = x.GetBookingId(), x.GetOccupancyId(), x.GetDetails().GetStatus()
+			case *bookingv1.BookingRescheduledEvent:
+				bookingID, status = x.GetBookingId(), bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED
+			case *bookingv1.BookingCancelledEvent:
+				bookingID, occupancyID, status = x.GetBookingId(), x.GetOccupancyId(), bookingv1.BookingStatus_BOOKING_STATUS_CANCELLED
+			case *bookingv1.BookingStatusChangedEvent:
+				bookingID, occupancyID, status = x.GetBookingId(), x.GetOccupancyId(), x.GetStatus()
+			default:
+				continue
+			}
+
+			// the reschedules do not carry the occupancy of the booking
+			if occupancyID == "" {
+				occupancyID, err = s.GetOccupancyID(ctx, bookingID)
+				if err != nil {
+					if errors.Is(err, store.ErrBookingNotFound) {
+						slog.Warn("skipping event of unknown booking", "event_uuid", env.GetUuid(), "booking_id", bookingID)
+						metrics.SkippedMessageCounter.WithLabelValues("booking_not_found").Inc()
+						continue
+					}
+					return fmt.Errorf("failed to get occupancy of booking %s for msg %s: %w", bookingID, env.GetUuid(), err)
+				}
+			}
+
+			if err := s.Add(ctx, bookingID, occupancyID, status, occurredAt); err != nil {
+				return fmt.Errorf("failed to process booking event %s: %w", env.GetUuid(), err)
+			}
+
+			if !stateRebuild {
+				if err := evaluator.RunCampaignability(ctx, occupancyID); err != nil {
+					return fmt.Errorf("failed to run campaignability for booking msg %s, occupancyID %s: %w", env.GetUuid(), occupancyID, err)
+				}
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,86 @@
+package consumer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	bookingv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/booking/v1"
+	"github.com/utilitywarehouse/energy-pkg/postgres"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/domain"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/store"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/store/migrations"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/testcommon"
+	"github.com/uw-labs/substrate"
+)
+
+type mockCampaignableEvaluator struct {
+	occupancyIDs []string
+}
+
+func (e *mockCampaignableEvaluator) RunCampaignability(_ context.Context, occupancyID string) error {
+	e.occupancyIDs = append(e.occupancyIDs, occupancyID)
+	return nil
+}
+
+func TestBookingConsumer(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+	container, err := postgres.SetupTestContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer container.Terminate(ctx)
+
+	postgresURL, err := postgres.GetTestContainerDSN(container)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool, err := postgres.Setup(ctx, postgresURL, migrations.Source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err = postgres.Teardown(pool, migrations.Source); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	s := store.NewBooking(pool)
+	evaluator := &mockCampaignableEvaluator{}
+
+	handler := HandleBooking(s, evaluator, false)
+
+	created, err := testcommon.MakeMessage(&bookingv1.BookingCreatedEvent{
+		BookingId:   "bookingID",
+		OccupancyId: "occupancyID",
+		Details: &bookingv1.Booking{
+			Status: bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED,
+		},
+	})
+	assert.NoError(err)
+	aborted, err := testcommon.MakeMessage(&bookingv1.BookingStatusChangedEvent{
+		BookingId:   "bookingID",
+		OccupancyId: "occupancyID",
+		Status:      bookingv1.BookingStatus_BOOKING_STATUS_ABORTED,
+	})
+	assert.NoError(err)
+	rescheduled, err := testcommon.MakeMessage(&bookingv1.BookingRescheduledEvent{
+		BookingId: "bookingID",
+	})
+	assert.NoError(err)
+	unknown, err := testcommon.MakeMessage(&bookingv1.BookingRescheduledEvent{
+		BookingId: "unknownBookingID",
+	})
+	assert.NoError(err)
+
+	err = handler(ctx, []substrate.Message{created, aborted, rescheduled, unknown})
+	assert.NoError(err, "failed to handle booking events")
+
+	bookings, err := s.GetByOccupancyID(ctx, "occupancyID")
+	assert.NoError(err, "failed to get bookings")
+	assert.Equal([]domain.Booking{
+		{ID: "bookingID", Status: bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED, AbortedVisits: 1},
+	}, bookings)
+	assert.Equal([]string{"occupancyID", "occupancyID", "occupancyID"}, evaluator.occupancyIDs, "mismatch")
+}
@@ -18,7 +18,7 @@ type CampaignabilityStore interface {
 	Add(ctx context.Context, occupancyID, accountID string, reasons domain.IneligibleReasons) error
 }
 
-func HandleCampaignability(store SuppliabilityStore) substratemessage.BatchHandlerFunc {
+func HandleCampaignability(store CampaignabilityStore, historyStore EvaluationHistoryStore) substratemessage.BatchHandlerFunc {
 	return func(ctx context.Context, messages []substrate.Message) error {
 		for _, msg := range messages {
 			var env energy_contracts.Envelope
@@ -38,7 +38,7 @@ func HandleCampaignability(store SuppliabilityStore) substratemessage.BatchHandl
 			}
 			switch x := inner.(type) {
 			case *smart.CampaignableOccupancyAddedEvent:
-				err = store.Add(ctx, x.GetOccupancyId(), x.GetAccountId(), nil)
+				err = projectEvaluation(ctx, store, historyStore, &env, x, domain.EvaluationTypeCampaignability, x.GetOccupancyId(), x.GetAccountId(), nil)
 			case *smart.CampaignableOccupancyRemovedEvent:
 				var reasons domain.IneligibleReasons
 				protoReasons := x.GetReasons()
@@ -49,7 +49,7 @@ func HandleCampaignability(store SuppliabilityStore) substratemessage.BatchHandl
 					}
 					reasons = append(reasons, ir)
 				}
-				err = store.Add(ctx, x.GetOccupancyId(), x.GetAccountId(), reasons)
+				err = projectEvaluation(ctx, store, historyStore, &env, x, domain.EvaluationTypeCampaignability, x.GetOccupancyId(), x.GetAccountId(), reasons)
 			}
 			if err != nil {
 				return fmt.Errorf("failed to process campaignability event %s: %w", env.GetUuid(), err)
@@ -39,7 +39,9 @@ func TestCampaignabilityConsumer(t *testing.T) {
 	}()
 	s := store.NewCampaignability(pool)
 
-	handler := HandleCampaignability(s)
+	historyStore := store.NewEvaluationHistory(pool)
+
+	handler := HandleCampaignability(s, historyStore)
 
 	campaignabilityEv1, err := testcommon.MakeMessage(&smart.CampaignableOccupancyAddedEvent{
 		OccupancyId: "occupancyID",
@@ -18,7 +18,7 @@ type EligibilityStore interface {
 	Add(ctx context.Context, occupancyID, accountID string, reasons domain.IneligibleReasons) error
 }
 
-func HandleEligibility(store EligibilityStore) substratemessage.BatchHandlerFunc {
+func HandleEligibility(store EligibilityStore, historyStore EvaluationHistoryStore) substratemessage.BatchHandlerFunc {
 	return func(ctx context.Context, messages []substrate.Message) error {
 		for _, msg := range messages {
 			var env energy_contracts.Envelope
@@ -38,7 +38,7 @@ func HandleEligibility(store EligibilityStore) substratemessage.BatchHandlerFunc
 			}
 			switch x := inner.(type) {
 			case *smart.EligibleOccupancyAddedEvent:
-				err = store.Add(ctx, x.GetOccupancyId(), x.GetAccountId(), nil)
+				err = projectEvaluation(ctx, store, historyStore, &env, x, domain.EvaluationTypeEligibility, x.GetOccupancyId(), x.GetAccountId(), nil)
 			case *smart.EligibleOccupancyRemovedEvent:
 				var reasons domain.IneligibleReasons
 				protoReasons := x.GetReasons()
@@ -49,7 +49,7 @@ func HandleEligibility(store EligibilityStore) substratemessage.BatchHandlerFunc
 					}
 					reasons = append(reasons, ir)
 				}
-				err = store.Add(ctx, x.GetOccupancyId(), x.GetAccountId(), reasons)
+				err = projectEvaluation(ctx, store, historyStore, &env, x, domain.EvaluationTypeEligibility, x.GetOccupancyId(), x.GetAccountId(), reasons)
 			}
 			if err != nil {
 				return fmt.Errorf("failed to process eligibility event %s: %w", env.GetUuid(), err)
@@ -39,7 +39,9 @@ func TestEligibilityConsumer(t *testing.T) {
 	}()
 	s := store.NewEligibility(pool)
 
-	handler := HandleEligibility(s)
+	historyStore := store.NewEvaluationHistory(pool)
+
+	handler := HandleEligibility(s, historyStore)
 
 	eligibilityEv1, err := testcommon.MakeMessage(&smart.EligibleOccupancyAddedEvent{
 		OccupancyId: "occupancyID",
@@ -76,4 +78,13 @@ func TestEligibilityConsumer(t *testing.T) {
 	assert.NoError(err, "failed to get eligibility")
 	expected.Reasons = domain.IneligibleReasons{domain.IneligibleReasonAlreadySmart, domain.IneligibleReasonComplexTariff}
 	assert.Equal(expected, eligibility, "eligibility mismatch")
+
+	history, err := historyStore.Get(ctx, "occupancyID", "accountID")
+	assert.NoError(err, "failed to get evaluation history")
+	assert.Len(history, 2)
+	assert.Equal("EligibleOccupancyAddedEvent", history[0].EventType)
+	assert.Empty(history[0].Reasons)
+	assert.Equal("EligibleOccupancyRemovedEvent", history[1].EventType)
+	assert.Equal(domain.EvaluationTypeEligibility, history[1].EvaluationType)
+	assert.Equal(expected.Reasons, history[1].Reasons)
 }
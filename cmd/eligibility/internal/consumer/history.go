@@ -0,0 +1,41 @@
+package consumer
+
+import (
+	"context"
+	"time"
+
+	energy_contracts "github.com/utilitywarehouse/energy-contracts/pkg/generated"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/domain"
+	"google.golang.org/protobuf/proto"
+)
+
+type EvaluationHistoryStore interface {
+	Add(ctx context.Context, entry domain.EvaluationHistoryEntry) error
+}
+
+type evaluationStore interface {
+	Add(ctx context.Context, occupancyID, accountID string, reasons domain.IneligibleReasons) error
+}
+
+// projectEvaluation stores the latest evaluation result of an occupancy and appends it to its history.
+func projectEvaluation(ctx context.Context, store evaluationStore, historyStore EvaluationHistoryStore, env *energy_contracts.Envelope, event proto.Message,
+	evaluationType domain.EvaluationType, occupancyID, accountID string, reasons domain.IneligibleReasons) error {
+	if err := store.Add(ctx, occupancyID, accountID, reasons); err != nil {
+		return err
+	}
+
+	occurredAt := time.Now()
+	if env.GetOccurredAt() != nil {
+		occurredAt = env.GetOccurredAt().AsTime()
+	}
+
+	return historyStore.Add(ctx, domain.EvaluationHistoryEntry{
+		OccupancyID:    occupancyID,
+		AccountID:      accountID,
+		EvaluationType: evaluationType,
+		Reasons:        reasons,
+		EventType:      string(event.ProtoReflect().Descriptor().Name()),
+		EventUUID:      env.GetUuid(),
+		OccurredAt:     occurredAt,
+	})
+}
@@ -10,12 +10,14 @@ import (
 	"github.com/utilitywarehouse/energy-contracts/pkg/generated/platform"
 	"github.com/utilitywarehouse/energy-pkg/metrics"
 	"github.com/utilitywarehouse/energy-pkg/substratemessage"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/validation"
 	"github.com/uw-labs/substrate"
 	"google.golang.org/protobuf/proto"
 )
 
 type SiteStore interface {
-	Add(ctx context.Context, id, postCode string, at time.Time) error
+	Add(ctx context.Context, id string, postCode models.Postcode, at time.Time) error
 }
 
 type OccupancySiteStore interface {
@@ -52,7 +54,12 @@ func HandleSite(store SiteStore, occupancyStore OccupancySiteStore, evaluator Ev
 					slog.Info("skipping site event, empty postcode", "site_id", x.GetSiteId())
 					continue
 				}
-				err = store.Add(ctx, x.GetSiteId(), x.GetAddress().GetPostcode(), env.OccurredAt.AsTime())
+				postCode, err := models.ParsePostcode(x.GetAddress().GetPostcode())
+				if err != nil {
+					slog.Warn("site postcode is not a valid UK postcode", "site_id", x.GetSiteId(), "postcode", x.GetAddress().GetPostcode())
+					validation.PostcodeParseFailures.WithLabelValues("site").Inc()
+				}
+				err = store.Add(ctx, x.GetSiteId(), postCode, env.OccurredAt.AsTime())
 				if err != nil {
 					return fmt.Errorf("failed to process site event %s: %w", env.Uuid, err)
 				}
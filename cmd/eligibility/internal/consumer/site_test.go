@@ -43,7 +43,7 @@ func TestSiteConsumer(t *testing.T) {
 	siteEv1, err := testcommon.MakeMessage(&platform.SiteDiscoveredEvent{
 		SiteId: "siteID",
 		Address: &platform.SiteAddress{
-			Postcode: "postCode",
+			Postcode: "sw1a1aa",
 		},
 	})
 	assert.NoError(err)
@@ -55,7 +55,7 @@ func TestSiteConsumer(t *testing.T) {
 	assert.NoError(err, "failed to get site")
 	expected := store.Site{
 		ID:       "siteID",
-		PostCode: "postCode",
+		PostCode: "SW1A 1AA",
 	}
 	assert.Equal(expected, site, "site mismatch")
 }
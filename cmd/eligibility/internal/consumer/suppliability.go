@@ -18,7 +18,7 @@ type SuppliabilityStore interface {
 	Add(ctx context.Context, occupancyID, accountID string, reasons domain.IneligibleReasons) error
 }
 
-func HandleSuppliability(store SuppliabilityStore) substratemessage.BatchHandlerFunc {
+func HandleSuppliability(store SuppliabilityStore, historyStore EvaluationHistoryStore) substratemessage.BatchHandlerFunc {
 	return func(ctx context.Context, messages []substrate.Message) error {
 		for _, msg := range messages {
 			var env energy_contracts.Envelope
@@ -38,7 +38,7 @@ func HandleSuppliability(store SuppliabilityStore) substratemessage.BatchHandler
 			}
 			switch x := inner.(type) {
 			case *smart.SuppliableOccupancyAddedEvent:
-				err = store.Add(ctx, x.GetOccupancyId(), x.GetAccountId(), nil)
+				err = projectEvaluation(ctx, store, historyStore, &env, x, domain.EvaluationTypeSuppliability, x.GetOccupancyId(), x.GetAccountId(), nil)
 			case *smart.SuppliableOccupancyRemovedEvent:
 				var reasons domain.IneligibleReasons
 				protoReasons := x.GetReasons()
@@ -49,7 +49,7 @@ func HandleSuppliability(store SuppliabilityStore) substratemessage.BatchHandler
 					}
 					reasons = append(reasons, ir)
 				}
-				err = store.Add(ctx, x.GetOccupancyId(), x.GetAccountId(), reasons)
+				err = projectEvaluation(ctx, store, historyStore, &env, x, domain.EvaluationTypeSuppliability, x.GetOccupancyId(), x.GetAccountId(), reasons)
 			}
 			if err != nil {
 				return fmt.Errorf("failed to process suppliability event %s: %w", env.GetUuid(), err)
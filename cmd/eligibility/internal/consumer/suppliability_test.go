@@ -39,7 +39,9 @@ func TestSuppliabilityConsumer(t *testing.T) {
 	}()
 	s := store.NewSuppliability(pool)
 
-	handler := HandleSuppliability(s)
+	historyStore := store.NewEvaluationHistory(pool)
+
+	handler := HandleSuppliability(s, historyStore)
 
 	suppEv1, err := testcommon.MakeMessage(&smart.SuppliableOccupancyAddedEvent{
 		OccupancyId: "occupancyID",
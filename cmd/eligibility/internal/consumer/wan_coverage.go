@@ -9,16 +9,18 @@ import (
 	smart "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart/v1"
 	"github.com/utilitywarehouse/energy-pkg/metrics"
 	"github.com/utilitywarehouse/energy-pkg/substratemessage"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/validation"
 	"github.com/uw-labs/substrate"
 	"google.golang.org/protobuf/proto"
 )
 
 type PostcodeStore interface {
-	AddWanCoverage(ctx context.Context, postCode string, covered bool) error
+	AddWanCoverage(ctx context.Context, postCode models.Postcode, covered bool) error
 }
 
 type OccupancyPostcodeStore interface {
-	GetIDsByPostcode(ctx context.Context, postCode string) ([]string, error)
+	GetIDsByPostcode(ctx context.Context, postCode models.Postcode) ([]string, error)
 }
 
 func HandleWanCoverage(store PostcodeStore, occupancyStore OccupancyPostcodeStore, evaluator Evaluator, stateRebuild bool) substratemessage.BatchHandlerFunc {
@@ -39,18 +41,28 @@ func HandleWanCoverage(store PostcodeStore, occupancyStore OccupancyPostcodeStor
 			if err != nil {
 				return fmt.Errorf("error unmarshaling wan coverage event [%s] %s: %w", env.GetUuid(), env.GetMessage().GetTypeUrl(), err)
 			}
-			switch x := inner.(type) {
+			var covered bool
+			switch inner.(type) {
 			case *smart.WanCoverageAtPostcodeStartedEvent:
-				err = store.AddWanCoverage(ctx, x.GetPostcode(), true)
+				covered = true
 			case *smart.WanCoverageAtPostcodeEndedEvent:
-				err = store.AddWanCoverage(ctx, x.GetPostcode(), false)
+				covered = false
+			default:
+				continue
 			}
+
+			rawPostCode := inner.(wanCoverageIdentifier).GetPostcode()
+			postCode, err := models.ParsePostcode(rawPostCode)
 			if err != nil {
+				slog.Warn("wan coverage postcode is not a valid UK postcode", "event_uuid", env.GetUuid(), "postcode", rawPostCode)
+				validation.PostcodeParseFailures.WithLabelValues("wan_coverage").Inc()
+			}
+
+			if err = store.AddWanCoverage(ctx, postCode, covered); err != nil {
 				return fmt.Errorf("failed to process wan coverage event %s: %w", env.Uuid, err)
 			}
 
 			if !stateRebuild {
-				postCode := inner.(wanCoverageIdentifier).GetPostcode()
 				occupanciesIDs, err := occupancyStore.GetIDsByPostcode(ctx, postCode)
 				if err != nil {
 					return fmt.Errorf("failed to get occupancies for msg %s, postCode %s: %w", env.GetUuid(), postCode, err)
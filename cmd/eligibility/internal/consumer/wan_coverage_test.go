@@ -41,26 +41,26 @@ func TestWanCoverageConsumer(t *testing.T) {
 	handler := HandleWanCoverage(s, nil, nil, true)
 
 	wanCoverageEv1, err := testcommon.MakeMessage(&smart.WanCoverageAtPostcodeStartedEvent{
-		Postcode: "postCode",
+		Postcode: "sw1a1aa",
 	})
 	assert.NoError(err)
 
 	err = handler(ctx, []substrate.Message{wanCoverageEv1})
 	assert.NoError(err, "failed to handle wan coverage event")
 
-	covered, err := s.GetWanCoverage(ctx, "postCode")
+	covered, err := s.GetWanCoverage(ctx, "SW1A 1AA")
 	assert.NoError(err, "failed to get wan coverage for post code")
 	assert.True(covered)
 
 	wanCoverageEv2, err := testcommon.MakeMessage(&smart.WanCoverageAtPostcodeEndedEvent{
-		Postcode: "postCode",
+		Postcode: "SW1A 1AA",
 	})
 	assert.NoError(err)
 
 	err = handler(ctx, []substrate.Message{wanCoverageEv2})
 	assert.NoError(err, "failed to handle wan coverage removed event")
 
-	covered, err = s.GetWanCoverage(ctx, "postCode")
+	covered, err = s.GetWanCoverage(ctx, "SW1A 1AA")
 	assert.NoError(err, "failed to get wan coverage for post code")
 	assert.False(covered)
 }
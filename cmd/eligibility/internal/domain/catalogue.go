@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/utilitywarehouse/energy-contracts/pkg/generated/platform"
+)
+
+// The catalogues shipped with the service are copies of the ones deployed from infra/resources, the
+// copies are kept identical by TestDefaultCataloguesMatchDeployed.
+//go:generate cp ../../../../infra/resources/unsupported_ssc_list.tsv data/unsupported_sscs.tsv
+//go:generate cp ../../../../infra/resources/standard_meter_capacities.tsv data/standard_meter_capacities.tsv
+
+var (
+	//go:embed data/unsupported_sscs.tsv
+	defaultUnsupportedSSCs []byte
+	//go:embed data/standard_meter_capacities.tsv
+	defaultStandardCapacities []byte
+
+	// DefaultUnsupportedSSCs is the unsupported SSCs catalogue shipped with the service.
+	DefaultUnsupportedSSCs = mustParse(ParseUnsupportedSSCs, defaultUnsupportedSSCs)
+	// DefaultStandardCapacities are the standard meter capacities shipped with the service.
+	DefaultStandardCapacities = mustParse(ParseStandardCapacities, defaultStandardCapacities)
+)
+
+// UnsupportedSSCs holds, per profile class, the SSCs which are considered complex tariffs.
+type UnsupportedSSCs map[platform.ProfileClass]map[string]struct{}
+
+// HasComplexSSC returns true if the SSC of the meterpoint is unsupported for its profile class.
+func (u UnsupportedSSCs) HasComplexSSC(m ComplexSSCPredicate) bool {
+	sscs, ok := u[m.GetProfileClass()]
+	if !ok {
+		return false
+	}
+	_, found := sscs[m.GetSSC()]
+	return found
+}
+
+// ParseUnsupportedSSCs reads a catalogue of tab separated lines with the SSC and the comma separated
+// profile classes it is unsupported for, e.g. "0003\t02,04". Empty lines and lines starting with # are ignored.
+func ParseUnsupportedSSCs(r io.Reader) (UnsupportedSSCs, error) {
+	result := UnsupportedSSCs{}
+
+	err := scanLines(r, func(line string) error {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 2 {
+			return fmt.Errorf("expected ssc and profile classes, got %q", line)
+		}
+
+		ssc := strings.TrimSpace(fields[0])
+		if ssc == "" {
+			return fmt.Errorf("missing ssc in %q", line)
+		}
+
+		for _, pc := range strings.Split(fields[1], ",") {
+			profileClass, err := parseProfileClass(strings.TrimSpace(pc))
+			if err != nil {
+				return err
+			}
+			if _, ok := result[profileClass]; !ok {
+				result[profileClass] = map[string]struct{}{}
+			}
+			result[profileClass][ssc] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// StandardCapacities holds the gas meter capacities which are not considered large capacity.
+type StandardCapacities map[float32]struct{}
+
+// IsLargeCapacity returns true if the meter capacity is not one of the standard capacities.
+func (s StandardCapacities) IsLargeCapacity(m CapacityGetter) bool {
+	_, found := s[m.GetCapacity()]
+	return !found
+}
+
+// ParseStandardCapacities reads a list of capacities, one per line.
+// Empty lines and lines starting with # are ignored.
+func ParseStandardCapacities(r io.Reader) (StandardCapacities, error) {
+	result := StandardCapacities{}
+
+	err := scanLines(r, func(line string) error {
+		capacity, err := strconv.ParseFloat(line, 32)
+		if err != nil {
+			return fmt.Errorf("invalid capacity %q: %w", line, err)
+		}
+		result[float32(capacity)] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no standard capacities provided")
+	}
+
+	return result, nil
+}
+
+// parseProfileClass parses two digit profile classes, e.g. 02 for PROFILE_CLASS_02.
+func parseProfileClass(value string) (platform.ProfileClass, error) {
+	if len(value) == 1 {
+		value = "0" + value
+	}
+	profileClass, ok := platform.ProfileClass_value["PROFILE_CLASS_"+value]
+	if !ok {
+		return 0, fmt.Errorf("invalid profile class %q", value)
+	}
+	return platform.ProfileClass(profileClass), nil
+}
+
+func scanLines(r io.Reader, fn func(line string) error) error {
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err := fn(strings.TrimSpace(line)); err != nil {
+			return fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+	}
+
+	return scanner.Err()
+}
+
+func mustParse[T any](parse func(io.Reader) (T, error), data []byte) T {
+	result, err := parse(bytes.NewReader(data))
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
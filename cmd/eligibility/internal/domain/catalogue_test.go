@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestDefaultCataloguesMatchDeployed(t *testing.T) {
+	testCases := []struct {
+		deployed string
+		embedded []byte
+	}{
+		{deployed: "../../../../infra/resources/unsupported_ssc_list.tsv", embedded: defaultUnsupportedSSCs},
+		{deployed: "../../../../infra/resources/standard_meter_capacities.tsv", embedded: defaultStandardCapacities},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.deployed, func(t *testing.T) {
+			deployed, err := os.ReadFile(tc.deployed)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(deployed, tc.embedded) {
+				t.Fatalf("the catalogue shipped with the service differs from %s, run go generate ./cmd/eligibility/internal/domain", tc.deployed)
+			}
+		})
+	}
+}
@@ -30,6 +30,7 @@ const (
 	IneligibleReasonMissingMeterData
 	IneligibleReasonMissingMeterpointData
 	IneligibleReasonMissingSiteData
+	IneligibleReasonSpecialistVisitRequired
 )
 
 type IneligibleReasons []IneligibleReason
@@ -68,6 +69,8 @@ func (r IneligibleReason) String() string {
 		return "MissingMeterpointData"
 	case IneligibleReasonMissingSiteData:
 		return "MissingSiteData"
+	case IneligibleReasonSpecialistVisitRequired:
+		return "SpecialistVisitRequired"
 	}
 }
 
@@ -87,6 +90,8 @@ func fromString(str string) (IneligibleReason, error) {
 		return IneligibleReasonAbortedBookings, nil
 	case "BookingScheduled":
 		return IneligibleReasonBookingScheduled, nil
+	case "BookingCompleted":
+		return IneligibleReasonBookingCompleted, nil
 	case "ComplexTariff":
 		return IneligibleReasonComplexTariff, nil
 	case "BookingReferenceMissing":
@@ -105,9 +110,16 @@ func fromString(str string) (IneligibleReason, error) {
 		return IneligibleReasonMissingMeterpointData, nil
 	case "MissingSiteData":
 		return IneligibleReasonMissingSiteData, nil
+	case "SpecialistVisitRequired":
+		return IneligibleReasonSpecialistVisitRequired, nil
 	}
 }
 
+// ParseIneligibleReason returns the ineligible reason for its string representation.
+func ParseIneligibleReason(str string) (IneligibleReason, error) {
+	return fromString(str)
+}
+
 func (r IneligibleReasons) Value() (driver.Value, error) {
 	var json = jsoniter.ConfigCompatibleWithStandardLibrary
 	strReasons := make([]string, 0)
@@ -176,6 +188,8 @@ func MapIneligibleProtoToDomainReason(reason smart.IneligibleReason) (Ineligible
 		return IneligibleReasonMissingMeterpointData, nil
 	case smart.IneligibleReason_INELIGIBLE_REASON_MISSING_SITE_DATA:
 		return IneligibleReasonMissingSiteData, nil
+	case smart.IneligibleReason_INELIGIBLE_REASON_SPECIALIST_VISIT_REQUIRED:
+		return IneligibleReasonSpecialistVisitRequired, nil
 	}
 
 	return IneligibleReasonUnknown, fmt.Errorf("reason not mapped")
@@ -213,6 +227,28 @@ func (r IneligibleReasons) Contains(reason IneligibleReason) bool {
 	return false
 }
 
+// Unique returns the reasons without duplicates, keeping the order in which they first appear.
+func (r IneligibleReasons) Unique() IneligibleReasons {
+	unique := make(IneligibleReasons, 0, len(r))
+	for _, reason := range r {
+		if !unique.Contains(reason) {
+			unique = append(unique, reason)
+		}
+	}
+	return unique
+}
+
+// Without returns the reasons other than the excluded ones.
+func (r IneligibleReasons) Without(excluded ...IneligibleReason) IneligibleReasons {
+	reasons := make(IneligibleReasons, 0, len(r))
+	for _, reason := range r {
+		if !IneligibleReasons(excluded).Contains(reason) {
+			reasons = append(reasons, reason)
+		}
+	}
+	return reasons
+}
+
 func mapDomainToProtoReason(reason IneligibleReason) (smart.IneligibleReason, error) {
 	switch reason {
 	case IneligibleReasonUnknown:
@@ -247,6 +283,8 @@ func mapDomainToProtoReason(reason IneligibleReason) (smart.IneligibleReason, er
 		return smart.IneligibleReason_INELIGIBLE_REASON_MISSING_METERPOINT_DATA, nil
 	case IneligibleReasonMissingSiteData:
 		return smart.IneligibleReason_INELIGIBLE_REASON_MISSING_SITE_DATA, nil
+	case IneligibleReasonSpecialistVisitRequired:
+		return smart.IneligibleReason_INELIGIBLE_REASON_SPECIALIST_VISIT_REQUIRED, nil
 	default:
 		return smart.IneligibleReason_INELIGIBLE_REASON_UNKNOWN, fmt.Errorf("reason not mapped")
 	}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+type EvaluationType string
+
+const (
+	EvaluationTypeEligibility     EvaluationType = "eligibility"
+	EvaluationTypeSuppliability   EvaluationType = "suppliability"
+	EvaluationTypeCampaignability EvaluationType = "campaignability"
+)
+
+// EvaluationHistoryEntry is the result of an evaluation of an occupancy as published at the time,
+// along with the event which recorded it.
+type EvaluationHistoryEntry struct {
+	OccupancyID    string
+	AccountID      string
+	EvaluationType EvaluationType
+	Reasons        IneligibleReasons
+	EventType      string
+	EventUUID      string
+	OccurredAt     time.Time
+}
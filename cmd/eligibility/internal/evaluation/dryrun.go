@@ -0,0 +1,261 @@
+package evaluation
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/domain"
+)
+
+const (
+	criterionCampaignability = "campaignability"
+	criterionEligibility     = "eligibility"
+	criterionSuppliability   = "suppliability"
+	criterionBookingJourney  = "booking_journey"
+)
+
+// DryRunResult holds the evaluation of an occupancy against the evaluator rule set and meter catalogue (baseline)
+// and against a candidate rule set and meter catalogue.
+type DryRunResult struct {
+	OccupancyID string
+	AccountID   string
+	Baseline    domain.OccupancyEvaluation
+	Candidate   domain.OccupancyEvaluation
+}
+
+// DryRun evaluates the occupancy against the evaluator and the candidate rule sets without publishing anything.
+// The candidate is evaluated with the candidate meter catalogue, or the evaluator one if it is nil.
+func (e *Evaluator) DryRun(ctx context.Context, occupancyID string, candidate *RuleSet, candidateCatalogue MeterCatalogue) (DryRunResult, error) {
+	occupancy, err := e.LoadOccupancy(ctx, occupancyID)
+	if err != nil {
+		return DryRunResult{}, fmt.Errorf("failed to load occupancy for ID %s: %w", occupancyID, err)
+	}
+
+	candidateEvaluator := e
+	if candidateCatalogue != nil {
+		withCatalogue := *e
+		withCatalogue.meterCatalogue = candidateCatalogue
+		candidateEvaluator = &withCatalogue
+	}
+
+	return DryRunResult{
+		OccupancyID: occupancy.ID,
+		AccountID:   occupancy.Account.ID,
+		Baseline:    e.evaluateAll(e.rules(), occupancy),
+		Candidate:   candidateEvaluator.evaluateAll(candidate, occupancy),
+	}, nil
+}
+
+func (e *Evaluator) evaluateAll(ruleSet *RuleSet, o *domain.Occupancy) domain.OccupancyEvaluation {
+	return domain.OccupancyEvaluation{
+		OccupancyID:              o.ID,
+		CampaignabilityEvaluated: true,
+		Campaignability:          e.evaluate(ruleSet.Campaignability, o),
+		EligibilityEvaluated:     true,
+		Eligibility:              e.evaluate(ruleSet.Eligibility, o),
+		SuppliabilityEvaluated:   true,
+		Suppliability:            e.evaluate(ruleSet.Suppliability, o),
+	}
+}
+
+// DryRunReport aggregates the differences between the baseline and candidate evaluations of many occupancies.
+// It is safe for concurrent use.
+type DryRunReport struct {
+	mu sync.Mutex
+
+	BaselineVersion  string                    `json:"baseline_version"`
+	CandidateVersion string                    `json:"candidate_version"`
+	Evaluated        int                       `json:"evaluated"`
+	Failed           int                       `json:"failed"`
+	Changed          int                       `json:"changed"`
+	Criteria         map[string]*CriterionDiff `json:"criteria"`
+
+	changes []DryRunChange
+}
+
+// CriterionDiff counts the occupancies changing status and the reasons gained or lost for a criterion.
+type CriterionDiff struct {
+	BecameEligible   int            `json:"became_eligible"`
+	BecameIneligible int            `json:"became_ineligible"`
+	ReasonsGained    map[string]int `json:"reasons_gained"`
+	ReasonsLost      map[string]int `json:"reasons_lost"`
+}
+
+// DryRunChange is an occupancy whose status for a criterion differs between the baseline and candidate rule sets.
+type DryRunChange struct {
+	OccupancyID       string
+	AccountID         string
+	Criterion         string
+	BaselineEligible  bool
+	CandidateEligible bool
+	ReasonsGained     []string
+	ReasonsLost       []string
+}
+
+func NewDryRunReport(baselineVersion, candidateVersion string) *DryRunReport {
+	criteria := make(map[string]*CriterionDiff, 4)
+	for _, criterion := range []string{criterionCampaignability, criterionEligibility, criterionSuppliability, criterionBookingJourney} {
+		criteria[criterion] = &CriterionDiff{
+			ReasonsGained: make(map[string]int),
+			ReasonsLost:   make(map[string]int),
+		}
+	}
+
+	return &DryRunReport{
+		BaselineVersion:  baselineVersion,
+		CandidateVersion: candidateVersion,
+		Criteria:         criteria,
+	}
+}
+
+// AddFailure records an occupancy which could not be evaluated.
+func (r *DryRunReport) AddFailure() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Failed++
+}
+
+// Add records the differences of a dry run result.
+func (r *DryRunReport) Add(result DryRunResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Evaluated++
+
+	changed := false
+	changed = r.addCriterion(result, criterionCampaignability, result.Baseline.Campaignability, result.Candidate.Campaignability) || changed
+	changed = r.addCriterion(result, criterionEligibility, result.Baseline.Eligibility, result.Candidate.Eligibility) || changed
+	changed = r.addCriterion(result, criterionSuppliability, result.Baseline.Suppliability, result.Candidate.Suppliability) || changed
+
+	baselineEligible := isBookingJourneyEligible(result.Baseline.Campaignability, result.Baseline.Eligibility, result.Baseline.Suppliability)
+	candidateEligible := isBookingJourneyEligible(result.Candidate.Campaignability, result.Candidate.Eligibility, result.Candidate.Suppliability)
+	if baselineEligible != candidateEligible {
+		diff := r.Criteria[criterionBookingJourney]
+		if candidateEligible {
+			diff.BecameEligible++
+		} else {
+			diff.BecameIneligible++
+		}
+		r.changes = append(r.changes, DryRunChange{
+			OccupancyID:       result.OccupancyID,
+			AccountID:         result.AccountID,
+			Criterion:         criterionBookingJourney,
+			BaselineEligible:  baselineEligible,
+			CandidateEligible: candidateEligible,
+		})
+		changed = true
+	}
+
+	if changed {
+		r.Changed++
+	}
+}
+
+func (r *DryRunReport) addCriterion(result DryRunResult, criterion string, baseline, candidate domain.IneligibleReasons) bool {
+	diff := r.Criteria[criterion]
+
+	gained := reasonsNotIn(candidate, baseline)
+	lost := reasonsNotIn(baseline, candidate)
+	for _, reason := range gained {
+		diff.ReasonsGained[reason]++
+	}
+	for _, reason := range lost {
+		diff.ReasonsLost[reason]++
+	}
+
+	baselineEligible := len(baseline) == 0
+	candidateEligible := len(candidate) == 0
+	if baselineEligible == candidateEligible {
+		return len(gained) > 0 || len(lost) > 0
+	}
+
+	if candidateEligible {
+		diff.BecameEligible++
+	} else {
+		diff.BecameIneligible++
+	}
+	r.changes = append(r.changes, DryRunChange{
+		OccupancyID:       result.OccupancyID,
+		AccountID:         result.AccountID,
+		Criterion:         criterion,
+		BaselineEligible:  baselineEligible,
+		CandidateEligible: candidateEligible,
+		ReasonsGained:     gained,
+		ReasonsLost:       lost,
+	})
+
+	return true
+}
+
+// Changes returns the occupancies changing status, sorted by occupancy ID and criterion.
+func (r *DryRunReport) Changes() []DryRunChange {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	changes := make([]DryRunChange, len(r.changes))
+	copy(changes, r.changes)
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].OccupancyID != changes[j].OccupancyID {
+			return changes[i].OccupancyID < changes[j].OccupancyID
+		}
+		return changes[i].Criterion < changes[j].Criterion
+	})
+
+	return changes
+}
+
+// WriteSummary writes the counts of the report as JSON.
+func (r *DryRunReport) WriteSummary(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteChangesCSV writes the occupancies changing status as CSV.
+func (r *DryRunReport) WriteChangesCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"occupancy_id", "account_id", "criterion", "baseline_eligible", "candidate_eligible", "reasons_gained", "reasons_lost"}); err != nil {
+		return err
+	}
+	for _, change := range r.Changes() {
+		if err := writer.Write([]string{
+			change.OccupancyID,
+			change.AccountID,
+			change.Criterion,
+			strconv.FormatBool(change.BaselineEligible),
+			strconv.FormatBool(change.CandidateEligible),
+			strings.Join(change.ReasonsGained, ";"),
+			strings.Join(change.ReasonsLost, ";"),
+		}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// reasonsNotIn returns the sorted names of the reasons in x which are not in y.
+func reasonsNotIn(x, y domain.IneligibleReasons) []string {
+	var result []string
+	for _, reason := range x {
+		if !y.Contains(reason) {
+			result = append(result, reason.String())
+		}
+	}
+	sort.Strings(result)
+
+	return result
+}
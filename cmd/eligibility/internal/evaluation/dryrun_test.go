@@ -0,0 +1,226 @@
+package evaluation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utilitywarehouse/energy-contracts/pkg/generated/platform"
+	energy_domain "github.com/utilitywarehouse/energy-pkg/domain"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/domain"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/testcommon"
+)
+
+const candidateRules = `
+version: "candidate"
+
+campaignability:
+  - name: opt-out
+    predicate: account_opt_out
+    reason: OptOut
+
+eligibility:
+  - name: meter-missing
+    predicate: meter_missing
+    reason: MissingMeterData
+
+suppliability:
+  - name: no-wan-coverage
+    predicate: no_wan_coverage
+    reason: NoWanCoverage
+  - name: alt-han
+    predicate: meterpoint_alt_han
+    reason: AltHan
+`
+
+func TestDryRun(t *testing.T) {
+	ctx := context.Background()
+
+	site := &domain.Site{
+		ID:          "site-id",
+		Postcode:    "AP 24X",
+		WanCoverage: true,
+	}
+	mStore := &mockStore{
+		occupancies: map[string]domain.Occupancy{
+			"complex-tariff": {ID: "complex-tariff", Account: domain.Account{ID: "account-id-1"}, Site: site},
+			"alt-han":        {ID: "alt-han", Account: domain.Account{ID: "account-id-2"}, Site: site},
+			"eligible":       {ID: "eligible", Account: domain.Account{ID: "account-id-3"}, Site: site},
+		},
+		servicesByOccupancy: map[string][]domain.Service{
+			"complex-tariff": {
+				{
+					ID:         "service-id-1",
+					Mpxn:       "mpxn-1",
+					SupplyType: energy_domain.SupplyTypeElectricity,
+					Meterpoint: &domain.Meterpoint{
+						Mpxn:         "mpxn-1",
+						ProfileClass: platform.ProfileClass_PROFILE_CLASS_02,
+						SSC:          "0003",
+					},
+				},
+			},
+			"alt-han": {
+				{
+					ID:         "service-id-2",
+					Mpxn:       "mpxn-2",
+					SupplyType: energy_domain.SupplyTypeElectricity,
+					Meterpoint: &domain.Meterpoint{
+						Mpxn:         "mpxn-2",
+						AltHan:       true,
+						ProfileClass: platform.ProfileClass_PROFILE_CLASS_01,
+					},
+				},
+			},
+			"eligible": {
+				{
+					ID:         "service-id-3",
+					Mpxn:       "mpxn-3",
+					SupplyType: energy_domain.SupplyTypeElectricity,
+					Meterpoint: &domain.Meterpoint{
+						Mpxn:         "mpxn-3",
+						ProfileClass: platform.ProfileClass_PROFILE_CLASS_01,
+					},
+				},
+			},
+		},
+		meters: map[string]domain.Meter{
+			"mpxn-1": {Mpxn: "mpxn-1", MSN: "msn-1", SupplyType: energy_domain.SupplyTypeElectricity, MeterType: "some_type"},
+			"mpxn-2": {Mpxn: "mpxn-2", MSN: "msn-2", SupplyType: energy_domain.SupplyTypeElectricity, MeterType: "some_type"},
+			"mpxn-3": {Mpxn: "mpxn-3", MSN: "msn-3", SupplyType: energy_domain.SupplyTypeElectricity, MeterType: "some_type"},
+		},
+	}
+
+	candidate, err := parseRuleSet([]byte(candidateRules), formatYAML)
+	require.NoError(t, err)
+
+	sink := &testcommon.MockSink{}
+	evaluator := NewEvaluator(mStore, mStore, mStore, sink, sink, sink, sink, &mockMeterSerialNumberStore{}, &mockPSRCodeStore{}, nil, nil)
+
+	report := NewDryRunReport(DefaultRuleSet().Version, candidate.Version)
+	for _, id := range []string{"complex-tariff", "alt-han", "eligible"} {
+		result, err := evaluator.DryRun(ctx, id, candidate, nil)
+		require.NoError(t, err)
+		report.Add(result)
+	}
+	report.AddFailure()
+
+	// nothing is published on dry runs
+	assert.Empty(t, sink.Msgs)
+
+	assert.Equal(t, 3, report.Evaluated)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, 1, report.Changed)
+
+	assert.Equal(t, &CriterionDiff{
+		BecameEligible: 1,
+		ReasonsGained:  map[string]int{},
+		ReasonsLost:    map[string]int{"ComplexTariff": 1},
+	}, report.Criteria[criterionEligibility])
+	assert.Equal(t, &CriterionDiff{
+		BecameEligible: 1,
+		ReasonsGained:  map[string]int{},
+		ReasonsLost:    map[string]int{},
+	}, report.Criteria[criterionBookingJourney])
+	assert.Equal(t, 0, report.Criteria[criterionSuppliability].BecameEligible)
+
+	assert.Equal(t, []DryRunChange{
+		{
+			OccupancyID:       "complex-tariff",
+			AccountID:         "account-id-1",
+			Criterion:         criterionBookingJourney,
+			BaselineEligible:  false,
+			CandidateEligible: true,
+		},
+		{
+			OccupancyID:       "complex-tariff",
+			AccountID:         "account-id-1",
+			Criterion:         criterionEligibility,
+			BaselineEligible:  false,
+			CandidateEligible: true,
+			ReasonsLost:       []string{"ComplexTariff"},
+		},
+	}, report.Changes())
+
+	var csv bytes.Buffer
+	require.NoError(t, report.WriteChangesCSV(&csv))
+	assert.Equal(t, `occupancy_id,account_id,criterion,baseline_eligible,candidate_eligible,reasons_gained,reasons_lost
+complex-tariff,account-id-1,booking_journey,false,true,,
+complex-tariff,account-id-1,eligibility,false,true,,ComplexTariff
+`, csv.String())
+
+	var summary bytes.Buffer
+	require.NoError(t, report.WriteSummary(&summary))
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(summary.Bytes(), &decoded))
+	assert.Equal(t, "candidate", decoded["candidate_version"])
+	assert.EqualValues(t, 1, decoded["changed"])
+}
+
+func TestDryRun_CandidateMeterCatalogue(t *testing.T) {
+	ctx := context.Background()
+
+	site := &domain.Site{
+		ID:          "site-id",
+		Postcode:    "AP 24X",
+		WanCoverage: true,
+	}
+	mStore := &mockStore{
+		occupancies: map[string]domain.Occupancy{
+			"complex-tariff": {ID: "complex-tariff", Account: domain.Account{ID: "account-id-1"}, Site: site},
+		},
+		servicesByOccupancy: map[string][]domain.Service{
+			"complex-tariff": {
+				{
+					ID:         "service-id-1",
+					Mpxn:       "mpxn-1",
+					SupplyType: energy_domain.SupplyTypeElectricity,
+					Meterpoint: &domain.Meterpoint{
+						Mpxn:         "mpxn-1",
+						ProfileClass: platform.ProfileClass_PROFILE_CLASS_02,
+						SSC:          "0003",
+					},
+				},
+			},
+		},
+		meters: map[string]domain.Meter{
+			"mpxn-1": {Mpxn: "mpxn-1", MSN: "msn-1", SupplyType: energy_domain.SupplyTypeElectricity, MeterType: "some_type"},
+		},
+	}
+
+	// the candidate catalogue no longer lists the SSC of the occupancy as unsupported
+	unsupportedSSCs, err := domain.ParseUnsupportedSSCs(strings.NewReader("0393\t01\n"))
+	require.NoError(t, err)
+	candidateCatalogue := struct {
+		domain.UnsupportedSSCs
+		domain.StandardCapacities
+	}{unsupportedSSCs, domain.DefaultStandardCapacities}
+
+	evaluator := NewEvaluator(mStore, mStore, mStore, nil, nil, nil, nil, &mockMeterSerialNumberStore{}, &mockPSRCodeStore{}, nil, nil)
+	ruleSet := DefaultRuleSet()
+
+	result, err := evaluator.DryRun(ctx, "complex-tariff", ruleSet, candidateCatalogue)
+	require.NoError(t, err)
+	assert.True(t, result.Baseline.Eligibility.Contains(domain.IneligibleReasonComplexTariff))
+	assert.False(t, result.Candidate.Eligibility.Contains(domain.IneligibleReasonComplexTariff))
+
+	report := NewDryRunReport(ruleSet.Version, ruleSet.Version)
+	report.Add(result)
+
+	assert.Equal(t, 1, report.Changed)
+	assert.Equal(t, &CriterionDiff{
+		BecameEligible: 1,
+		ReasonsGained:  map[string]int{},
+		ReasonsLost:    map[string]int{"ComplexTariff": 1},
+	}, report.Criteria[criterionEligibility])
+
+	// without a candidate catalogue the evaluator one is used
+	result, err = evaluator.DryRun(ctx, "complex-tariff", ruleSet, nil)
+	require.NoError(t, err)
+	assert.True(t, result.Candidate.Eligibility.Contains(domain.IneligibleReasonComplexTariff))
+}
@@ -18,7 +18,7 @@ func (e *Evaluator) RunFull(ctx context.Context, occupancyID string) error {
 		return fmt.Errorf("failed to load occupanncy for ID %s: %w", occupancyID, err)
 	}
 
-	cReasons := evaluateCampaignability(occupancy)
+	cReasons := e.evaluateCampaignability(occupancy)
 	err = e.publishCampaignabilityIfChanged(ctx, occupancy, cReasons)
 	if err != nil {
 		return fmt.Errorf("failed to evaluate campaignability for occupancy %s: %w", occupancyID, err)
@@ -30,7 +30,7 @@ func (e *Evaluator) RunFull(ctx context.Context, occupancyID string) error {
 		return fmt.Errorf("failed to evaluate eligibility for occupancy %s: %w", occupancyID, err)
 	}
 
-	sReasons := evaluateSuppliability(occupancy)
+	sReasons := e.evaluateSuppliability(occupancy)
 	err = e.publishSuppliabilityIfChanged(ctx, occupancy, sReasons)
 	if err != nil {
 		return fmt.Errorf("failed to evaluate suppliability for occupancy %s: %w", occupancyID, err)
@@ -56,7 +56,7 @@ func (e *Evaluator) RunCampaignability(ctx context.Context, occupancyID string)
 		return fmt.Errorf("failed to load occupanncy for ID %s: %w", occupancyID, err)
 	}
 
-	reasons := evaluateCampaignability(occupancy)
+	reasons := e.evaluateCampaignability(occupancy)
 	err = e.publishCampaignabilityIfChanged(ctx, occupancy, reasons)
 	if err != nil {
 		return fmt.Errorf("failed to evaluate campaignability for occupancy %s: %w", occupancyID, err)
@@ -78,7 +78,7 @@ func (e *Evaluator) RunSuppliability(ctx context.Context, occupancyID string) er
 		return fmt.Errorf("failed to load occupanncy for ID %s: %w", occupancyID, err)
 	}
 
-	reasons := evaluateSuppliability(occupancy)
+	reasons := e.evaluateSuppliability(occupancy)
 	err = e.publishSuppliabilityIfChanged(ctx, occupancy, reasons)
 	if err != nil {
 		return fmt.Errorf("failed to evaluate suppliability for occupancy %s: %w", occupancyID, err)
@@ -227,9 +227,8 @@ func (e *Evaluator) publishSmartBookingJourneyEligibilityIfNeeded(ctx context.Co
 		}
 	}
 
-	eligible := len(occupancy.EvaluationResult.Campaignability) == 0 &&
-		len(occupancy.EvaluationResult.Suppliability) == 0 &&
-		len(occupancy.EvaluationResult.Eligibility) == 0
+	result := occupancy.EvaluationResult
+	eligible := isBookingJourneyEligible(result.Campaignability, result.Eligibility, result.Suppliability)
 
 	if eligible && hasBookingRef {
 		err = e.bookingEligibilitySync.Sink(ctx, &smart.SmartBookingJourneyOccupancyAddedEvent{
@@ -251,6 +250,15 @@ func (e *Evaluator) publishSmartBookingJourneyEligibilityIfNeeded(ctx context.Co
 	return nil
 }
 
+// isBookingJourneyEligible reports whether an occupancy with these reasons goes through the smart booking journey.
+// A scheduled booking takes the occupancy out of campaigns, but it stays in the booking journey so that the
+// customer can still reschedule or cancel the booking.
+func isBookingJourneyEligible(campaignability, eligibility, suppliability domain.IneligibleReasons) bool {
+	return len(campaignability.Without(domain.IneligibleReasonBookingScheduled)) == 0 &&
+		len(eligibility) == 0 &&
+		len(suppliability) == 0
+}
+
 func ineligibleReasonSlicesEqual(x, y domain.IneligibleReasons) bool {
 	less := func(a, b domain.IneligibleReason) bool { return a < b }
 	return cmp.Equal(x, y, cmpopts.SortSlices(less))
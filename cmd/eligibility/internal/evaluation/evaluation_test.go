@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/utilitywarehouse/energy-contracts/pkg/generated/platform"
 	smart "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart/v1"
+	bookingv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/booking/v1"
 	energy_domain "github.com/utilitywarehouse/energy-pkg/domain"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/domain"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/store"
@@ -1889,6 +1890,236 @@ func TestRunCampaignability(t *testing.T) {
 				}, cMockSync.Msgs[0])
 			},
 		},
+		{
+			description: "occupancy not campaignable but kept in smart booking journey if booking scheduled",
+			occupancyID: "occupancy-id",
+			evaluator: Evaluator{
+				occupancyStore: &mockStore{occupancies: map[string]domain.Occupancy{
+					"occupancy-id": {
+						ID: "occupancy-id",
+						Account: domain.Account{
+							ID: "account-id",
+						},
+						Site: &domain.Site{
+							ID:          "site-id",
+							Postcode:    "AP 24X",
+							WanCoverage: true,
+						},
+						Bookings: []domain.Booking{
+							{ID: "booking-id", Status: bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED},
+						},
+						EvaluationResult: domain.OccupancyEvaluation{
+							OccupancyID:              "occupancy-id",
+							EligibilityEvaluated:     true,
+							Eligibility:              nil,
+							SuppliabilityEvaluated:   true,
+							Suppliability:            nil,
+							CampaignabilityEvaluated: true,
+							Campaignability:          nil,
+						},
+					},
+				}},
+				serviceStore: &mockStore{servicesByOccupancy: map[string][]domain.Service{
+					"occupancy-id": {
+						{
+							ID:         "service-id",
+							Mpxn:       "mpxn",
+							SupplyType: energy_domain.SupplyTypeElectricity,
+							Meterpoint: &domain.Meterpoint{
+								Mpxn:         "mpxn",
+								AltHan:       false,
+								ProfileClass: platform.ProfileClass_PROFILE_CLASS_06,
+								SSC:          "ssc",
+							},
+							BookingReference: "booking-ref",
+						},
+					},
+				}},
+				meterStore: &mockStore{meters: map[string]domain.Meter{
+					"mpxn": {
+						ID:         "meter-id",
+						Mpxn:       "mpxn",
+						MSN:        "msn",
+						SupplyType: energy_domain.SupplyTypeElectricity,
+						MeterType:  "some_type",
+					},
+				}},
+				eligibilitySync:        &eMockSync,
+				suppliabilitySync:      &sMockSync,
+				campaignabilitySync:    &cMockSync,
+				bookingEligibilitySync: &bMockSync,
+			},
+			checkOutput: func() {
+				assert.True(len(eMockSync.Msgs) == 0)
+				assert.True(len(sMockSync.Msgs) == 0)
+
+				assert.True(len(cMockSync.Msgs) == 1)
+				assert.True(len(bMockSync.Msgs) == 1)
+
+				assert.Equal(&smart.CampaignableOccupancyRemovedEvent{
+					OccupancyId: "occupancy-id",
+					AccountId:   "account-id",
+					Reasons:     []smart.IneligibleReason{smart.IneligibleReason_INELIGIBLE_REASON_BOOKING_SCHEDULED},
+				}, cMockSync.Msgs[0])
+				// the customer can still reschedule the booking
+				assert.Equal(&smart.SmartBookingJourneyOccupancyAddedEvent{
+					OccupancyId: "occupancy-id",
+					Reference:   "booking-ref",
+				}, bMockSync.Msgs[0])
+			},
+		},
+		{
+			description: "occupancy not campaignable if booking completed or visits repeatedly aborted",
+			occupancyID: "occupancy-id",
+			evaluator: Evaluator{
+				occupancyStore: &mockStore{occupancies: map[string]domain.Occupancy{
+					"occupancy-id": {
+						ID: "occupancy-id",
+						Account: domain.Account{
+							ID: "account-id",
+						},
+						Site: &domain.Site{
+							ID:          "site-id",
+							Postcode:    "AP 24X",
+							WanCoverage: true,
+						},
+						Bookings: []domain.Booking{
+							{ID: "booking-id-1", Status: bookingv1.BookingStatus_BOOKING_STATUS_ABORTED, AbortedVisits: 2},
+							{ID: "booking-id-2", Status: bookingv1.BookingStatus_BOOKING_STATUS_COMPLETED},
+						},
+						EvaluationResult: domain.OccupancyEvaluation{
+							OccupancyID:              "occupancy-id",
+							EligibilityEvaluated:     true,
+							Eligibility:              nil,
+							SuppliabilityEvaluated:   true,
+							Suppliability:            nil,
+							CampaignabilityEvaluated: true,
+							Campaignability:          nil,
+						},
+					},
+				}},
+				serviceStore: &mockStore{servicesByOccupancy: map[string][]domain.Service{
+					"occupancy-id": {
+						{
+							ID:         "service-id",
+							Mpxn:       "mpxn",
+							SupplyType: energy_domain.SupplyTypeElectricity,
+							Meterpoint: &domain.Meterpoint{
+								Mpxn:         "mpxn",
+								AltHan:       false,
+								ProfileClass: platform.ProfileClass_PROFILE_CLASS_06,
+								SSC:          "ssc",
+							},
+							BookingReference: "booking-ref",
+						},
+					},
+				}},
+				meterStore: &mockStore{meters: map[string]domain.Meter{
+					"mpxn": {
+						ID:         "meter-id",
+						Mpxn:       "mpxn",
+						MSN:        "msn",
+						SupplyType: energy_domain.SupplyTypeElectricity,
+						MeterType:  "some_type",
+					},
+				}},
+				eligibilitySync:        &eMockSync,
+				suppliabilitySync:      &sMockSync,
+				campaignabilitySync:    &cMockSync,
+				bookingEligibilitySync: &bMockSync,
+			},
+			checkOutput: func() {
+				assert.True(len(eMockSync.Msgs) == 0)
+				assert.True(len(sMockSync.Msgs) == 0)
+
+				assert.True(len(cMockSync.Msgs) == 1)
+				assert.True(len(bMockSync.Msgs) == 1)
+
+				removed, ok := cMockSync.Msgs[0].(*smart.CampaignableOccupancyRemovedEvent)
+				assert.True(ok)
+				assert.Equal("occupancy-id", removed.GetOccupancyId())
+				assert.ElementsMatch([]smart.IneligibleReason{
+					smart.IneligibleReason_INELIGIBLE_REASON_BOOKING_COMPLETED,
+					smart.IneligibleReason_INELIGIBLE_REASON_ABORTED_BOOKINGS,
+				}, removed.GetReasons())
+				assert.Equal(&smart.SmartBookingJourneyOccupancyRemovedEvent{
+					OccupancyId: "occupancy-id",
+				}, bMockSync.Msgs[0])
+			},
+		},
+		{
+			description: "occupancy campaignable after a single aborted visit",
+			occupancyID: "occupancy-id",
+			evaluator: Evaluator{
+				occupancyStore: &mockStore{occupancies: map[string]domain.Occupancy{
+					"occupancy-id": {
+						ID: "occupancy-id",
+						Account: domain.Account{
+							ID: "account-id",
+						},
+						Site: &domain.Site{
+							ID:          "site-id",
+							Postcode:    "AP 24X",
+							WanCoverage: true,
+						},
+						Bookings: []domain.Booking{
+							{ID: "booking-id", Status: bookingv1.BookingStatus_BOOKING_STATUS_NO_ACCESS, AbortedVisits: 1},
+						},
+						EvaluationResult: domain.OccupancyEvaluation{
+							OccupancyID:              "occupancy-id",
+							EligibilityEvaluated:     true,
+							Eligibility:              nil,
+							SuppliabilityEvaluated:   true,
+							Suppliability:            nil,
+							CampaignabilityEvaluated: true,
+							Campaignability:          nil,
+						},
+					},
+				}},
+				serviceStore: &mockStore{servicesByOccupancy: map[string][]domain.Service{
+					"occupancy-id": {
+						{
+							ID:         "service-id",
+							Mpxn:       "mpxn",
+							SupplyType: energy_domain.SupplyTypeElectricity,
+							Meterpoint: &domain.Meterpoint{
+								Mpxn:         "mpxn",
+								AltHan:       false,
+								ProfileClass: platform.ProfileClass_PROFILE_CLASS_06,
+								SSC:          "ssc",
+							},
+							BookingReference: "booking-ref",
+						},
+					},
+				}},
+				meterStore: &mockStore{meters: map[string]domain.Meter{
+					"mpxn": {
+						ID:         "meter-id",
+						Mpxn:       "mpxn",
+						MSN:        "msn",
+						SupplyType: energy_domain.SupplyTypeElectricity,
+						MeterType:  "some_type",
+					},
+				}},
+				eligibilitySync:        &eMockSync,
+				suppliabilitySync:      &sMockSync,
+				campaignabilitySync:    &cMockSync,
+				bookingEligibilitySync: &bMockSync,
+			},
+			checkOutput: func() {
+				// campaignability is unchanged so only the booking journey event is published
+				assert.True(len(eMockSync.Msgs) == 0)
+				assert.True(len(sMockSync.Msgs) == 0)
+
+				assert.True(len(cMockSync.Msgs) == 0)
+				assert.True(len(bMockSync.Msgs) == 1)
+
+				assert.Equal(&smart.SmartBookingJourneyOccupancyAddedEvent{
+					OccupancyId: "occupancy-id",
+					Reference:   "booking-ref",
+				}, bMockSync.Msgs[0])
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -2464,6 +2695,153 @@ func TestRunEligibility(t *testing.T) {
 				}, eMockSync.Msgs[0])
 			},
 		},
+		{
+			description: "occupancy not eligible if account has PSR code requiring specialist visit",
+			occupancyID: "occupancy-id",
+			evaluator: Evaluator{
+				meterSerialNumberStore: &mockMeterSerialNumberStore{},
+				psrCodeStore:           &mockPSRCodeStore{},
+				occupancyStore: &mockStore{occupancies: map[string]domain.Occupancy{
+					"occupancy-id": {
+						ID: "occupancy-id",
+						Account: domain.Account{
+							ID:       "account-id",
+							PSRCodes: []string{"02", specialistVisitPSRCode},
+						},
+						Site: &domain.Site{
+							ID:          "site-id",
+							Postcode:    "AP 24X",
+							WanCoverage: true,
+						},
+						EvaluationResult: domain.OccupancyEvaluation{
+							OccupancyID:              "occupancy-id",
+							EligibilityEvaluated:     false,
+							Eligibility:              nil,
+							SuppliabilityEvaluated:   false,
+							Suppliability:            nil,
+							CampaignabilityEvaluated: true,
+							Campaignability:          nil,
+						},
+					},
+				}},
+				serviceStore: &mockStore{servicesByOccupancy: map[string][]domain.Service{
+					"occupancy-id": {
+						{
+							ID:         "service-id",
+							Mpxn:       "mpxn",
+							SupplyType: energy_domain.SupplyTypeElectricity,
+							Meterpoint: &domain.Meterpoint{
+								Mpxn:         "mpxn",
+								AltHan:       false,
+								ProfileClass: platform.ProfileClass_PROFILE_CLASS_06,
+								SSC:          "ssc",
+							},
+							BookingReference: "booking-ref",
+						},
+					},
+				}},
+				meterStore: &mockStore{meters: map[string]domain.Meter{
+					"mpxn": {
+						ID:         "meter-id",
+						Mpxn:       "mpxn",
+						MSN:        "msn",
+						SupplyType: energy_domain.SupplyTypeElectricity,
+						MeterType:  "some_type",
+					},
+				}},
+				eligibilitySync:        &eMockSync,
+				suppliabilitySync:      &sMockSync,
+				campaignabilitySync:    &cMockSync,
+				bookingEligibilitySync: &bMockSync,
+			},
+			checkOutput: func() {
+				// only eligible + booking events should be published
+				assert.True(len(sMockSync.Msgs) == 0)
+				assert.True(len(cMockSync.Msgs) == 0)
+
+				assert.True(len(eMockSync.Msgs) == 1)
+				assert.True(len(bMockSync.Msgs) == 0)
+
+				assert.Equal(&smart.EligibleOccupancyRemovedEvent{
+					OccupancyId: "occupancy-id",
+					AccountId:   "account-id",
+					Reasons:     []smart.IneligibleReason{smart.IneligibleReason_INELIGIBLE_REASON_SPECIALIST_VISIT_REQUIRED},
+				}, eMockSync.Msgs[0])
+			},
+		},
+		{
+			description: "occupancy eligible if account PSR codes do not require specialist visit",
+			occupancyID: "occupancy-id",
+			evaluator: Evaluator{
+				meterSerialNumberStore: &mockMeterSerialNumberStore{},
+				psrCodeStore:           &mockPSRCodeStore{},
+				occupancyStore: &mockStore{occupancies: map[string]domain.Occupancy{
+					"occupancy-id": {
+						ID: "occupancy-id",
+						Account: domain.Account{
+							ID:       "account-id",
+							PSRCodes: []string{"02"},
+						},
+						Site: &domain.Site{
+							ID:          "site-id",
+							Postcode:    "AP 24X",
+							WanCoverage: true,
+						},
+						EvaluationResult: domain.OccupancyEvaluation{
+							OccupancyID:              "occupancy-id",
+							EligibilityEvaluated:     false,
+							Eligibility:              nil,
+							SuppliabilityEvaluated:   false,
+							Suppliability:            nil,
+							CampaignabilityEvaluated: true,
+							Campaignability:          nil,
+						},
+					},
+				}},
+				serviceStore: &mockStore{servicesByOccupancy: map[string][]domain.Service{
+					"occupancy-id": {
+						{
+							ID:         "service-id",
+							Mpxn:       "mpxn",
+							SupplyType: energy_domain.SupplyTypeElectricity,
+							Meterpoint: &domain.Meterpoint{
+								Mpxn:         "mpxn",
+								AltHan:       false,
+								ProfileClass: platform.ProfileClass_PROFILE_CLASS_06,
+								SSC:          "ssc",
+							},
+							BookingReference: "booking-ref",
+						},
+					},
+				}},
+				meterStore: &mockStore{meters: map[string]domain.Meter{
+					"mpxn": {
+						ID:         "meter-id",
+						Mpxn:       "mpxn",
+						MSN:        "msn",
+						SupplyType: energy_domain.SupplyTypeElectricity,
+						MeterType:  "some_type",
+					},
+				}},
+				eligibilitySync:        &eMockSync,
+				suppliabilitySync:      &sMockSync,
+				campaignabilitySync:    &cMockSync,
+				bookingEligibilitySync: &bMockSync,
+			},
+			checkOutput: func() {
+				// only eligible + booking events should be published
+				assert.True(len(sMockSync.Msgs) == 0)
+				assert.True(len(cMockSync.Msgs) == 0)
+
+				assert.True(len(eMockSync.Msgs) == 1)
+				assert.True(len(bMockSync.Msgs) == 0)
+
+				assert.Equal(&smart.EligibleOccupancyAddedEvent{
+					OccupancyId: "occupancy-id",
+					AccountId:   "account-id",
+				}, eMockSync.Msgs[0])
+			},
+		},
 		{
 			description: "occupancy not eligible for smart booking journey with no previous eligibility evaluation",
 			occupancyID: "occupancy-id",
@@ -2923,6 +3301,49 @@ func TestRunEligibility(t *testing.T) {
 	}
 }
 
+func TestIsBookingJourneyEligible(t *testing.T) {
+	type testCase struct {
+		description     string
+		campaignability domain.IneligibleReasons
+		eligibility     domain.IneligibleReasons
+		suppliability   domain.IneligibleReasons
+		expected        bool
+	}
+
+	testCases := []testCase{
+		{
+			description: "no reasons",
+			expected:    true,
+		},
+		{
+			description:     "a scheduled booking keeps the occupancy in the booking journey",
+			campaignability: domain.IneligibleReasons{domain.IneligibleReasonBookingScheduled},
+			expected:        true,
+		},
+		{
+			description:     "another campaignability reason takes the occupancy out of the booking journey",
+			campaignability: domain.IneligibleReasons{domain.IneligibleReasonBookingScheduled, domain.IneligibleReasonBookingCompleted},
+			expected:        false,
+		},
+		{
+			description: "an eligibility reason takes the occupancy out of the booking journey",
+			eligibility: domain.IneligibleReasons{domain.IneligibleReasonAlreadySmart},
+			expected:    false,
+		},
+		{
+			description:   "a suppliability reason takes the occupancy out of the booking journey",
+			suppliability: domain.IneligibleReasons{domain.IneligibleReasonNoWanCoverage},
+			expected:      false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isBookingJourneyEligible(tc.campaignability, tc.eligibility, tc.suppliability))
+		})
+	}
+}
+
 type mockStore struct {
 	occupancies         map[string]domain.Occupancy
 	servicesByOccupancy map[string][]domain.Service
@@ -2937,6 +3358,19 @@ func (m *mockMeterSerialNumberStore) FindMeterSerialNumber(s string) bool {
 	return strings.EqualFold(s, exceptionMSN)
 }
 
+type mockPSRCodeStore struct{}
+
+const specialistVisitPSRCode = "17"
+
+func (m *mockPSRCodeStore) RequiresSpecialistVisit(codes []string) bool {
+	for _, code := range codes {
+		if code == specialistVisitPSRCode {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *mockStore) LoadOccupancy(_ context.Context, id string) (domain.Occupancy, error) {
 	if occ, ok := s.occupancies[id]; ok {
 		return occ, nil
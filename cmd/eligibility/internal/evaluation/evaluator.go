@@ -25,11 +25,34 @@ type MeterSerialNumberStore interface {
 	FindMeterSerialNumber(msn string) bool
 }
 
+type PSRCodeStore interface {
+	RequiresSpecialistVisit(codes []string) bool
+}
+
+type MeterCatalogue interface {
+	HasComplexSSC(m domain.ComplexSSCPredicate) bool
+	IsLargeCapacity(m domain.CapacityGetter) bool
+}
+
+// defaultMeterCatalogue evaluates meters against the catalogues shipped with the service.
+type defaultMeterCatalogue struct{}
+
+func (defaultMeterCatalogue) HasComplexSSC(m domain.ComplexSSCPredicate) bool {
+	return domain.HasComplexSSC(m)
+}
+
+func (defaultMeterCatalogue) IsLargeCapacity(m domain.CapacityGetter) bool {
+	return domain.IsLargeCapacity(m)
+}
+
 type Evaluator struct {
 	occupancyStore         OccupancyStore
 	serviceStore           ServiceStore
 	meterStore             MeterStore
 	meterSerialNumberStore MeterSerialNumberStore
+	psrCodeStore           PSRCodeStore
+	meterCatalogue         MeterCatalogue
+	ruleSet                *RuleSet
 	eligibilitySync        publisher.SyncPublisher
 	suppliabilitySync      publisher.SyncPublisher
 	campaignabilitySync    publisher.SyncPublisher
@@ -38,7 +61,8 @@ type Evaluator struct {
 
 func NewEvaluator(occupanciesStore OccupancyStore, serviceStore ServiceStore, meterStore MeterStore,
 	eligibilitySync publisher.SyncPublisher, suppliabilitySync publisher.SyncPublisher, campaignabilitySync publisher.SyncPublisher,
-	bookingEligibilitySync publisher.SyncPublisher, meterSerialNumberStore MeterSerialNumberStore) *Evaluator {
+	bookingEligibilitySync publisher.SyncPublisher, meterSerialNumberStore MeterSerialNumberStore, psrCodeStore PSRCodeStore,
+	meterCatalogue MeterCatalogue, ruleSet *RuleSet) *Evaluator {
 	return &Evaluator{
 		occupancyStore:         occupanciesStore,
 		serviceStore:           serviceStore,
@@ -48,5 +72,8 @@ func NewEvaluator(occupanciesStore OccupancyStore, serviceStore ServiceStore, me
 		campaignabilitySync:    campaignabilitySync,
 		bookingEligibilitySync: bookingEligibilitySync,
 		meterSerialNumberStore: meterSerialNumberStore,
+		psrCodeStore:           psrCodeStore,
+		meterCatalogue:         meterCatalogue,
+		ruleSet:                ruleSet,
 	}
 }
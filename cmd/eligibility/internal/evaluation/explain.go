@@ -0,0 +1,222 @@
+package evaluation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/domain"
+)
+
+// Explanation is a trace of a fresh evaluation of an occupancy against the configured rule set,
+// together with the inputs used and the results currently stored for it.
+type Explanation struct {
+	OccupancyID     string               `json:"occupancy_id"`
+	AccountID       string               `json:"account_id"`
+	RuleSetVersion  string               `json:"rule_set_version"`
+	Inputs          OccupancyInputs      `json:"inputs"`
+	Services        []ServiceInputs      `json:"services"`
+	Campaignability CriterionExplanation `json:"campaignability"`
+	Eligibility     CriterionExplanation `json:"eligibility"`
+	Suppliability   CriterionExplanation `json:"suppliability"`
+	BookingJourney  BookingJourneyInputs `json:"booking_journey"`
+}
+
+// OccupancyInputs are the occupancy level values the rules are evaluated against.
+type OccupancyInputs struct {
+	SiteID                     string   `json:"site_id,omitempty"`
+	Postcode                   string   `json:"postcode,omitempty"`
+	WanCoverage                *bool    `json:"wan_coverage,omitempty"`
+	OptOut                     bool     `json:"opt_out"`
+	PSRCodes                   []string `json:"psr_codes,omitempty"`
+	PSRSpecialistVisitRequired bool     `json:"psr_specialist_visit_required"`
+}
+
+// ServiceInputs are the service, meterpoint and meter values the rules are evaluated against.
+type ServiceInputs struct {
+	ServiceID    string   `json:"service_id"`
+	Mpxn         string   `json:"mpxn"`
+	SupplyType   string   `json:"supply_type"`
+	Meterpoint   bool     `json:"meterpoint_found"`
+	SSC          string   `json:"ssc,omitempty"`
+	ProfileClass string   `json:"profile_class,omitempty"`
+	AltHan       bool     `json:"alt_han"`
+	Meter        bool     `json:"meter_found"`
+	MSN          string   `json:"msn,omitempty"`
+	MeterType    string   `json:"meter_type,omitempty"`
+	Capacity     *float32 `json:"capacity,omitempty"`
+	MSNException bool     `json:"msn_exception"`
+	BookingRef   string   `json:"booking_reference,omitempty"`
+}
+
+// BookingJourneyInputs are the values used to decide whether the occupancy goes through the smart booking journey.
+type BookingJourneyInputs struct {
+	HasBookingRef bool `json:"has_booking_reference"`
+	Eligible      bool `json:"eligible"`
+}
+
+// CriterionExplanation traces every rule of a criterion and compares the fresh result with the stored one.
+type CriterionExplanation struct {
+	Rules     []RuleTrace `json:"rules"`
+	Fresh     []string    `json:"fresh_reasons"`
+	Evaluated bool        `json:"stored_evaluated"`
+	Stored    []string    `json:"stored_reasons"`
+	Changed   bool        `json:"changed"`
+
+	fresh domain.IneligibleReasons
+}
+
+// RuleTrace records whether a rule fired, and for service predicates which services it fired for.
+type RuleTrace struct {
+	Rule       string         `json:"rule"`
+	Predicate  string         `json:"predicate"`
+	SupplyType string         `json:"supply_type,omitempty"`
+	Reason     string         `json:"reason"`
+	Fired      bool           `json:"fired"`
+	Services   []ServiceTrace `json:"services,omitempty"`
+}
+
+type ServiceTrace struct {
+	ServiceID string `json:"service_id"`
+	Mpxn      string `json:"mpxn"`
+	Fired     bool   `json:"fired"`
+}
+
+// Explain evaluates the occupancy against all the rules without publishing anything,
+// returning a per rule and per service trace of the evaluation.
+func (e *Evaluator) Explain(ctx context.Context, occupancyID string) (*Explanation, error) {
+	occupancy, err := e.LoadOccupancy(ctx, occupancyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load occupancy for ID %s: %w", occupancyID, err)
+	}
+
+	bookingRefs, err := e.serviceStore.GetLiveServicesWithBookingRef(ctx, occupancyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get booking refs for services of occupancy ID %s: %w", occupancyID, err)
+	}
+	serviceBookingRefs := make(map[string]string, len(bookingRefs))
+	hasBookingRef := len(bookingRefs) > 0
+	for _, s := range bookingRefs {
+		if s.BookingRef == "" || s.DeletedAt != nil {
+			hasBookingRef = false
+			continue
+		}
+		serviceBookingRefs[s.ServiceID] = s.BookingRef
+	}
+
+	ruleSet := e.rules()
+	result := occupancy.EvaluationResult
+
+	explanation := &Explanation{
+		OccupancyID:     occupancy.ID,
+		AccountID:       occupancy.Account.ID,
+		RuleSetVersion:  ruleSet.Version,
+		Inputs:          e.occupancyInputs(occupancy),
+		Services:        make([]ServiceInputs, 0, len(occupancy.Services)),
+		Campaignability: e.explainCriterion(ruleSet.Campaignability, occupancy, result.CampaignabilityEvaluated, result.Campaignability),
+		Eligibility:     e.explainCriterion(ruleSet.Eligibility, occupancy, result.EligibilityEvaluated, result.Eligibility),
+		Suppliability:   e.explainCriterion(ruleSet.Suppliability, occupancy, result.SuppliabilityEvaluated, result.Suppliability),
+	}
+
+	for _, s := range occupancy.Services {
+		inputs := e.serviceInputs(s)
+		inputs.BookingRef = serviceBookingRefs[s.ID]
+		explanation.Services = append(explanation.Services, inputs)
+	}
+
+	explanation.BookingJourney = BookingJourneyInputs{
+		HasBookingRef: hasBookingRef,
+		Eligible:      isBookingJourneyEligible(explanation.Campaignability.fresh, explanation.Eligibility.fresh, explanation.Suppliability.fresh),
+	}
+
+	return explanation, nil
+}
+
+func (e *Evaluator) explainCriterion(rules []Rule, o *domain.Occupancy, evaluated bool, stored domain.IneligibleReasons) CriterionExplanation {
+	result := evaluation{reason: make(map[domain.IneligibleReason]struct{}, 0)}
+	traces := make([]RuleTrace, 0, len(rules))
+
+	for _, r := range rules {
+		trace := RuleTrace{
+			Rule:       r.Name,
+			Predicate:  r.Predicate,
+			SupplyType: r.SupplyType,
+			Reason:     r.Reason.String(),
+		}
+
+		if r.occupancyPredicate != nil {
+			trace.Fired = r.occupancyPredicate(e, o)
+		} else {
+			for _, s := range o.Services {
+				if r.SupplyType != "" && s.SupplyType != r.supplyType {
+					continue
+				}
+				fired := r.servicePredicate(e, s)
+				trace.Services = append(trace.Services, ServiceTrace{
+					ServiceID: s.ID,
+					Mpxn:      s.Mpxn,
+					Fired:     fired,
+				})
+				trace.Fired = trace.Fired || fired
+			}
+		}
+
+		if trace.Fired {
+			result.addReason(r.Reason)
+		}
+		traces = append(traces, trace)
+	}
+
+	fresh := result.status()
+
+	return CriterionExplanation{
+		Rules:     traces,
+		Fresh:     fresh.ToString(),
+		Evaluated: evaluated,
+		Stored:    stored.ToString(),
+		Changed:   !evaluated || !ineligibleReasonSlicesEqual(stored, fresh),
+		fresh:     fresh,
+	}
+}
+
+func (e *Evaluator) occupancyInputs(o *domain.Occupancy) OccupancyInputs {
+	inputs := OccupancyInputs{
+		OptOut:   o.Account.OptOut,
+		PSRCodes: o.Account.PSRCodes,
+	}
+	if o.Site != nil {
+		wanCoverage := o.Site.WanCoverage
+		inputs.SiteID = o.Site.ID
+		inputs.Postcode = o.Site.Postcode
+		inputs.WanCoverage = &wanCoverage
+	}
+	if len(o.Account.PSRCodes) > 0 && e.psrCodeStore != nil {
+		inputs.PSRSpecialistVisitRequired = e.psrCodeStore.RequiresSpecialistVisit(o.Account.PSRCodes)
+	}
+
+	return inputs
+}
+
+func (e *Evaluator) serviceInputs(s domain.Service) ServiceInputs {
+	inputs := ServiceInputs{
+		ServiceID:  s.ID,
+		Mpxn:       s.Mpxn,
+		SupplyType: s.SupplyType.String(),
+	}
+	if s.Meterpoint != nil {
+		inputs.Meterpoint = true
+		inputs.SSC = s.Meterpoint.SSC
+		inputs.ProfileClass = s.Meterpoint.ProfileClass.String()
+		inputs.AltHan = s.Meterpoint.AltHan
+	}
+	if s.Meter != nil {
+		inputs.Meter = true
+		inputs.MSN = s.Meter.MSN
+		inputs.MeterType = s.Meter.MeterType
+		inputs.Capacity = s.Meter.Capacity
+		if e.meterSerialNumberStore != nil {
+			inputs.MSNException = e.meterSerialNumberStore.FindMeterSerialNumber(s.Meter.MSN)
+		}
+	}
+
+	return inputs
+}
@@ -0,0 +1,157 @@
+package evaluation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utilitywarehouse/energy-contracts/pkg/generated/platform"
+	energy_domain "github.com/utilitywarehouse/energy-pkg/domain"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/domain"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/store"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/testcommon"
+)
+
+func TestExplain(t *testing.T) {
+	ctx := context.Background()
+
+	var capacity float32 = 100
+
+	mStore := &mockStore{
+		occupancies: map[string]domain.Occupancy{
+			"occupancy-id": {
+				ID: "occupancy-id",
+				Account: domain.Account{
+					ID: "account-id",
+				},
+				Site: &domain.Site{
+					ID:          "site-id",
+					Postcode:    "AP 24X",
+					WanCoverage: true,
+				},
+				EvaluationResult: domain.OccupancyEvaluation{
+					OccupancyID:              "occupancy-id",
+					EligibilityEvaluated:     true,
+					Eligibility:              domain.IneligibleReasons{domain.IneligibleReasonAlreadySmart},
+					SuppliabilityEvaluated:   true,
+					Suppliability:            domain.IneligibleReasons{domain.IneligibleReasonMeterLargeCapacity},
+					CampaignabilityEvaluated: false,
+				},
+			},
+		},
+		servicesByOccupancy: map[string][]domain.Service{
+			"occupancy-id": {
+				{
+					ID:         "service-id-1",
+					Mpxn:       "mpan",
+					SupplyType: energy_domain.SupplyTypeElectricity,
+					Meterpoint: &domain.Meterpoint{
+						Mpxn:         "mpan",
+						ProfileClass: platform.ProfileClass_PROFILE_CLASS_01,
+						SSC:          "0393",
+					},
+					BookingReference: "booking-ref",
+				},
+				{
+					ID:               "service-id-2",
+					Mpxn:             "mprn",
+					SupplyType:       energy_domain.SupplyTypeGas,
+					BookingReference: "booking-ref",
+				},
+			},
+		},
+		meters: map[string]domain.Meter{
+			"mpan": {
+				ID:         "meter-id-1",
+				Mpxn:       "mpan",
+				MSN:        exceptionMSN,
+				SupplyType: energy_domain.SupplyTypeElectricity,
+				MeterType:  platform.MeterTypeElec_METER_TYPE_ELEC_S2A.String(),
+			},
+			"mprn": {
+				ID:         "meter-id-2",
+				Mpxn:       "mprn",
+				MSN:        "msn",
+				SupplyType: energy_domain.SupplyTypeGas,
+				Capacity:   &capacity,
+			},
+		},
+	}
+
+	sink := &testcommon.MockSink{}
+	evaluator := NewEvaluator(mStore, mStore, mStore, sink, sink, sink, sink, &mockMeterSerialNumberStore{}, &mockPSRCodeStore{}, nil, nil)
+
+	explanation, err := evaluator.Explain(ctx, "occupancy-id")
+	require.NoError(t, err)
+
+	// nothing is published when explaining an evaluation
+	assert.Empty(t, sink.Msgs)
+
+	assert.Equal(t, "occupancy-id", explanation.OccupancyID)
+	assert.Equal(t, "account-id", explanation.AccountID)
+	assert.Equal(t, DefaultRuleSet().Version, explanation.RuleSetVersion)
+
+	wanCoverage := true
+	assert.Equal(t, OccupancyInputs{
+		SiteID:      "site-id",
+		Postcode:    "AP 24X",
+		WanCoverage: &wanCoverage,
+	}, explanation.Inputs)
+
+	require.Len(t, explanation.Services, 2)
+	assert.Equal(t, ServiceInputs{
+		ServiceID:    "service-id-1",
+		Mpxn:         "mpan",
+		SupplyType:   energy_domain.SupplyTypeElectricity.String(),
+		Meterpoint:   true,
+		SSC:          "0393",
+		ProfileClass: platform.ProfileClass_PROFILE_CLASS_01.String(),
+		Meter:        true,
+		MSN:          exceptionMSN,
+		MeterType:    platform.MeterTypeElec_METER_TYPE_ELEC_S2A.String(),
+		MSNException: true,
+		BookingRef:   "booking-ref",
+	}, explanation.Services[0])
+	assert.Equal(t, &capacity, explanation.Services[1].Capacity)
+
+	// the smart electricity meter is in the MSN exception list, so the stored already smart reason is stale
+	assert.Empty(t, explanation.Eligibility.Fresh)
+	assert.Equal(t, []string{"AlreadySmart"}, explanation.Eligibility.Stored)
+	assert.True(t, explanation.Eligibility.Changed)
+
+	alreadySmart := findRuleTrace(t, explanation.Eligibility.Rules, "already-smart")
+	assert.False(t, alreadySmart.Fired)
+	assert.Equal(t, []ServiceTrace{
+		{ServiceID: "service-id-1", Mpxn: "mpan", Fired: false},
+		{ServiceID: "service-id-2", Mpxn: "mprn", Fired: false},
+	}, alreadySmart.Services)
+
+	assert.Equal(t, []string{"LargeCapacityMeter"}, explanation.Suppliability.Fresh)
+	assert.False(t, explanation.Suppliability.Changed)
+
+	largeCapacity := findRuleTrace(t, explanation.Suppliability.Rules, "gas-meter-large-capacity")
+	assert.True(t, largeCapacity.Fired)
+	assert.Equal(t, []ServiceTrace{{ServiceID: "service-id-2", Mpxn: "mprn", Fired: true}}, largeCapacity.Services)
+
+	// campaignability was never evaluated
+	assert.Empty(t, explanation.Campaignability.Fresh)
+	assert.False(t, explanation.Campaignability.Evaluated)
+	assert.True(t, explanation.Campaignability.Changed)
+
+	assert.Equal(t, BookingJourneyInputs{HasBookingRef: true, Eligible: false}, explanation.BookingJourney)
+
+	_, err = evaluator.Explain(ctx, "missing-occupancy-id")
+	assert.ErrorIs(t, err, store.ErrOccupancyNotFound)
+}
+
+func findRuleTrace(t *testing.T, traces []RuleTrace, rule string) RuleTrace {
+	t.Helper()
+	for _, trace := range traces {
+		if trace.Rule == rule {
+			return trace
+		}
+	}
+	t.Fatalf("rule %s not traced", rule)
+	return RuleTrace{}
+}
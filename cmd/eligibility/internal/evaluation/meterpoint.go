@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/domain"
 	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
+	"golang.org/x/sync/errgroup"
 )
 
 var (
@@ -16,21 +18,29 @@ var (
 type MeterpointIneligibleReason string
 
 const (
-	alreadySmartMeterpointIneligibleReason  MeterpointIneligibleReason = "already_a_smart_meter"
-	notWanMeterpointIneligibleReason        MeterpointIneligibleReason = "not_WAN"
-	isAltHanMeterpointIneligibleReason      MeterpointIneligibleReason = "Alt_HAN"
-	hasRelatedMeterpointIneligibleReason    MeterpointIneligibleReason = "related_meterpoints_present"
-	hasComplexSSCMeterpointIneligibleReason MeterpointIneligibleReason = "complex_SSC"
-	largeCapacityMeterpointIneligibleReason MeterpointIneligibleReason = "large_capacity"
+	MeterpointIneligibleReasonAlreadySmart       MeterpointIneligibleReason = "already_a_smart_meter"
+	MeterpointIneligibleReasonNoWan              MeterpointIneligibleReason = "not_WAN"
+	MeterpointIneligibleReasonAltHan             MeterpointIneligibleReason = "Alt_HAN"
+	MeterpointIneligibleReasonRelatedMeterpoints MeterpointIneligibleReason = "related_meterpoints_present"
+	MeterpointIneligibleReasonComplexSSC         MeterpointIneligibleReason = "complex_SSC"
+	MeterpointIneligibleReasonLargeCapacity      MeterpointIneligibleReason = "large_capacity"
 )
 
+// MeterpointEligible is the eligibility of a meterpoint, with every reason it fails on.
 type MeterpointEligible struct {
 	Eligible bool
-	Reason   MeterpointIneligibleReason
+	Reasons  []MeterpointIneligibleReason
+}
+
+func newMeterpointEligible(reasons []MeterpointIneligibleReason) MeterpointEligible {
+	return MeterpointEligible{
+		Eligible: len(reasons) == 0,
+		Reasons:  reasons,
+	}
 }
 
 type WanCoverageStore interface {
-	GetWanCoverage(ctx context.Context, postcode string) (bool, error)
+	GetWanCoverage(ctx context.Context, postcode models.Postcode) (bool, error)
 }
 
 type AltHanStore interface {
@@ -46,107 +56,142 @@ type XoserveAPI interface {
 	GetMPRNTechnicalDetails(ctx context.Context, mprn string) (*models.GasMeterTechnicalDetails, error)
 }
 
+// MeterpointTimeouts are the deadlines of the lookups made by the meterpoint evaluator
+// for each dependency, a zero timeout means no deadline other than the caller's.
+type MeterpointTimeouts struct {
+	Ecoes   time.Duration
+	Xoserve time.Duration
+	Stores  time.Duration
+}
+
 type MeterpointEvaluator struct {
 	WanCoverageStore
 	AltHanStore
-	ecoesAPI   EcoesAPI
-	xoserveAPI XoserveAPI
+	ecoesAPI       EcoesAPI
+	xoserveAPI     XoserveAPI
+	meterCatalogue MeterCatalogue
+	timeouts       MeterpointTimeouts
 }
 
-func NewMeterpointEvaluator(w WanCoverageStore, a AltHanStore, ecoesAPI EcoesAPI, xoserveAPI XoserveAPI) *MeterpointEvaluator {
+func NewMeterpointEvaluator(w WanCoverageStore, a AltHanStore, ecoesAPI EcoesAPI, xoserveAPI XoserveAPI, meterCatalogue MeterCatalogue, timeouts MeterpointTimeouts) *MeterpointEvaluator {
+	if meterCatalogue == nil {
+		meterCatalogue = defaultMeterCatalogue{}
+	}
 	return &MeterpointEvaluator{
 		WanCoverageStore: w,
 		AltHanStore:      a,
 		ecoesAPI:         ecoesAPI,
 		xoserveAPI:       xoserveAPI,
+		meterCatalogue:   meterCatalogue,
+		timeouts:         timeouts,
 	}
 }
 
-func (e *MeterpointEvaluator) GetElectricityMeterpointEligibility(ctx context.Context, mpan string, postcode string) (MeterpointEligible, error) {
-	// None of the meters at the meters points can be smart (SMETS1 or SMETS2)
-	// to use exactly the same logic as in https://github.com/utilitywarehouse/energy-smart-booking/blob/master/cmd/eligibility/internal/domain/entities.go#L388
+// withTimeout calls fn with a context bound by the dependency timeout.
+func withTimeout(ctx context.Context, timeout time.Duration, fn func(ctx context.Context) error) error {
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	meters, err := e.ecoesAPI.GetMPANTechnicalDetails(ctx, mpan)
-	if err != nil {
-		return MeterpointEligible{}, fmt.Errorf("%w: %w", ErrThirdPartyMeterpointError, err)
+	return fn(ctx)
+}
+
+// GetElectricityMeterpointEligibility evaluates all the electricity criteria and returns every reason the meterpoint fails on.
+// The lookups are made concurrently, each bound by the timeout of its dependency.
+func (e *MeterpointEvaluator) GetElectricityMeterpointEligibility(ctx context.Context, mpan string, postcode models.Postcode) (MeterpointEligible, error) {
+	var (
+		meters                          *models.ElectricityMeterTechnicalDetails
+		isWan, isAltHan, hasRelatedMPAN bool
+	)
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return withTimeout(gCtx, e.timeouts.Ecoes, func(ctx context.Context) (err error) {
+			meters, err = e.ecoesAPI.GetMPANTechnicalDetails(ctx, mpan)
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrThirdPartyMeterpointError, err)
+			}
+			return nil
+		})
+	})
+	g.Go(func() error {
+		return withTimeout(gCtx, e.timeouts.Stores, func(ctx context.Context) (err error) {
+			isWan, err = e.GetWanCoverage(ctx, postcode)
+			return err
+		})
+	})
+	g.Go(func() error {
+		return withTimeout(gCtx, e.timeouts.Stores, func(ctx context.Context) (err error) {
+			isAltHan, err = e.GetAltHan(ctx, mpan)
+			return err
+		})
+	})
+	g.Go(func() error {
+		return withTimeout(gCtx, e.timeouts.Ecoes, func(ctx context.Context) (err error) {
+			hasRelatedMPAN, err = e.ecoesAPI.HasRelatedMPAN(ctx, mpan)
+			return err
+		})
+	})
+	if err := g.Wait(); err != nil {
+		return MeterpointEligible{}, err
 	}
 
+	var reasons []MeterpointIneligibleReason
+
+	// None of the meters at the meters points can be smart (SMETS1 or SMETS2)
+	// to use exactly the same logic as in https://github.com/utilitywarehouse/energy-smart-booking/blob/master/cmd/eligibility/internal/domain/entities.go#L388
 	for _, meter := range meters.Meters {
 		if domain.IsElectricitySmartMeter(meter.MeterType.String()) {
-			return MeterpointEligible{
-				Eligible: false,
-				Reason:   alreadySmartMeterpointIneligibleReason,
-			}, nil
+			reasons = append(reasons, MeterpointIneligibleReasonAlreadySmart)
+			break
 		}
 	}
 
 	// Property must have WAN
-	isWan, err := e.GetWanCoverage(ctx, postcode)
-	if err != nil {
-		return MeterpointEligible{}, err
-	}
 	if !isWan {
-		return MeterpointEligible{
-			Eligible: false,
-			Reason:   notWanMeterpointIneligibleReason,
-		}, nil
+		reasons = append(reasons, MeterpointIneligibleReasonNoWan)
 	}
 
 	// Property must not require ALT-HAN
-	isAltHan, err := e.GetAltHan(ctx, mpan)
-	if err != nil {
-		return MeterpointEligible{}, err
-	}
 	if isAltHan {
-		return MeterpointEligible{
-			Eligible: false,
-			Reason:   isAltHanMeterpointIneligibleReason,
-		}, nil
+		reasons = append(reasons, MeterpointIneligibleReasonAltHan)
 	}
 
 	// Electricity must not have a related MPAN Set-up
 	// We should not receive a related MPAN from a GetRelatedMPANs call
-	HasRelatedMPAN, err := e.ecoesAPI.HasRelatedMPAN(ctx, mpan)
-	if err != nil {
-		return MeterpointEligible{}, err
-	}
-	if HasRelatedMPAN {
-		return MeterpointEligible{
-			Eligible: false,
-			Reason:   hasRelatedMeterpointIneligibleReason,
-		}, nil
+	if hasRelatedMPAN {
+		reasons = append(reasons, MeterpointIneligibleReasonRelatedMeterpoints)
 	}
 
 	// Electricity must not have “complex tariff”
 	// Similar to the current logic in the normal eligibilty check for "complex tariff"
-	if domain.HasComplexSSC(meters) {
-		return MeterpointEligible{
-			Eligible: false,
-			Reason:   hasComplexSSCMeterpointIneligibleReason,
-		}, nil
+	if e.meterCatalogue.HasComplexSSC(meters) {
+		reasons = append(reasons, MeterpointIneligibleReasonComplexSSC)
 	}
 
-	return MeterpointEligible{
-		Eligible: true,
-	}, nil
+	return newMeterpointEligible(reasons), nil
 }
 
+// GetGasMeterpointEligibility evaluates all the gas criteria and returns every reason the meterpoint fails on.
 func (e *MeterpointEvaluator) GetGasMeterpointEligibility(ctx context.Context, mprn string) (MeterpointEligible, error) {
-	meters, err := e.xoserveAPI.GetMPRNTechnicalDetails(ctx, mprn)
+	var meters *models.GasMeterTechnicalDetails
+	err := withTimeout(ctx, e.timeouts.Xoserve, func(ctx context.Context) (err error) {
+		meters, err = e.xoserveAPI.GetMPRNTechnicalDetails(ctx, mprn)
+		return err
+	})
 	if err != nil {
 		return MeterpointEligible{}, fmt.Errorf("%w: %w", ErrThirdPartyMeterpointError, err)
 	}
 
+	var reasons []MeterpointIneligibleReason
+
 	// Gas meter at property must not be “large capacity”
 	// Large Capacity means the meter's capacity is different than 6 or 212
-	if domain.IsLargeCapacity(meters) {
-		return MeterpointEligible{
-			Eligible: false,
-			Reason:   largeCapacityMeterpointIneligibleReason,
-		}, nil
+	if e.meterCatalogue.IsLargeCapacity(meters) {
+		reasons = append(reasons, MeterpointIneligibleReasonLargeCapacity)
 	}
 
-	return MeterpointEligible{
-		Eligible: true,
-	}, nil
+	return newMeterpointEligible(reasons), nil
 }
@@ -2,7 +2,9 @@ package evaluation
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"testing"
 	"time"
 
@@ -10,9 +12,9 @@ import (
 	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
 )
 
-type mockWanStore struct{ store map[string]bool }
+type mockWanStore struct{ store map[models.Postcode]bool }
 
-func (s *mockWanStore) GetWanCoverage(_ context.Context, postcode string) (bool, error) {
+func (s *mockWanStore) GetWanCoverage(_ context.Context, postcode models.Postcode) (bool, error) {
 	wan := s.store[postcode]
 	return wan, nil
 }
@@ -58,7 +60,7 @@ func (x *mockXoserveAPI) GetMPRNTechnicalDetails(_ context.Context, mprn string)
 }
 
 type meterpointEligibilityMocks struct {
-	wanStore                         map[string]bool
+	wanStore                         map[models.Postcode]bool
 	altHanStore                      map[string]bool
 	ecoesTechnicalDetailsResponses   map[string]*models.ElectricityMeterTechnicalDetails
 	ecoesRelatedMPANResponses        map[string]*models.ElectricityMeterRelatedMPAN
@@ -67,11 +69,11 @@ type meterpointEligibilityMocks struct {
 
 type electricityMeterpointEligibilityTestCases struct {
 	mpan     string
-	postcode string
+	postcode models.Postcode
 
 	description         string
 	expectedEligibility bool
-	expectedReason      MeterpointIneligibleReason
+	expectedReasons     []MeterpointIneligibleReason
 }
 
 func mustTime(date string) time.Time {
@@ -84,7 +86,7 @@ func mustTime(date string) time.Time {
 
 func TestGetElectricityMeterpointEligibility(t *testing.T) {
 	electricityMeterpointTestMocks := meterpointEligibilityMocks{
-		wanStore: map[string]bool{
+		wanStore: map[models.Postcode]bool{
 			"post-code-1": true,
 			"post-code-2": true,
 			"post-code-4": true,
@@ -168,35 +170,42 @@ func TestGetElectricityMeterpointEligibility(t *testing.T) {
 			postcode:            "post-code-2",
 			description:         "ineligible because alt-HAN",
 			expectedEligibility: false,
-			expectedReason:      "Alt_HAN",
+			expectedReasons:     []MeterpointIneligibleReason{"Alt_HAN"},
 		},
 		{
 			mpan:                "mpan-3",
 			postcode:            "post-code-3",
 			description:         "ineligible because no WAN",
 			expectedEligibility: false,
-			expectedReason:      "not_WAN",
+			expectedReasons:     []MeterpointIneligibleReason{"not_WAN"},
 		},
 		{
 			mpan:                "mpan-4",
 			postcode:            "post-code-4",
 			description:         "ineligible because has related MPAN",
 			expectedEligibility: false,
-			expectedReason:      "related_meterpoints_present",
+			expectedReasons:     []MeterpointIneligibleReason{"related_meterpoints_present"},
 		},
 		{
 			mpan:                "mpan-5",
 			postcode:            "post-code-5",
 			description:         "ineligible because complex SSC",
 			expectedEligibility: false,
-			expectedReason:      "complex_SSC",
+			expectedReasons:     []MeterpointIneligibleReason{"complex_SSC"},
 		},
 		{
 			mpan:                "mpan-6",
 			postcode:            "post-code-6",
 			description:         "ineligible because already smart meter",
 			expectedEligibility: false,
-			expectedReason:      "already_a_smart_meter",
+			expectedReasons:     []MeterpointIneligibleReason{"already_a_smart_meter"},
+		},
+		{
+			mpan:                "mpan-2",
+			postcode:            "post-code-3",
+			description:         "ineligible because no WAN and alt-HAN",
+			expectedEligibility: false,
+			expectedReasons:     []MeterpointIneligibleReason{"not_WAN", "Alt_HAN"},
 		},
 	}
 
@@ -211,6 +220,8 @@ func TestGetElectricityMeterpointEligibility(t *testing.T) {
 				}, &mockXoserveAPI{
 					technicalDetailResponses: electricityMeterpointTestMocks.xoserveTechnicalDetailsResponses,
 				},
+				nil,
+				MeterpointTimeouts{},
 			)
 
 			actualEligibility, err := evaluator.GetElectricityMeterpointEligibility(context.Background(), tc.mpan, tc.postcode)
@@ -220,9 +231,86 @@ func TestGetElectricityMeterpointEligibility(t *testing.T) {
 			if tc.expectedEligibility != actualEligibility.Eligible {
 				t.Fatalf("unexpected eligibility result for %s (expected %t, got %t)", tc.description, tc.expectedEligibility, actualEligibility.Eligible)
 			}
-			if tc.expectedReason != actualEligibility.Reason {
-				t.Fatalf("unexpected eligibility faiure reason for %s (expected %q, got %q)", tc.description, tc.expectedReason, actualEligibility.Reason)
+			if !slices.Equal(tc.expectedReasons, actualEligibility.Reasons) {
+				t.Fatalf("unexpected eligibility faiure reasons for %s (expected %q, got %q)", tc.description, tc.expectedReasons, actualEligibility.Reasons)
 			}
 		})
 	}
 }
+
+func TestGetGasMeterpointEligibility(t *testing.T) {
+	evaluator := NewMeterpointEvaluator(
+		&mockWanStore{},
+		&mockAltHanStore{},
+		&mockEcoesAPI{},
+		&mockXoserveAPI{
+			technicalDetailResponses: map[string]*models.GasMeterTechnicalDetails{
+				"mprn-1": {Capacity: 6},
+				"mprn-2": {Capacity: 100},
+			},
+		},
+		nil,
+		MeterpointTimeouts{},
+	)
+
+	testCases := []struct {
+		mprn                string
+		description         string
+		expectedEligibility bool
+		expectedReasons     []MeterpointIneligibleReason
+	}{
+		{
+			mprn:                "mprn-1",
+			description:         "standard eligible test case",
+			expectedEligibility: true,
+		},
+		{
+			mprn:                "mprn-2",
+			description:         "ineligible because large capacity",
+			expectedEligibility: false,
+			expectedReasons:     []MeterpointIneligibleReason{"large_capacity"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			actualEligibility, err := evaluator.GetGasMeterpointEligibility(context.Background(), tc.mprn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.expectedEligibility != actualEligibility.Eligible {
+				t.Fatalf("unexpected eligibility result for %s (expected %t, got %t)", tc.description, tc.expectedEligibility, actualEligibility.Eligible)
+			}
+			if !slices.Equal(tc.expectedReasons, actualEligibility.Reasons) {
+				t.Fatalf("unexpected eligibility faiure reasons for %s (expected %q, got %q)", tc.description, tc.expectedReasons, actualEligibility.Reasons)
+			}
+		})
+	}
+}
+
+type slowEcoesAPI struct{}
+
+func (e *slowEcoesAPI) GetMPANTechnicalDetails(ctx context.Context, _ string) (*models.ElectricityMeterTechnicalDetails, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (e *slowEcoesAPI) HasRelatedMPAN(_ context.Context, _ string) (bool, error) {
+	return false, nil
+}
+
+func TestGetElectricityMeterpointEligibilityTimeout(t *testing.T) {
+	evaluator := NewMeterpointEvaluator(
+		&mockWanStore{},
+		&mockAltHanStore{},
+		&slowEcoesAPI{},
+		&mockXoserveAPI{},
+		nil,
+		MeterpointTimeouts{Ecoes: 10 * time.Millisecond},
+	)
+
+	_, err := evaluator.GetElectricityMeterpointEligibility(context.Background(), "mpan-1", "post-code-1")
+	if !errors.Is(err, ErrThirdPartyMeterpointError) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the ECOES lookup to time out, got %v", err)
+	}
+}
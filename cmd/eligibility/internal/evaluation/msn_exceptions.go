@@ -0,0 +1,43 @@
+package evaluation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+type OccupancyMSNStore interface {
+	GetIDsByMSN(ctx context.Context, msn string) ([]string, error)
+}
+
+// ReevaluateMeterSerialNumbers runs a full evaluation of the live occupancies with meters added to or removed
+// from the MSN exception list. Every occupancy is evaluated at most once, failures don't stop the remaining
+// evaluations and are returned together.
+func (e *Evaluator) ReevaluateMeterSerialNumbers(ctx context.Context, occupancyStore OccupancyMSNStore, msns []string) error {
+	var errs []error
+	evaluated := map[string]struct{}{}
+
+	for _, msn := range msns {
+		occupancyIDs, err := occupancyStore.GetIDsByMSN(ctx, msn)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get occupancies for meter serial number %s: %w", msn, err))
+			continue
+		}
+
+		for _, occupancyID := range occupancyIDs {
+			if _, ok := evaluated[occupancyID]; ok {
+				continue
+			}
+			evaluated[occupancyID] = struct{}{}
+
+			if err := e.RunFull(ctx, occupancyID); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	slog.Info("re-evaluated occupancies for meter serial number exceptions change", "msns", len(msns), "occupancies", len(evaluated), "failed", len(errs))
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,76 @@
+package evaluation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utilitywarehouse/energy-contracts/pkg/generated/platform"
+	smart "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart/v1"
+	energy_domain "github.com/utilitywarehouse/energy-pkg/domain"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/domain"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/store"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/testcommon"
+)
+
+type mockOccupancyMSNStore map[string][]string
+
+func (s mockOccupancyMSNStore) GetIDsByMSN(_ context.Context, msn string) ([]string, error) {
+	return s[msn], nil
+}
+
+func TestReevaluateMeterSerialNumbers(t *testing.T) {
+	ctx := context.Background()
+
+	mStore := &mockStore{
+		occupancies: map[string]domain.Occupancy{
+			"occupancy-id": {
+				ID:      "occupancy-id",
+				Account: domain.Account{ID: "account-id"},
+				Site:    &domain.Site{ID: "site-id", Postcode: "AP 24X", WanCoverage: true},
+				EvaluationResult: domain.OccupancyEvaluation{
+					OccupancyID:          "occupancy-id",
+					EligibilityEvaluated: true,
+					Eligibility:          domain.IneligibleReasons{domain.IneligibleReasonAlreadySmart},
+				},
+			},
+		},
+		servicesByOccupancy: map[string][]domain.Service{
+			"occupancy-id": {
+				{
+					ID:         "service-id",
+					Mpxn:       "mpan",
+					SupplyType: energy_domain.SupplyTypeElectricity,
+					Meterpoint: &domain.Meterpoint{
+						Mpxn:         "mpan",
+						ProfileClass: platform.ProfileClass_PROFILE_CLASS_01,
+					},
+				},
+			},
+		},
+		meters: map[string]domain.Meter{
+			"mpan": {
+				ID:         "meter-id",
+				Mpxn:       "mpan",
+				MSN:        exceptionMSN,
+				SupplyType: energy_domain.SupplyTypeElectricity,
+				MeterType:  platform.MeterTypeElec_METER_TYPE_ELEC_S2A.String(),
+			},
+		},
+	}
+	msnStore := mockOccupancyMSNStore{
+		exceptionMSN:  {"occupancy-id"},
+		"other-msn":   {"occupancy-id"},
+		"missing-msn": {"missing-occupancy-id"},
+	}
+
+	eSink, sSink, cSink, bSink := &testcommon.MockSink{}, &testcommon.MockSink{}, &testcommon.MockSink{}, &testcommon.MockSink{}
+	evaluator := NewEvaluator(mStore, mStore, mStore, eSink, sSink, cSink, bSink, &mockMeterSerialNumberStore{}, &mockPSRCodeStore{}, nil, nil)
+
+	err := evaluator.ReevaluateMeterSerialNumbers(ctx, msnStore, []string{exceptionMSN, "other-msn", "missing-msn"})
+	assert.ErrorIs(t, err, store.ErrOccupancyNotFound)
+
+	// the occupancy is evaluated once and becomes eligible as its smart meter is in the exception list
+	assert.Equal(t, 1, len(eSink.Msgs))
+	assert.Equal(t, &smart.EligibleOccupancyAddedEvent{OccupancyId: "occupancy-id", AccountId: "account-id"}, eSink.Msgs[0])
+}
@@ -0,0 +1,85 @@
+package evaluation
+
+import (
+	bookingv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/booking/v1"
+	energy_domain "github.com/utilitywarehouse/energy-pkg/domain"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/domain"
+)
+
+// abortedVisitsThreshold is the number of aborted visits after which no more
+// bookings are sought for the occupancy.
+const abortedVisitsThreshold = 2
+
+// occupancyPredicate is evaluated once against the whole occupancy.
+type occupancyPredicate func(e *Evaluator, o *domain.Occupancy) bool
+
+// servicePredicate is evaluated against each live service of the occupancy,
+// the rule matches if any of the services satisfies it.
+type servicePredicate func(e *Evaluator, s domain.Service) bool
+
+var occupancyPredicates = map[string]occupancyPredicate{
+	"site_missing": func(_ *Evaluator, o *domain.Occupancy) bool {
+		return o.Site == nil
+	},
+	"no_wan_coverage": func(_ *Evaluator, o *domain.Occupancy) bool {
+		return o.Site != nil && !o.Site.WanCoverage
+	},
+	"no_active_service": func(_ *Evaluator, o *domain.Occupancy) bool {
+		return len(o.Services) == 0
+	},
+	"account_opt_out": func(_ *Evaluator, o *domain.Occupancy) bool {
+		return o.Account.OptOut
+	},
+	"psr_specialist_visit": func(e *Evaluator, o *domain.Occupancy) bool {
+		return len(o.Account.PSRCodes) > 0 && e.psrCodeStore.RequiresSpecialistVisit(o.Account.PSRCodes)
+	},
+	"booking_scheduled": func(_ *Evaluator, o *domain.Occupancy) bool {
+		return hasBookingWithStatus(o, bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED)
+	},
+	"booking_completed": func(_ *Evaluator, o *domain.Occupancy) bool {
+		return hasBookingWithStatus(o, bookingv1.BookingStatus_BOOKING_STATUS_COMPLETED)
+	},
+	"repeated_aborted_visits": func(_ *Evaluator, o *domain.Occupancy) bool {
+		abortedVisits := 0
+		for _, b := range o.Bookings {
+			abortedVisits += b.AbortedVisits
+		}
+		return abortedVisits >= abortedVisitsThreshold
+	},
+}
+
+var servicePredicates = map[string]servicePredicate{
+	"meterpoint_missing": func(_ *Evaluator, s domain.Service) bool {
+		return s.Meterpoint == nil
+	},
+	"meterpoint_alt_han": func(_ *Evaluator, s domain.Service) bool {
+		return s.Meterpoint != nil && s.Meterpoint.AltHan
+	},
+	"complex_tariff": func(e *Evaluator, s domain.Service) bool {
+		return s.Meterpoint != nil && e.catalogue().HasComplexSSC(s.Meterpoint)
+	},
+	"meter_missing": func(_ *Evaluator, s domain.Service) bool {
+		return s.Meter == nil
+	},
+	"meter_large_capacity": func(e *Evaluator, s domain.Service) bool {
+		return s.Meter != nil && s.Meter.Capacity != nil && e.catalogue().IsLargeCapacity(s.Meter)
+	},
+	// meters in the MSN exception list are smart but still need to be booked
+	"meter_already_smart": func(e *Evaluator, s domain.Service) bool {
+		return s.Meter != nil && s.Meter.IsSmart() && !e.meterSerialNumberStore.FindMeterSerialNumber(s.Meter.MSN)
+	},
+}
+
+func hasBookingWithStatus(o *domain.Occupancy, status bookingv1.BookingStatus) bool {
+	for _, b := range o.Bookings {
+		if b.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
+var supplyTypes = map[string]energy_domain.SupplyType{
+	"electricity": energy_domain.SupplyTypeElectricity,
+	"gas":         energy_domain.SupplyTypeGas,
+}
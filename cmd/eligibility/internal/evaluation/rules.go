@@ -1,7 +1,6 @@
 package evaluation
 
 import (
-	energy_domain "github.com/utilitywarehouse/energy-pkg/domain"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/domain"
 )
 
@@ -24,82 +23,41 @@ func (e evaluation) status() domain.IneligibleReasons {
 	return reasons
 }
 
-func evaluateSuppliability(o *domain.Occupancy) domain.IneligibleReasons {
-	result := evaluation{reason: make(map[domain.IneligibleReason]struct{}, 0)}
-
-	if o.Site == nil {
-		result.addReason(domain.IneligibleReasonMissingSiteData)
-	} else if !o.Site.WanCoverage {
-		result.addReason(domain.IneligibleReasonNoWanCoverage)
+// rules returns the configured rule set, falling back to the default one.
+func (e *Evaluator) rules() *RuleSet {
+	if e.ruleSet == nil {
+		return DefaultRuleSet()
 	}
+	return e.ruleSet
+}
 
-	if len(o.Services) == 0 {
-		result.addReason(domain.IneligibleReasonNoActiveService)
-	}
-
-	for _, s := range o.Services {
-		// we only project meterpoints for electricity ssc or profile class changes or if a meterpoint is alt han
-		// if there is no entry, it means the meterpoint is not alt han
-		// profile class and ssc are evaluated in eligibility criteria
-		if s.Meterpoint != nil && s.Meterpoint.AltHan {
-			result.addReason(domain.IneligibleReasonAltHan)
-		}
-
-		// we need to evaluate the meter capacity only if it's a gas service
-		if s.SupplyType == energy_domain.SupplyTypeGas {
-			if s.Meter == nil {
-				result.addReason(domain.IneligibleReasonMissingMeterData)
-			} else if s.Meter.Capacity != nil && (domain.IsLargeCapacity(s.Meter)) {
-				result.addReason(domain.IneligibleReasonMeterLargeCapacity)
-			}
-		}
+// catalogue returns the configured meter catalogue, falling back to the default one.
+func (e *Evaluator) catalogue() MeterCatalogue {
+	if e.meterCatalogue == nil {
+		return defaultMeterCatalogue{}
 	}
+	return e.meterCatalogue
+}
 
-	return result.status()
+func (e *Evaluator) evaluateSuppliability(o *domain.Occupancy) domain.IneligibleReasons {
+	return e.evaluate(e.rules().Suppliability, o)
 }
 
 func (e *Evaluator) evaluateEligibility(o *domain.Occupancy) domain.IneligibleReasons {
-	result := evaluation{reason: make(map[domain.IneligibleReason]struct{}, 0)}
-
-	if o.Site == nil {
-		result.addReason(domain.IneligibleReasonMissingSiteData)
-	} else if !o.Site.WanCoverage {
-		result.addReason(domain.IneligibleReasonNoWanCoverage)
-	}
-
-	if len(o.Services) == 0 {
-		result.addReason(domain.IneligibleReasonNoActiveService)
-	}
-
-	for _, s := range o.Services {
-		// complex tariff needs to be evaluated for electricity service only
-		if s.SupplyType == energy_domain.SupplyTypeElectricity {
-			if s.Meterpoint == nil {
-				result.addReason(domain.IneligibleReasonMissingMeterpointData)
-			} else if domain.HasComplexSSC(s.Meterpoint) {
-				result.addReason(domain.IneligibleReasonComplexTariff)
-			}
-		}
-
-		if s.Meter == nil {
-			result.addReason(domain.IneligibleReasonMissingMeterData)
-		} else if s.Meter.IsSmart() && !e.meterSerialNumberStore.FindMeterSerialNumber(s.Meter.MSN) {
-			result.addReason(domain.IneligibleReasonAlreadySmart)
-		}
-	}
+	return e.evaluate(e.rules().Eligibility, o)
+}
 
-	return result.status()
+func (e *Evaluator) evaluateCampaignability(o *domain.Occupancy) domain.IneligibleReasons {
+	return e.evaluate(e.rules().Campaignability, o)
 }
 
-func evaluateCampaignability(o *domain.Occupancy) domain.IneligibleReasons {
+func (e *Evaluator) evaluate(rules []Rule, o *domain.Occupancy) domain.IneligibleReasons {
 	result := evaluation{reason: make(map[domain.IneligibleReason]struct{}, 0)}
 
-	if o.Account.OptOut {
-		result.addReason(domain.IneligibleReasonBookingOptOut)
-	}
-
-	if len(o.Services) == 0 {
-		result.addReason(domain.IneligibleReasonNoActiveService)
+	for _, r := range rules {
+		if r.matches(e, o) {
+			result.addReason(r.Reason)
+		}
 	}
 
 	return result.status()
@@ -0,0 +1,204 @@
+package evaluation
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	energy_domain "github.com/utilitywarehouse/energy-pkg/domain"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+var ErrInvalidRuleSet = errors.New("invalid rule set")
+
+//go:embed default_rules.yaml
+var defaultRules []byte
+
+var defaultRuleSet = mustParseRuleSet(defaultRules, formatYAML)
+
+type format int
+
+const (
+	formatYAML format = iota
+	formatJSON
+)
+
+// RuleSet is a versioned set of rules evaluated against an occupancy to produce
+// its campaignability, eligibility and suppliability ineligible reasons.
+type RuleSet struct {
+	Version         string
+	Campaignability []Rule
+	Eligibility     []Rule
+	Suppliability   []Rule
+}
+
+// Rule adds Reason to the evaluation result when its predicate holds.
+type Rule struct {
+	Name       string
+	Predicate  string
+	SupplyType string
+	Reason     domain.IneligibleReason
+
+	occupancyPredicate occupancyPredicate
+	servicePredicate   servicePredicate
+	supplyType         energy_domain.SupplyType
+}
+
+type ruleSetConfig struct {
+	Version         string       `yaml:"version" json:"version"`
+	Campaignability []ruleConfig `yaml:"campaignability" json:"campaignability"`
+	Eligibility     []ruleConfig `yaml:"eligibility" json:"eligibility"`
+	Suppliability   []ruleConfig `yaml:"suppliability" json:"suppliability"`
+}
+
+type ruleConfig struct {
+	Name       string `yaml:"name" json:"name"`
+	Predicate  string `yaml:"predicate" json:"predicate"`
+	SupplyType string `yaml:"supply_type" json:"supply_type"`
+	Reason     string `yaml:"reason" json:"reason"`
+}
+
+// DefaultRuleSet returns the rule set shipped with the service.
+func DefaultRuleSet() *RuleSet {
+	return defaultRuleSet
+}
+
+// LoadRuleSet reads and validates the rule set in the provided file, the format
+// is picked from the file extension, defaulting to YAML.
+func LoadRuleSet(path string) (*RuleSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open rule set file, %w", err)
+	}
+
+	f := formatYAML
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		f = formatJSON
+	}
+
+	return parseRuleSet(data, f)
+}
+
+func (r Rule) matches(e *Evaluator, o *domain.Occupancy) bool {
+	if r.occupancyPredicate != nil {
+		return r.occupancyPredicate(e, o)
+	}
+
+	for _, s := range o.Services {
+		if r.SupplyType != "" && s.SupplyType != r.supplyType {
+			continue
+		}
+		if r.servicePredicate(e, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func mustParseRuleSet(data []byte, f format) *RuleSet {
+	ruleSet, err := parseRuleSet(data, f)
+	if err != nil {
+		panic(err)
+	}
+	return ruleSet
+}
+
+func parseRuleSet(data []byte, f format) (*RuleSet, error) {
+	var cfg ruleSetConfig
+
+	switch f {
+	case formatJSON:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidRuleSet, err)
+		}
+	default:
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidRuleSet, err)
+		}
+	}
+
+	if cfg.Version == "" {
+		return nil, fmt.Errorf("%w: missing version", ErrInvalidRuleSet)
+	}
+
+	campaignability, err := compileRules("campaignability", cfg.Campaignability)
+	if err != nil {
+		return nil, err
+	}
+	eligibility, err := compileRules("eligibility", cfg.Eligibility)
+	if err != nil {
+		return nil, err
+	}
+	suppliability, err := compileRules("suppliability", cfg.Suppliability)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RuleSet{
+		Version:         cfg.Version,
+		Campaignability: campaignability,
+		Eligibility:     eligibility,
+		Suppliability:   suppliability,
+	}, nil
+}
+
+func compileRules(section string, cfgs []ruleConfig) ([]Rule, error) {
+	rules := make([]Rule, 0, len(cfgs))
+	names := make(map[string]struct{}, len(cfgs))
+
+	for i, cfg := range cfgs {
+		if cfg.Name == "" {
+			return nil, fmt.Errorf("%w: %s rule %d has no name", ErrInvalidRuleSet, section, i)
+		}
+		if _, ok := names[cfg.Name]; ok {
+			return nil, fmt.Errorf("%w: %s rule %s is duplicated", ErrInvalidRuleSet, section, cfg.Name)
+		}
+		names[cfg.Name] = struct{}{}
+
+		reason, err := domain.ParseIneligibleReason(cfg.Reason)
+		if err != nil || reason == domain.IneligibleReasonUnknown {
+			return nil, fmt.Errorf("%w: %s rule %s has unknown reason %q", ErrInvalidRuleSet, section, cfg.Name, cfg.Reason)
+		}
+
+		rule := Rule{
+			Name:       cfg.Name,
+			Predicate:  cfg.Predicate,
+			SupplyType: cfg.SupplyType,
+			Reason:     reason,
+		}
+
+		if p, ok := occupancyPredicates[cfg.Predicate]; ok {
+			if cfg.SupplyType != "" {
+				return nil, fmt.Errorf("%w: %s rule %s sets supply type on occupancy predicate %s", ErrInvalidRuleSet, section, cfg.Name, cfg.Predicate)
+			}
+			rule.occupancyPredicate = p
+		} else if p, ok := servicePredicates[cfg.Predicate]; ok {
+			rule.servicePredicate = p
+		} else {
+			return nil, fmt.Errorf("%w: %s rule %s has unknown predicate %q", ErrInvalidRuleSet, section, cfg.Name, cfg.Predicate)
+		}
+
+		if cfg.SupplyType != "" {
+			supplyType, ok := supplyTypes[cfg.SupplyType]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s rule %s has unknown supply type %q", ErrInvalidRuleSet, section, cfg.Name, cfg.SupplyType)
+			}
+			rule.supplyType = supplyType
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
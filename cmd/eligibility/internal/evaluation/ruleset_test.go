@@ -0,0 +1,195 @@
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utilitywarehouse/energy-contracts/pkg/generated/platform"
+	energy_domain "github.com/utilitywarehouse/energy-pkg/domain"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/domain"
+)
+
+func TestDefaultRuleSet(t *testing.T) {
+	ruleSet := DefaultRuleSet()
+
+	require.NotNil(t, ruleSet)
+	assert.NotEmpty(t, ruleSet.Version)
+	assert.Len(t, ruleSet.Campaignability, 5)
+	assert.Len(t, ruleSet.Eligibility, 8)
+	assert.Len(t, ruleSet.Suppliability, 6)
+}
+
+func TestLoadRuleSet(t *testing.T) {
+	expected := func(ruleSet *RuleSet) {
+		assert.Equal(t, "test", ruleSet.Version)
+
+		require.Len(t, ruleSet.Campaignability, 1)
+		assert.Equal(t, "opt-out", ruleSet.Campaignability[0].Name)
+		assert.Equal(t, domain.IneligibleReasonBookingOptOut, ruleSet.Campaignability[0].Reason)
+
+		require.Len(t, ruleSet.Eligibility, 1)
+		assert.Equal(t, "complex_tariff", ruleSet.Eligibility[0].Predicate)
+		assert.Equal(t, "electricity", ruleSet.Eligibility[0].SupplyType)
+		assert.Equal(t, domain.IneligibleReasonComplexTariff, ruleSet.Eligibility[0].Reason)
+
+		require.Len(t, ruleSet.Suppliability, 1)
+		assert.Equal(t, domain.IneligibleReasonMissingMeterData, ruleSet.Suppliability[0].Reason)
+	}
+
+	ruleSet, err := LoadRuleSet("./testdata/rules.yaml")
+	require.NoError(t, err)
+	expected(ruleSet)
+
+	ruleSet, err = LoadRuleSet("./testdata/rules.json")
+	require.NoError(t, err)
+	expected(ruleSet)
+
+	_, err = LoadRuleSet("./testdata/unknown_field.json")
+	assert.ErrorIs(t, err, ErrInvalidRuleSet)
+
+	_, err = LoadRuleSet("./testdata/missing.yaml")
+	assert.Error(t, err)
+}
+
+func TestParseRuleSetInvalid(t *testing.T) {
+	testCases := []struct {
+		description string
+		input       string
+	}{
+		{
+			description: "missing version",
+			input: `
+eligibility:
+  - name: meter-missing
+    predicate: meter_missing
+    reason: MissingMeterData
+`,
+		},
+		{
+			description: "unknown field",
+			input: `
+version: "test"
+eligibility:
+  - name: meter-missing
+    predicate: meter_missing
+    reason: MissingMeterData
+    enabled: true
+`,
+		},
+		{
+			description: "unknown section",
+			input: `
+version: "test"
+bookability:
+  - name: meter-missing
+    predicate: meter_missing
+    reason: MissingMeterData
+`,
+		},
+		{
+			description: "unknown reason",
+			input: `
+version: "test"
+eligibility:
+  - name: meter-missing
+    predicate: meter_missing
+    reason: MeterMissing
+`,
+		},
+		{
+			description: "unknown predicate",
+			input: `
+version: "test"
+eligibility:
+  - name: meter-missing
+    predicate: no_meter
+    reason: MissingMeterData
+`,
+		},
+		{
+			description: "unknown supply type",
+			input: `
+version: "test"
+eligibility:
+  - name: meter-missing
+    predicate: meter_missing
+    supply_type: water
+    reason: MissingMeterData
+`,
+		},
+		{
+			description: "supply type on occupancy predicate",
+			input: `
+version: "test"
+eligibility:
+  - name: site-missing
+    predicate: site_missing
+    supply_type: gas
+    reason: MissingSiteData
+`,
+		},
+		{
+			description: "duplicated rule name",
+			input: `
+version: "test"
+eligibility:
+  - name: meter-missing
+    predicate: meter_missing
+    reason: MissingMeterData
+  - name: meter-missing
+    predicate: meter_missing
+    supply_type: gas
+    reason: MissingMeterData
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			_, err := parseRuleSet([]byte(tc.input), formatYAML)
+			assert.ErrorIs(t, err, ErrInvalidRuleSet)
+		})
+	}
+}
+
+func TestEvaluateWithRuleSet(t *testing.T) {
+	ruleSet, err := LoadRuleSet("./testdata/rules.yaml")
+	require.NoError(t, err)
+
+	evaluator := Evaluator{ruleSet: ruleSet}
+
+	occupancy := &domain.Occupancy{
+		ID: "occupancy-id",
+		Account: domain.Account{
+			ID:     "account-id",
+			OptOut: true,
+		},
+		Services: []domain.Service{
+			{
+				ID:         "service-id-1",
+				Mpxn:       "mpxn-1",
+				SupplyType: energy_domain.SupplyTypeElectricity,
+				Meterpoint: &domain.Meterpoint{
+					Mpxn:         "mpxn-1",
+					AltHan:       true,
+					ProfileClass: platform.ProfileClass_PROFILE_CLASS_02,
+					SSC:          "0003",
+				},
+			},
+			{
+				ID:         "service-id-2",
+				Mpxn:       "mpxn-2",
+				SupplyType: energy_domain.SupplyTypeGas,
+			},
+		},
+	}
+
+	// rules not in the rule set, such as alt han or missing site data, are not evaluated
+	assert.Equal(t, domain.IneligibleReasons{domain.IneligibleReasonBookingOptOut}, evaluator.evaluateCampaignability(occupancy))
+	assert.Equal(t, domain.IneligibleReasons{domain.IneligibleReasonComplexTariff}, evaluator.evaluateEligibility(occupancy))
+	assert.Equal(t, domain.IneligibleReasons{domain.IneligibleReasonMissingMeterData}, evaluator.evaluateSuppliability(occupancy))
+
+	occupancy.Services = occupancy.Services[:1]
+	assert.Nil(t, evaluator.evaluateSuppliability(occupancy))
+}
@@ -0,0 +1,253 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/store"
+	"golang.org/x/time/rate"
+)
+
+// ErrJobNotRunning is returned when cancelling a job which already finished.
+var ErrJobNotRunning = errors.New("evaluation job is not running")
+
+const progressInterval = 5 * time.Second
+
+// staleJobTimeout is how long a running job can go without a progress update before it's considered
+// interrupted, running jobs update their progress every progressInterval.
+const staleJobTimeout = time.Minute
+
+type JobStore interface {
+	Create(ctx context.Context, id, selector string, total int) error
+	UpdateProgress(ctx context.Context, id string, processed, failed int) error
+	AddError(ctx context.Context, id, occupancyID, evaluationErr string) error
+	Finish(ctx context.Context, id string, status store.EvaluationJobStatus) (bool, error)
+	Get(ctx context.Context, id string) (store.EvaluationJob, error)
+	FailStale(ctx context.Context, staleAfter time.Duration) ([]string, error)
+}
+
+type Evaluator interface {
+	RunFull(ctx context.Context, occupancyID string) error
+}
+
+// Runner runs evaluation jobs in the background, persisting their progress so it can be queried
+// while they run and after they finish.
+type Runner struct {
+	ctx       context.Context
+	store     JobStore
+	evaluator Evaluator
+	workers   int
+	rateLimit rate.Limit
+
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewRunner creates a job runner evaluating occupancies with the given number of workers.
+// The rate limit is the maximum number of evaluations per second of each job, 0 means no limit.
+// The jobs are stopped and marked as failed when the context is cancelled.
+func NewRunner(ctx context.Context, jobStore JobStore, evaluator Evaluator, workers int, rateLimit float64) *Runner {
+	limit := rate.Inf
+	if rateLimit > 0 {
+		limit = rate.Limit(rateLimit)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	return &Runner{
+		ctx:       ctx,
+		store:     jobStore,
+		evaluator: evaluator,
+		workers:   workers,
+		rateLimit: limit,
+		cancels:   map[string]context.CancelFunc{},
+	}
+}
+
+// Start creates a job evaluating the given occupancies and runs it in the background.
+// The selector describes what the occupancies were selected by, e.g. "account:<id>".
+func (r *Runner) Start(ctx context.Context, selector string, occupancyIDs []string) (store.EvaluationJob, error) {
+	if err := r.ctx.Err(); err != nil {
+		return store.EvaluationJob{}, fmt.Errorf("evaluation job runner stopped: %w", err)
+	}
+
+	id := uuid.New().String()
+
+	if err := r.store.Create(ctx, id, selector, len(occupancyIDs)); err != nil {
+		return store.EvaluationJob{}, fmt.Errorf("failed to create evaluation job: %w", err)
+	}
+
+	jobCtx, cancel := context.WithCancel(r.ctx)
+	r.mu.Lock()
+	r.cancels[id] = cancel
+	r.mu.Unlock()
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer func() {
+			r.mu.Lock()
+			delete(r.cancels, id)
+			r.mu.Unlock()
+			cancel()
+		}()
+		r.run(jobCtx, id, occupancyIDs)
+	}()
+
+	slog.Info("evaluation job started", "job_id", id, "selector", selector, "total", len(occupancyIDs))
+
+	return r.store.Get(ctx, id)
+}
+
+// Cancel stops a running job. Jobs which are not running in this instance, e.g. after a restart,
+// are marked as cancelled.
+func (r *Runner) Cancel(ctx context.Context, id string) error {
+	r.mu.Lock()
+	cancel, ok := r.cancels[id]
+	r.mu.Unlock()
+
+	if ok {
+		cancel()
+		return nil
+	}
+
+	if _, err := r.store.Get(ctx, id); err != nil {
+		return err
+	}
+	cancelled, err := r.store.Finish(ctx, id, store.EvaluationJobStatusCancelled)
+	if err != nil {
+		return fmt.Errorf("failed to cancel evaluation job %s: %w", id, err)
+	}
+	if !cancelled {
+		return ErrJobNotRunning
+	}
+
+	return nil
+}
+
+// Get returns the job state.
+func (r *Runner) Get(ctx context.Context, id string) (store.EvaluationJob, error) {
+	return r.store.Get(ctx, id)
+}
+
+// Wait blocks until all the jobs started finished.
+func (r *Runner) Wait() {
+	r.wg.Wait()
+}
+
+// WatchStale marks as failed the jobs left running by an instance which stopped without finishing
+// them, e.g. after a crash. It checks on start and then every staleJobTimeout until the context is cancelled.
+func (r *Runner) WatchStale(ctx context.Context) error {
+	ticker := time.NewTicker(staleJobTimeout)
+	defer ticker.Stop()
+
+	for {
+		ids, err := r.store.FailStale(ctx, staleJobTimeout)
+		if err != nil && ctx.Err() == nil {
+			slog.Error("failed to mark stale evaluation jobs as failed", "error", err)
+		}
+		for _, id := range ids {
+			slog.Warn("stale evaluation job marked as failed", "job_id", id)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Runner) run(ctx context.Context, id string, occupancyIDs []string) {
+	start := time.Now()
+	limiter := rate.NewLimiter(r.rateLimit, 1)
+
+	var processed, failed atomic.Int64
+
+	channel := make(chan string, len(occupancyIDs))
+	for _, occupancyID := range occupancyIDs {
+		channel <- occupancyID
+	}
+	close(channel)
+
+	var wg sync.WaitGroup
+	for i := 0; i < r.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for occupancyID := range channel {
+				if err := limiter.Wait(ctx); err != nil {
+					return
+				}
+
+				err := r.evaluator.RunFull(ctx, occupancyID)
+				if err != nil && ctx.Err() != nil {
+					return
+				}
+				if err != nil {
+					failed.Add(1)
+					slog.Error("failed to run evaluation", "job_id", id, "occupancy_id", occupancyID, "error", err)
+					// the job context may be cancelled while recording the error, so it's not used
+					if err := r.store.AddError(context.Background(), id, occupancyID, err.Error()); err != nil {
+						slog.Error("failed to record evaluation job error", "job_id", id, "occupancy_id", occupancyID, "error", err)
+					}
+				}
+				processed.Add(1)
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+
+progress:
+	for {
+		select {
+		case <-ticker.C:
+			r.updateProgress(id, int(processed.Load()), int(failed.Load()))
+		case <-done:
+			break progress
+		}
+	}
+
+	r.updateProgress(id, int(processed.Load()), int(failed.Load()))
+
+	status := store.EvaluationJobStatusCompleted
+	if ctx.Err() != nil {
+		status = store.EvaluationJobStatusCancelled
+		// jobs interrupted by a shutdown aren't resumed
+		if r.ctx.Err() != nil {
+			status = store.EvaluationJobStatusFailed
+		}
+	}
+	if _, err := r.store.Finish(context.Background(), id, status); err != nil {
+		slog.Error("failed to finish evaluation job", "job_id", id, "error", err)
+	}
+
+	slog.Info("evaluation job finished",
+		"job_id", id,
+		"status", status,
+		"processed", processed.Load(),
+		"failed", failed.Load(),
+		"elapsed", time.Since(start).String())
+}
+
+func (r *Runner) updateProgress(id string, processed, failed int) {
+	if err := r.store.UpdateProgress(context.Background(), id, processed, failed); err != nil {
+		slog.Error("failed to update evaluation job progress", "job_id", id, "error", err)
+	}
+}
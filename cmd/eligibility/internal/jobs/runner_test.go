@@ -0,0 +1,209 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/jobs"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/store"
+)
+
+type mockJobStore struct {
+	mu   sync.Mutex
+	jobs map[string]store.EvaluationJob
+}
+
+func newMockJobStore() *mockJobStore {
+	return &mockJobStore{jobs: map[string]store.EvaluationJob{}}
+}
+
+func (s *mockJobStore) Create(_ context.Context, id, selector string, total int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs[id] = store.EvaluationJob{ID: id, Selector: selector, Status: store.EvaluationJobStatusRunning, Total: total}
+	return nil
+}
+
+func (s *mockJobStore) UpdateProgress(_ context.Context, id string, processed, failed int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job := s.jobs[id]
+	job.Processed, job.Failed = processed, failed
+	s.jobs[id] = job
+	return nil
+}
+
+func (s *mockJobStore) AddError(_ context.Context, id, occupancyID, evaluationErr string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job := s.jobs[id]
+	job.Errors = append(job.Errors, store.EvaluationJobError{OccupancyID: occupancyID, Error: evaluationErr})
+	s.jobs[id] = job
+	return nil
+}
+
+func (s *mockJobStore) Finish(_ context.Context, id string, status store.EvaluationJobStatus) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job := s.jobs[id]
+	if job.Status != store.EvaluationJobStatusRunning {
+		return false, nil
+	}
+	job.Status = status
+	s.jobs[id] = job
+	return true, nil
+}
+
+func (s *mockJobStore) FailStale(_ context.Context, _ time.Duration) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var ids []string
+	for id, job := range s.jobs {
+		if job.Status == store.EvaluationJobStatusRunning {
+			job.Status = store.EvaluationJobStatusFailed
+			s.jobs[id] = job
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (s *mockJobStore) Get(_ context.Context, id string) (store.EvaluationJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return store.EvaluationJob{}, store.ErrEvaluationJobNotFound
+	}
+	return job, nil
+}
+
+type mockEvaluator struct {
+	block chan struct{}
+}
+
+func (e *mockEvaluator) RunFull(ctx context.Context, occupancyID string) error {
+	if e.block != nil {
+		select {
+		case <-e.block:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if occupancyID == "broken" {
+		return errors.New("failed to load occupancy")
+	}
+	return nil
+}
+
+func TestRunner(t *testing.T) {
+	ctx := context.Background()
+
+	jobStore := newMockJobStore()
+	runner := jobs.NewRunner(ctx, jobStore, &mockEvaluator{}, 2, 0)
+
+	job, err := runner.Start(ctx, "account:account-id", []string{"occupancy-1", "broken", "occupancy-2"})
+	require.NoError(t, err)
+	assert.Equal(t, "account:account-id", job.Selector)
+	assert.Equal(t, 3, job.Total)
+
+	runner.Wait()
+
+	job, err = runner.Get(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, store.EvaluationJobStatusCompleted, job.Status)
+	assert.Equal(t, 3, job.Processed)
+	assert.Equal(t, 1, job.Failed)
+	assert.Equal(t, []store.EvaluationJobError{{OccupancyID: "broken", Error: "failed to load occupancy"}}, job.Errors)
+
+	assert.ErrorIs(t, runner.Cancel(ctx, job.ID), jobs.ErrJobNotRunning)
+	assert.ErrorIs(t, runner.Cancel(ctx, "missing-job-id"), store.ErrEvaluationJobNotFound)
+}
+
+func TestRunnerCancel(t *testing.T) {
+	ctx := context.Background()
+
+	jobStore := newMockJobStore()
+	runner := jobs.NewRunner(ctx, jobStore, &mockEvaluator{block: make(chan struct{})}, 1, 0)
+
+	job, err := runner.Start(ctx, "mpxn:mpxn", []string{"occupancy-1", "occupancy-2"})
+	require.NoError(t, err)
+
+	require.NoError(t, runner.Cancel(ctx, job.ID))
+	runner.Wait()
+
+	job, err = runner.Get(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, store.EvaluationJobStatusCancelled, job.Status)
+	assert.Equal(t, 0, job.Processed)
+	assert.Empty(t, job.Errors)
+}
+
+func TestRunnerRateLimit(t *testing.T) {
+	ctx := context.Background()
+
+	runner := jobs.NewRunner(ctx, newMockJobStore(), &mockEvaluator{}, 5, 20)
+
+	start := time.Now()
+	_, err := runner.Start(ctx, "postcode:AB1 2CD", []string{"occupancy-1", "occupancy-2", "occupancy-3"})
+	require.NoError(t, err)
+	runner.Wait()
+
+	// the first evaluation runs straight away, the others wait for the limiter
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+}
+
+func TestRunnerShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	jobStore := newMockJobStore()
+	runner := jobs.NewRunner(ctx, jobStore, &mockEvaluator{block: make(chan struct{})}, 1, 0)
+
+	job, err := runner.Start(ctx, "mpxn:mpxn", []string{"occupancy-1", "occupancy-2"})
+	require.NoError(t, err)
+
+	cancel()
+	runner.Wait()
+
+	job, err = jobStore.Get(context.Background(), job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, store.EvaluationJobStatusFailed, job.Status)
+
+	_, err = runner.Start(context.Background(), "mpxn:mpxn", []string{"occupancy-1"})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRunnerWatchStale(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	jobStore := newMockJobStore()
+	require.NoError(t, jobStore.Create(ctx, "stale-job", "account:account-id", 1))
+
+	runner := jobs.NewRunner(ctx, jobStore, &mockEvaluator{}, 1, 0)
+
+	done := make(chan error)
+	go func() {
+		done <- runner.WatchStale(ctx)
+	}()
+
+	assert.Eventually(t, func() bool {
+		job, err := jobStore.Get(ctx, "stale-job")
+		return err == nil && job.Status == store.EvaluationJobStatusFailed
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
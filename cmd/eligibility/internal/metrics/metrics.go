@@ -9,3 +9,18 @@ var SmartBookingEvaluationCounter = promauto.NewCounterVec(prometheus.CounterOpt
 	Name: "smart_booking_evaluation_total",
 	Help: "the total number of evaluations for smart booking",
 }, []string{"criteria"})
+
+var MSNExceptionsGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "smart_booking_msn_exceptions",
+	Help: "the number of meter serial numbers in the smart meter exception list",
+}, []string{"state"})
+
+var MSNExceptionsLastReloadGauge = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "smart_booking_msn_exceptions_last_reload_timestamp_seconds",
+	Help: "the time the meter serial number exception list was last reloaded",
+})
+
+var MSNExceptionsReloadErrorsCounter = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "smart_booking_msn_exceptions_reload_errors_total",
+	Help: "the total number of failed reloads of the meter serial number exception list",
+})
@@ -0,0 +1,98 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	bookingv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/booking/v1"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/domain"
+)
+
+var ErrBookingNotFound = errors.New("booking not found")
+
+type BookingStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewBooking(pool *pgxpool.Pool) *BookingStore {
+	return &BookingStore{pool: pool}
+}
+
+// Add records the status of a booking of the occupancy, a status older than the
+// one already recorded is ignored. Moving the booking to an aborted status counts
+// as one more aborted visit.
+func (s *BookingStore) Add(ctx context.Context, bookingID, occupancyID string, status bookingv1.BookingStatus, at time.Time) error {
+	q := `
+	INSERT INTO bookings (id, occupancy_id, status, aborted_visits, status_updated_at)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (id)
+	DO UPDATE
+	SET status = $3,
+		aborted_visits = bookings.aborted_visits + CASE WHEN bookings.status <> $3 THEN $4 ELSE 0 END,
+		status_updated_at = $5,
+		updated_at = now()
+	WHERE bookings.status_updated_at <= $5;`
+
+	abortedVisits := 0
+	if isAbortedVisit(status) {
+		abortedVisits = 1
+	}
+
+	_, err := s.pool.Exec(ctx, q, bookingID, occupancyID, status.String(), abortedVisits, at)
+
+	return err
+}
+
+func (s *BookingStore) GetOccupancyID(ctx context.Context, bookingID string) (string, error) {
+	var occupancyID string
+
+	q := `SELECT occupancy_id FROM bookings WHERE id = $1;`
+	if err := s.pool.QueryRow(ctx, q, bookingID).Scan(&occupancyID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrBookingNotFound
+		}
+		return "", err
+	}
+
+	return occupancyID, nil
+}
+
+func (s *BookingStore) GetByOccupancyID(ctx context.Context, occupancyID string) ([]domain.Booking, error) {
+	return getBookingsByOccupancyID(ctx, s.pool, occupancyID)
+}
+
+func getBookingsByOccupancyID(ctx context.Context, pool *pgxpool.Pool, occupancyID string) ([]domain.Booking, error) {
+	q := `
+	SELECT id, status, aborted_visits FROM bookings
+	WHERE occupancy_id = $1
+	ORDER BY created_at;`
+
+	rows, err := pool.Query(ctx, q, occupancyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bookings []domain.Booking
+	for rows.Next() {
+		var (
+			booking domain.Booking
+			status  string
+		)
+		if err := rows.Scan(&booking.ID, &status, &booking.AbortedVisits); err != nil {
+			return nil, err
+		}
+		booking.Status = bookingv1.BookingStatus(bookingv1.BookingStatus_value[status])
+		bookings = append(bookings, booking)
+	}
+
+	return bookings, rows.Err()
+}
+
+func isAbortedVisit(status bookingv1.BookingStatus) bool {
+	return status == bookingv1.BookingStatus_BOOKING_STATUS_ABORTED ||
+		status == bookingv1.BookingStatus_BOOKING_STATUS_NO_ACCESS
+}
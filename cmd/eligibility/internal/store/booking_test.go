@@ -0,0 +1,64 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	bookingv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/booking/v1"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/domain"
+)
+
+func TestBooking(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+
+	store := NewBooking(connect(ctx))
+	defer store.pool.Close()
+
+	const occupancyID = "booking_occupancy"
+	now := time.Date(2024, time.August, 1, 10, 0, 0, 0, time.UTC)
+
+	err := store.Add(ctx, "booking1", occupancyID, bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED, now)
+	assert.NoError(err, "failed to add booking")
+
+	occID, err := store.GetOccupancyID(ctx, "booking1")
+	assert.NoError(err, "failed to get occupancy of booking")
+	assert.Equal(occupancyID, occID, "mismatch")
+
+	_, err = store.GetOccupancyID(ctx, "booking2")
+	assert.ErrorIs(err, ErrBookingNotFound)
+
+	// the installer could not access the property
+	err = store.Add(ctx, "booking1", occupancyID, bookingv1.BookingStatus_BOOKING_STATUS_NO_ACCESS, now.Add(time.Hour))
+	assert.NoError(err, "failed to update booking")
+	// redelivered status change is not counted again
+	err = store.Add(ctx, "booking1", occupancyID, bookingv1.BookingStatus_BOOKING_STATUS_NO_ACCESS, now.Add(time.Hour))
+	assert.NoError(err, "failed to update booking")
+	// outdated status change is ignored
+	err = store.Add(ctx, "booking1", occupancyID, bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED, now)
+	assert.NoError(err, "failed to update booking")
+
+	bookings, err := store.GetByOccupancyID(ctx, occupancyID)
+	assert.NoError(err, "failed to get bookings")
+	assert.Equal([]domain.Booking{
+		{ID: "booking1", Status: bookingv1.BookingStatus_BOOKING_STATUS_NO_ACCESS, AbortedVisits: 1},
+	}, bookings)
+
+	// rescheduled and aborted again
+	err = store.Add(ctx, "booking1", occupancyID, bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED, now.Add(2*time.Hour))
+	assert.NoError(err, "failed to update booking")
+	err = store.Add(ctx, "booking1", occupancyID, bookingv1.BookingStatus_BOOKING_STATUS_ABORTED, now.Add(3*time.Hour))
+	assert.NoError(err, "failed to update booking")
+
+	err = store.Add(ctx, "booking2", occupancyID, bookingv1.BookingStatus_BOOKING_STATUS_COMPLETED, now.Add(4*time.Hour))
+	assert.NoError(err, "failed to add booking")
+
+	bookings, err = store.GetByOccupancyID(ctx, occupancyID)
+	assert.NoError(err, "failed to get bookings")
+	assert.Equal([]domain.Booking{
+		{ID: "booking1", Status: bookingv1.BookingStatus_BOOKING_STATUS_ABORTED, AbortedVisits: 2},
+		{ID: "booking2", Status: bookingv1.BookingStatus_BOOKING_STATUS_COMPLETED, AbortedVisits: 0},
+	}, bookings)
+}
@@ -0,0 +1,75 @@
+package store
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/domain"
+)
+
+// EvaluationHistoryStore is an append-only log of the evaluation results published for each occupancy.
+type EvaluationHistoryStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewEvaluationHistory(pool *pgxpool.Pool) *EvaluationHistoryStore {
+	return &EvaluationHistoryStore{pool: pool}
+}
+
+// Add appends an entry to the history, entries for events already recorded are ignored
+// so that replaying the events doesn't duplicate the history.
+func (s *EvaluationHistoryStore) Add(ctx context.Context, entry domain.EvaluationHistoryEntry) error {
+	q := `
+	INSERT INTO evaluation_history(occupancy_id, account_id, evaluation_type, reasons, event_type, event_uuid, occurred_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	ON CONFLICT (event_uuid, evaluation_type)
+	DO NOTHING;`
+
+	_, err := s.pool.Exec(ctx, q,
+		entry.OccupancyID,
+		entry.AccountID,
+		entry.EvaluationType,
+		entry.Reasons,
+		entry.EventType,
+		entry.EventUUID,
+		entry.OccurredAt.UTC(),
+	)
+
+	return err
+}
+
+// Get returns the history of an occupancy of an account, oldest first.
+func (s *EvaluationHistoryStore) Get(ctx context.Context, occupancyID, accountID string) ([]domain.EvaluationHistoryEntry, error) {
+	entries := make([]domain.EvaluationHistoryEntry, 0)
+
+	q := `
+	SELECT occupancy_id, account_id, evaluation_type, reasons, event_type, event_uuid, occurred_at
+	FROM evaluation_history
+	WHERE occupancy_id = $1
+	AND account_id = $2
+	ORDER BY occurred_at, id;`
+
+	rows, err := s.pool.Query(ctx, q, occupancyID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry domain.EvaluationHistoryEntry
+		if err := rows.Scan(
+			&entry.OccupancyID,
+			&entry.AccountID,
+			&entry.EvaluationType,
+			&entry.Reasons,
+			&entry.EventType,
+			&entry.EventUUID,
+			&entry.OccurredAt,
+		); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
@@ -0,0 +1,74 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/domain"
+)
+
+func TestEvaluationHistory(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+
+	store := NewEvaluationHistory(connect(ctx))
+	defer store.pool.Close()
+
+	at := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	removed := domain.EvaluationHistoryEntry{
+		OccupancyID:    "occupancy1",
+		AccountID:      "account1",
+		EvaluationType: domain.EvaluationTypeEligibility,
+		Reasons:        domain.IneligibleReasons{domain.IneligibleReasonAlreadySmart},
+		EventType:      "EligibleOccupancyRemovedEvent",
+		EventUUID:      "uuid1",
+		OccurredAt:     at,
+	}
+	added := domain.EvaluationHistoryEntry{
+		OccupancyID:    "occupancy1",
+		AccountID:      "account1",
+		EvaluationType: domain.EvaluationTypeEligibility,
+		EventType:      "EligibleOccupancyAddedEvent",
+		EventUUID:      "uuid2",
+		OccurredAt:     at.Add(time.Hour),
+	}
+	suppliable := domain.EvaluationHistoryEntry{
+		OccupancyID:    "occupancy1",
+		AccountID:      "account1",
+		EvaluationType: domain.EvaluationTypeSuppliability,
+		EventType:      "SuppliableOccupancyAddedEvent",
+		EventUUID:      "uuid3",
+		OccurredAt:     at.Add(time.Minute),
+	}
+	// entries of another account are not returned
+	otherAccount := domain.EvaluationHistoryEntry{
+		OccupancyID:    "occupancy1",
+		AccountID:      "account2",
+		EvaluationType: domain.EvaluationTypeEligibility,
+		EventType:      "EligibleOccupancyAddedEvent",
+		EventUUID:      "uuid4",
+		OccurredAt:     at.Add(-time.Hour),
+	}
+
+	assert.NoError(store.Add(ctx, added))
+	assert.NoError(store.Add(ctx, otherAccount))
+	assert.NoError(store.Add(ctx, removed))
+	assert.NoError(store.Add(ctx, suppliable))
+	// replayed events are not duplicated
+	assert.NoError(store.Add(ctx, removed))
+
+	entries, err := store.Get(ctx, "occupancy1", "account1")
+	assert.NoError(err)
+	assert.Equal([]domain.EvaluationHistoryEntry{removed, suppliable, added}, entries)
+
+	entries, err = store.Get(ctx, "occupancy1", "account2")
+	assert.NoError(err)
+	assert.Equal([]domain.EvaluationHistoryEntry{otherAccount}, entries)
+
+	entries, err = store.Get(ctx, "occupancy2", "account1")
+	assert.NoError(err)
+	assert.Empty(entries)
+}
@@ -0,0 +1,180 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrEvaluationJobNotFound = errors.New("evaluation job not found")
+
+type EvaluationJobStatus string
+
+const (
+	EvaluationJobStatusRunning   EvaluationJobStatus = "running"
+	EvaluationJobStatusCompleted EvaluationJobStatus = "completed"
+	EvaluationJobStatusCancelled EvaluationJobStatus = "cancelled"
+	EvaluationJobStatusFailed    EvaluationJobStatus = "failed"
+)
+
+type EvaluationJobError struct {
+	OccupancyID string
+	Error       string
+}
+
+type EvaluationJob struct {
+	ID          string
+	Selector    string
+	Status      EvaluationJobStatus
+	Total       int
+	Processed   int
+	Failed      int
+	CreatedAt   time.Time
+	UpdatedAt   *time.Time
+	CompletedAt *time.Time
+	Errors      []EvaluationJobError
+}
+
+type EvaluationJobStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewEvaluationJob(pool *pgxpool.Pool) *EvaluationJobStore {
+	return &EvaluationJobStore{pool: pool}
+}
+
+// Create adds a running job for the given number of occupancies.
+func (s *EvaluationJobStore) Create(ctx context.Context, id, selector string, total int) error {
+	q := `
+	INSERT INTO evaluation_jobs(id, selector, status, total)
+	VALUES ($1, $2, $3, $4);`
+
+	_, err := s.pool.Exec(ctx, q, id, selector, EvaluationJobStatusRunning, total)
+
+	return err
+}
+
+// UpdateProgress sets the number of occupancies processed and failed so far.
+func (s *EvaluationJobStore) UpdateProgress(ctx context.Context, id string, processed, failed int) error {
+	q := `
+	UPDATE evaluation_jobs
+	SET processed = $2, failed = $3, updated_at = now()
+	WHERE id = $1;`
+
+	_, err := s.pool.Exec(ctx, q, id, processed, failed)
+
+	return err
+}
+
+// AddError records the evaluation error of an occupancy.
+func (s *EvaluationJobStore) AddError(ctx context.Context, id, occupancyID, evaluationErr string) error {
+	q := `
+	INSERT INTO evaluation_job_errors(job_id, occupancy_id, error)
+	VALUES ($1, $2, $3);`
+
+	_, err := s.pool.Exec(ctx, q, id, occupancyID, evaluationErr)
+
+	return err
+}
+
+// Finish sets the final status of a running job, it returns false if the job is not running.
+func (s *EvaluationJobStore) Finish(ctx context.Context, id string, status EvaluationJobStatus) (bool, error) {
+	q := `
+	UPDATE evaluation_jobs
+	SET status = $2, updated_at = now(), completed_at = now()
+	WHERE id = $1
+	AND status = $3;`
+
+	tag, err := s.pool.Exec(ctx, q, id, status, EvaluationJobStatusRunning)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// FailStale marks as failed the running jobs which haven't been updated for the given duration,
+// it returns the IDs of the jobs marked.
+func (s *EvaluationJobStore) FailStale(ctx context.Context, staleAfter time.Duration) ([]string, error) {
+	q := `
+	UPDATE evaluation_jobs
+	SET status = $1, updated_at = now(), completed_at = now()
+	WHERE status = $2
+	AND COALESCE(updated_at, created_at) < now() - make_interval(secs => $3)
+	RETURNING id;`
+
+	rows, err := s.pool.Query(ctx, q, EvaluationJobStatusFailed, EvaluationJobStatusRunning, staleAfter.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// Get returns the job with the errors of the occupancies which failed to be evaluated.
+func (s *EvaluationJobStore) Get(ctx context.Context, id string) (EvaluationJob, error) {
+	var (
+		job         EvaluationJob
+		updatedAt   sql.NullTime
+		completedAt sql.NullTime
+	)
+
+	q := `
+	SELECT id, selector, status, total, processed, failed, created_at, updated_at, completed_at
+	FROM evaluation_jobs
+	WHERE id = $1;`
+
+	err := s.pool.QueryRow(ctx, q, id).Scan(
+		&job.ID,
+		&job.Selector,
+		&job.Status,
+		&job.Total,
+		&job.Processed,
+		&job.Failed,
+		&job.CreatedAt,
+		&updatedAt,
+		&completedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return EvaluationJob{}, ErrEvaluationJobNotFound
+		}
+		return EvaluationJob{}, err
+	}
+	if updatedAt.Valid {
+		job.UpdatedAt = &updatedAt.Time
+	}
+	if completedAt.Valid {
+		job.CompletedAt = &completedAt.Time
+	}
+
+	rows, err := s.pool.Query(ctx, `SELECT occupancy_id, error FROM evaluation_job_errors WHERE job_id = $1 ORDER BY created_at;`, id)
+	if err != nil {
+		return EvaluationJob{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var jobErr EvaluationJobError
+		if err := rows.Scan(&jobErr.OccupancyID, &jobErr.Error); err != nil {
+			return EvaluationJob{}, err
+		}
+		job.Errors = append(job.Errors, jobErr)
+	}
+
+	return job, rows.Err()
+}
@@ -0,0 +1,73 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluationJob(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+
+	store := NewEvaluationJob(connect(ctx))
+	defer store.pool.Close()
+
+	_, err := store.Get(ctx, "job1")
+	assert.ErrorIs(err, ErrEvaluationJobNotFound)
+
+	err = store.Create(ctx, "job1", "account:account1", 3)
+	assert.NoError(err, "failed to create job")
+
+	job, err := store.Get(ctx, "job1")
+	assert.NoError(err, "failed to get job")
+	assert.Equal(EvaluationJobStatusRunning, job.Status)
+	assert.Equal("account:account1", job.Selector)
+	assert.Equal(3, job.Total)
+	assert.Nil(job.CompletedAt)
+
+	assert.NoError(store.AddError(ctx, "job1", "occupancy2", "failed to load occupancy"))
+	assert.NoError(store.UpdateProgress(ctx, "job1", 3, 1))
+
+	finished, err := store.Finish(ctx, "job1", EvaluationJobStatusCompleted)
+	assert.NoError(err)
+	assert.True(finished)
+
+	// a finished job can't be cancelled
+	finished, err = store.Finish(ctx, "job1", EvaluationJobStatusCancelled)
+	assert.NoError(err)
+	assert.False(finished)
+
+	job, err = store.Get(ctx, "job1")
+	assert.NoError(err, "failed to get job")
+	assert.Equal(EvaluationJobStatusCompleted, job.Status)
+	assert.Equal(3, job.Processed)
+	assert.Equal(1, job.Failed)
+	assert.NotNil(job.CompletedAt)
+	assert.Equal([]EvaluationJobError{{OccupancyID: "occupancy2", Error: "failed to load occupancy"}}, job.Errors)
+}
+
+func TestEvaluationJobFailStale(t *testing.T) {
+	ctx := context.Background()
+	assert := assert.New(t)
+
+	store := NewEvaluationJob(connect(ctx))
+	defer store.pool.Close()
+
+	assert.NoError(store.Create(ctx, "staleJob1", "account:account1", 1))
+
+	ids, err := store.FailStale(ctx, time.Hour)
+	assert.NoError(err)
+	assert.NotContains(ids, "staleJob1")
+
+	ids, err = store.FailStale(ctx, 0)
+	assert.NoError(err)
+	assert.Contains(ids, "staleJob1")
+
+	job, err := store.Get(ctx, "staleJob1")
+	assert.NoError(err, "failed to get job")
+	assert.Equal(EvaluationJobStatusFailed, job.Status)
+	assert.NotNil(job.CompletedAt)
+}
@@ -0,0 +1,224 @@
+package inmemory
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/domain"
+	"github.com/utilitywarehouse/go-operational/op"
+)
+
+const defaultCatalogueVersion = "default"
+
+// FileVersion identifies the content of a loaded data file.
+type FileVersion struct {
+	Path     string
+	Version  string
+	LoadedAt time.Time
+}
+
+func (v FileVersion) String() string {
+	if v.Path == "" {
+		return v.Version
+	}
+	return fmt.Sprintf("%s@%s", v.Path, v.Version)
+}
+
+// MeterCatalogueStore holds the unsupported SSCs and standard meter capacities used to evaluate
+// meterpoints and meters. The catalogues are loaded from files, if provided, and reloaded when the
+// file content changes, otherwise the defaults shipped with the service are used.
+type MeterCatalogueStore struct {
+	sscFilePath      string
+	capacityFilePath string
+
+	mu                 sync.RWMutex
+	unsupportedSSCs    domain.UnsupportedSSCs
+	standardCapacities domain.StandardCapacities
+	sscVersion         FileVersion
+	capacityVersion    FileVersion
+	reloadErr          error
+}
+
+func NewMeterCatalogue(sscFilePath, capacityFilePath string) (*MeterCatalogueStore, error) {
+	s := &MeterCatalogueStore{
+		sscFilePath:        sscFilePath,
+		capacityFilePath:   capacityFilePath,
+		unsupportedSSCs:    domain.DefaultUnsupportedSSCs,
+		standardCapacities: domain.DefaultStandardCapacities,
+		sscVersion:         FileVersion{Version: defaultCatalogueVersion, LoadedAt: time.Now()},
+		capacityVersion:    FileVersion{Version: defaultCatalogueVersion, LoadedAt: time.Now()},
+	}
+
+	if _, err := s.Reload(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *MeterCatalogueStore) HasComplexSSC(m domain.ComplexSSCPredicate) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.unsupportedSSCs.HasComplexSSC(m)
+}
+
+func (s *MeterCatalogueStore) IsLargeCapacity(m domain.CapacityGetter) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.standardCapacities.IsLargeCapacity(m)
+}
+
+// Versions returns the versions of the active unsupported SSCs and standard capacities catalogues.
+func (s *MeterCatalogueStore) Versions() (ssc FileVersion, capacity FileVersion) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.sscVersion, s.capacityVersion
+}
+
+// Reload loads the catalogue files if their content changed since the last load. Both files are parsed
+// before either is swapped in, so on failure the previously loaded catalogues stay active together.
+func (s *MeterCatalogueStore) Reload() (bool, error) {
+	var (
+		unsupportedSSCs    domain.UnsupportedSSCs
+		standardCapacities domain.StandardCapacities
+		sscVersion         FileVersion
+		capacityVersion    FileVersion
+	)
+
+	if s.sscFilePath != "" {
+		data, version, err := readVersionedFile(s.sscFilePath)
+		if err != nil {
+			return false, s.failReload(fmt.Errorf("failed to open unsupported SSCs file, %w", err))
+		}
+		if version != s.currentSSCVersion() {
+			unsupportedSSCs, err = domain.ParseUnsupportedSSCs(bytes.NewReader(data))
+			if err != nil {
+				return false, s.failReload(fmt.Errorf("failed to parse unsupported SSCs file %s: %w", s.sscFilePath, err))
+			}
+			sscVersion = FileVersion{Path: s.sscFilePath, Version: version, LoadedAt: time.Now()}
+		}
+	}
+
+	if s.capacityFilePath != "" {
+		data, version, err := readVersionedFile(s.capacityFilePath)
+		if err != nil {
+			return false, s.failReload(fmt.Errorf("failed to open standard meter capacities file, %w", err))
+		}
+		if version != s.currentCapacityVersion() {
+			standardCapacities, err = domain.ParseStandardCapacities(bytes.NewReader(data))
+			if err != nil {
+				return false, s.failReload(fmt.Errorf("failed to parse standard meter capacities file %s: %w", s.capacityFilePath, err))
+			}
+			capacityVersion = FileVersion{Path: s.capacityFilePath, Version: version, LoadedAt: time.Now()}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sscChanged, capacityChanged := sscVersion.Version != "", capacityVersion.Version != ""
+	if sscChanged {
+		s.unsupportedSSCs = unsupportedSSCs
+		s.sscVersion = sscVersion
+	}
+	if capacityChanged {
+		s.standardCapacities = standardCapacities
+		s.capacityVersion = capacityVersion
+	}
+	s.reloadErr = nil
+
+	return sscChanged || capacityChanged, nil
+}
+
+// Watch reloads the catalogue files every interval until the context is cancelled.
+func (s *MeterCatalogueStore) Watch(ctx context.Context, interval time.Duration) error {
+	if s.sscFilePath == "" && s.capacityFilePath == "" {
+		return nil
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			changed, err := s.Reload()
+			if err != nil {
+				slog.Error("failed to reload meter catalogue, keeping previous version", "error", err)
+				continue
+			}
+			if changed {
+				ssc, capacity := s.Versions()
+				slog.Info("meter catalogue reloaded", "unsupported_ssc_version", ssc.String(), "standard_capacity_version", capacity.String())
+			}
+		}
+	}
+}
+
+// NewHealthCheck reports the active catalogue versions, the check is degraded if the last reload failed.
+func (s *MeterCatalogueStore) NewHealthCheck() func(*op.CheckResponse) {
+	return func(cr *op.CheckResponse) {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		versions := fmt.Sprintf("unsupported SSCs: %s, standard capacities: %s", s.sscVersion, s.capacityVersion)
+		if s.reloadErr != nil {
+			cr.Degraded(fmt.Sprintf("%s, last reload failed: %s", versions, s.reloadErr), "Check the meter catalogue files")
+			return
+		}
+
+		cr.Healthy(versions)
+	}
+}
+
+func (s *MeterCatalogueStore) currentSSCVersion() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.sscVersion.Version
+}
+
+func (s *MeterCatalogueStore) currentCapacityVersion() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.capacityVersion.Version
+}
+
+func (s *MeterCatalogueStore) failReload(err error) error {
+	s.mu.Lock()
+	s.reloadErr = err
+	s.mu.Unlock()
+
+	return err
+}
+
+// readVersionedFile returns the file content and a version derived from its hash.
+func readVersionedFile(path string) ([]byte, string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, "", err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading file: %w", err)
+	}
+
+	hash := sha256.Sum256(data)
+	return data, hex.EncodeToString(hash[:6]), nil
+}
@@ -0,0 +1,110 @@
+package inmemory_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/utilitywarehouse/energy-contracts/pkg/generated/platform"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/domain"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/store/inmemory"
+)
+
+func Test_MeterCatalogue_Defaults(t *testing.T) {
+
+	catalogue, err := inmemory.NewMeterCatalogue("", "")
+	require.NoError(t, err)
+
+	require.True(t, catalogue.HasComplexSSC(&domain.Meterpoint{ProfileClass: platform.ProfileClass_PROFILE_CLASS_02, SSC: "0003"}))
+	require.False(t, catalogue.HasComplexSSC(&domain.Meterpoint{ProfileClass: platform.ProfileClass_PROFILE_CLASS_01, SSC: "0003"}))
+	require.False(t, catalogue.IsLargeCapacity(meterWithCapacity(6)))
+	require.True(t, catalogue.IsLargeCapacity(meterWithCapacity(100)))
+
+	ssc, capacity := catalogue.Versions()
+	require.Equal(t, "default", ssc.String())
+	require.Equal(t, "default", capacity.String())
+
+	// nothing to watch without files
+	require.NoError(t, catalogue.Watch(context.Background(), 0))
+}
+
+func Test_MeterCatalogue_Reload(t *testing.T) {
+
+	dir := t.TempDir()
+	sscPath := filepath.Join(dir, "unsupported_ssc_list.tsv")
+	capacityPath := filepath.Join(dir, "standard_meter_capacities.tsv")
+
+	writeFile(t, sscPath, "# comment\n0393\t01\n\n0151\t02,04\n")
+	writeFile(t, capacityPath, "6\n")
+
+	catalogue, err := inmemory.NewMeterCatalogue(sscPath, capacityPath)
+	require.NoError(t, err)
+
+	require.True(t, catalogue.HasComplexSSC(&domain.Meterpoint{ProfileClass: platform.ProfileClass_PROFILE_CLASS_01, SSC: "0393"}))
+	require.False(t, catalogue.HasComplexSSC(&domain.Meterpoint{ProfileClass: platform.ProfileClass_PROFILE_CLASS_02, SSC: "0393"}))
+	require.True(t, catalogue.HasComplexSSC(&domain.Meterpoint{ProfileClass: platform.ProfileClass_PROFILE_CLASS_04, SSC: "0151"}))
+	require.True(t, catalogue.IsLargeCapacity(meterWithCapacity(212)))
+
+	initialSSC, initialCapacity := catalogue.Versions()
+	require.Equal(t, sscPath, initialSSC.Path)
+
+	// unchanged files are not reloaded
+	changed, err := catalogue.Reload()
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	writeFile(t, capacityPath, "6\n212\n")
+	changed, err = catalogue.Reload()
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.False(t, catalogue.IsLargeCapacity(meterWithCapacity(212)))
+
+	ssc, capacity := catalogue.Versions()
+	require.Equal(t, initialSSC.Version, ssc.Version)
+	require.NotEqual(t, initialCapacity.Version, capacity.Version)
+
+	// an invalid file keeps the previous catalogue active
+	writeFile(t, sscPath, "0393\tXX\n")
+	_, err = catalogue.Reload()
+	require.Error(t, err)
+	require.True(t, catalogue.HasComplexSSC(&domain.Meterpoint{ProfileClass: platform.ProfileClass_PROFILE_CLASS_01, SSC: "0393"}))
+
+	ssc, _ = catalogue.Versions()
+	require.Equal(t, initialSSC.Version, ssc.Version)
+
+	// a valid SSC file is not applied when the capacity file is invalid
+	_, capacity = catalogue.Versions()
+	writeFile(t, sscPath, "0393\t02\n")
+	writeFile(t, capacityPath, "# no capacities\n")
+	_, err = catalogue.Reload()
+	require.Error(t, err)
+	require.True(t, catalogue.HasComplexSSC(&domain.Meterpoint{ProfileClass: platform.ProfileClass_PROFILE_CLASS_01, SSC: "0393"}))
+	require.False(t, catalogue.HasComplexSSC(&domain.Meterpoint{ProfileClass: platform.ProfileClass_PROFILE_CLASS_02, SSC: "0393"}))
+
+	reloadedSSC, reloadedCapacity := catalogue.Versions()
+	require.Equal(t, initialSSC.Version, reloadedSSC.Version)
+	require.Equal(t, capacity.Version, reloadedCapacity.Version)
+}
+
+func Test_MeterCatalogue_InvalidFile(t *testing.T) {
+
+	capacityPath := filepath.Join(t.TempDir(), "standard_meter_capacities.tsv")
+	writeFile(t, capacityPath, "# no capacities\n")
+
+	_, err := inmemory.NewMeterCatalogue("", capacityPath)
+	require.Error(t, err)
+
+	_, err = inmemory.NewMeterCatalogue("./testdata/missing.tsv", "")
+	require.Error(t, err)
+}
+
+func meterWithCapacity(capacity float32) *domain.Meter {
+	return &domain.Meter{Capacity: &capacity}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
@@ -2,43 +2,231 @@ package inmemory
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"fmt"
-	"os"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/metrics"
+	"github.com/utilitywarehouse/go-operational/op"
 )
 
+const msnExpiryLayout = "2006-01-02"
+
+// MeterSerialNumberException is an entry of the smart meter exception list, the meters in the list
+// are smart but still need to go through a booking.
+type MeterSerialNumberException struct {
+	MSN     string
+	Reason  string
+	AddedBy string
+	// ExpiresOn is the last day the exception applies, zero if it never expires.
+	ExpiresOn time.Time
+}
+
+// Expired returns true if the exception no longer applies at the given time.
+func (e MeterSerialNumberException) Expired(at time.Time) bool {
+	return !e.ExpiresOn.IsZero() && !at.Before(e.ExpiresOn.AddDate(0, 0, 1))
+}
+
+// MeterSerialNumberStore holds the smart meter exception list loaded from a file. The file is reloaded
+// when its content changes and expired exceptions are dropped on every reload.
 type MeterSerialNumberStore struct {
-	meterSerialNumber map[string]struct{}
+	filePath string
+	now      func() time.Time
+
+	mu         sync.RWMutex
+	exceptions []MeterSerialNumberException
+	active     map[string]MeterSerialNumberException
+	version    FileVersion
+	reloadErr  error
 }
 
 func NewMeterSerialNumber(filePath string) (*MeterSerialNumberStore, error) {
-	msnMap, err := loadFromFile(filePath)
-	if err != nil {
+	s := &MeterSerialNumberStore{
+		filePath: filePath,
+		now:      time.Now,
+		active:   map[string]MeterSerialNumberException{},
+	}
+
+	if _, err := s.Reload(); err != nil {
 		return nil, err
 	}
 
-	return &MeterSerialNumberStore{
-		meterSerialNumber: msnMap,
-	}, nil
+	return s, nil
 }
 
 func (s *MeterSerialNumberStore) FindMeterSerialNumber(msn string) bool {
-	_, ok := s.meterSerialNumber[msn]
+	_, ok := s.Get(msn)
 	return ok
 }
 
-func loadFromFile(path string) (map[string]struct{}, error) {
-	result := map[string]struct{}{}
+// Get returns the active exception for the meter serial number, if any.
+func (s *MeterSerialNumberStore) Get(msn string) (MeterSerialNumberException, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	exception, ok := s.active[msn]
+	return exception, ok
+}
+
+// Version returns the version of the active exception list.
+func (s *MeterSerialNumberStore) Version() FileVersion {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.version
+}
 
-	file, err := os.Open(path)
+// Reload loads the exception list if its content changed since the last load and drops the expired
+// exceptions. It returns the meter serial numbers added to or removed from the active list.
+// On failure the previously loaded list stays active.
+func (s *MeterSerialNumberStore) Reload() ([]string, error) {
+	data, version, err := readVersionedFile(s.filePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open allowed meter serial number file, %w", err)
+		return nil, s.failReload(fmt.Errorf("failed to open allowed meter serial number file, %w", err))
+	}
+
+	s.mu.RLock()
+	exceptions, currentVersion := s.exceptions, s.version.Version
+	s.mu.RUnlock()
+
+	loaded := version != currentVersion
+	if loaded {
+		exceptions, err = parseMeterSerialNumberExceptions(bytes.NewReader(data))
+		if err != nil {
+			return nil, s.failReload(fmt.Errorf("failed to parse meter serial number file %s: %w", s.filePath, err))
+		}
+	}
+
+	now := s.now()
+	active := make(map[string]MeterSerialNumberException, len(exceptions))
+	expired := 0
+	for _, exception := range exceptions {
+		if exception.Expired(now) {
+			expired++
+			continue
+		}
+		active[exception.MSN] = exception
+	}
+
+	s.mu.Lock()
+	changed := diffMeterSerialNumbers(s.active, active)
+	s.exceptions = exceptions
+	s.active = active
+	if loaded {
+		s.version = FileVersion{Path: s.filePath, Version: version, LoadedAt: now}
+	}
+	s.reloadErr = nil
+	s.mu.Unlock()
+
+	metrics.MSNExceptionsGauge.WithLabelValues("active").Set(float64(len(active)))
+	metrics.MSNExceptionsGauge.WithLabelValues("expired").Set(float64(expired))
+	if loaded {
+		metrics.MSNExceptionsLastReloadGauge.Set(float64(now.Unix()))
 	}
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
+	return changed, nil
+}
+
+// Watch reloads the exception list every interval until the context is cancelled, calling onChange with
+// the meter serial numbers added to or removed from the active list.
+func (s *MeterSerialNumberStore) Watch(ctx context.Context, interval time.Duration, onChange func(ctx context.Context, msns []string) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			changed, err := s.Reload()
+			if err != nil {
+				slog.Error("failed to reload meter serial number exceptions, keeping previous version", "error", err)
+				continue
+			}
+			if len(changed) == 0 {
+				continue
+			}
+			slog.Info("meter serial number exceptions changed", "version", s.Version().String(), "changed", len(changed))
+			if onChange == nil {
+				continue
+			}
+			if err := onChange(ctx, changed); err != nil {
+				slog.Error("failed to handle meter serial number exceptions change", "error", err)
+			}
+		}
+	}
+}
+
+// NewHealthCheck reports the active exception list version, the check is degraded if the last reload failed.
+func (s *MeterSerialNumberStore) NewHealthCheck() func(*op.CheckResponse) {
+	return func(cr *op.CheckResponse) {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		status := fmt.Sprintf("meter serial number exceptions: %s, active: %d", s.version, len(s.active))
+		if s.reloadErr != nil {
+			cr.Degraded(fmt.Sprintf("%s, last reload failed: %s", status, s.reloadErr), "Check the meter serial number exception file")
+			return
+		}
+
+		cr.Healthy(status)
+	}
+}
+
+func (s *MeterSerialNumberStore) failReload(err error) error {
+	s.mu.Lock()
+	s.reloadErr = err
+	s.mu.Unlock()
+
+	metrics.MSNExceptionsReloadErrorsCounter.Inc()
+
+	return err
+}
+
+// parseMeterSerialNumberExceptions reads tab separated lines with the meter serial number and, optionally,
+// the reason, who added it and the expiry date (YYYY-MM-DD). Empty lines and lines starting with # are ignored.
+func parseMeterSerialNumberExceptions(r io.Reader) ([]MeterSerialNumberException, error) {
+	var result []MeterSerialNumberException
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
 		line := scanner.Text()
-		result[line] = struct{}{}
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) > 4 {
+			return nil, fmt.Errorf("line %d: expected at most 4 fields, got %d", lineNumber, len(fields))
+		}
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		fields = append(fields, make([]string, 4-len(fields))...)
+
+		exception := MeterSerialNumberException{
+			MSN:     fields[0],
+			Reason:  fields[1],
+			AddedBy: fields[2],
+		}
+		if exception.MSN == "" {
+			return nil, fmt.Errorf("line %d: missing meter serial number", lineNumber)
+		}
+		if fields[3] != "" {
+			expiresOn, err := time.Parse(msnExpiryLayout, fields[3])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid expiry date %q: %w", lineNumber, fields[3], err)
+			}
+			exception.ExpiresOn = expiresOn
+		}
+
+		result = append(result, exception)
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -47,3 +235,21 @@ func loadFromFile(path string) (map[string]struct{}, error) {
 
 	return result, nil
 }
+
+// diffMeterSerialNumbers returns the sorted meter serial numbers present in only one of the lists.
+func diffMeterSerialNumbers(previous, current map[string]MeterSerialNumberException) []string {
+	var changed []string
+	for msn := range current {
+		if _, ok := previous[msn]; !ok {
+			changed = append(changed, msn)
+		}
+	}
+	for msn := range previous {
+		if _, ok := current[msn]; !ok {
+			changed = append(changed, msn)
+		}
+	}
+	sort.Strings(changed)
+
+	return changed
+}
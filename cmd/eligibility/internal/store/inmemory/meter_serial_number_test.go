@@ -1,7 +1,9 @@
 package inmemory_test
 
 import (
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/store/inmemory"
@@ -18,3 +20,55 @@ func Test_FindMeterSerialNumber(t *testing.T) {
 	require.False(t, msnStore.FindMeterSerialNumber("CANTSEEME"))
 
 }
+
+func Test_MeterSerialNumber_Exceptions(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "msn_exception_list.tsv")
+	writeFile(t, path, "# msn\treason\tadded by\texpiry\nESM1\tfaulty comms hub\tops@uw.co.uk\t2099-01-31\nESM2\tlegacy\t\t2000-01-01\n\nESM3\n")
+
+	msnStore, err := inmemory.NewMeterSerialNumber(path)
+	require.NoError(t, err)
+
+	exception, ok := msnStore.Get("ESM1")
+	require.True(t, ok)
+	require.Equal(t, inmemory.MeterSerialNumberException{
+		MSN:       "ESM1",
+		Reason:    "faulty comms hub",
+		AddedBy:   "ops@uw.co.uk",
+		ExpiresOn: time.Date(2099, 1, 31, 0, 0, 0, 0, time.UTC),
+	}, exception)
+
+	// expired exceptions are ignored
+	require.False(t, msnStore.FindMeterSerialNumber("ESM2"))
+	require.True(t, msnStore.FindMeterSerialNumber("ESM3"))
+	require.False(t, msnStore.FindMeterSerialNumber(""))
+
+	changed, err := msnStore.Reload()
+	require.NoError(t, err)
+	require.Empty(t, changed)
+
+	writeFile(t, path, "ESM1\nESM4\tnew\n")
+	changed, err = msnStore.Reload()
+	require.NoError(t, err)
+	require.Equal(t, []string{"ESM3", "ESM4"}, changed)
+	require.True(t, msnStore.FindMeterSerialNumber("ESM4"))
+	require.False(t, msnStore.FindMeterSerialNumber("ESM3"))
+
+	// an invalid file keeps the previous list active
+	version := msnStore.Version()
+	writeFile(t, path, "ESM5\treason\tops\tnot-a-date\n")
+	_, err = msnStore.Reload()
+	require.Error(t, err)
+	require.True(t, msnStore.FindMeterSerialNumber("ESM4"))
+	require.False(t, msnStore.FindMeterSerialNumber("ESM5"))
+	require.Equal(t, version, msnStore.Version())
+}
+
+func Test_MeterSerialNumberException_Expired(t *testing.T) {
+
+	exception := inmemory.MeterSerialNumberException{MSN: "ESM1", ExpiresOn: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}
+
+	require.False(t, exception.Expired(time.Date(2024, 3, 1, 23, 59, 0, 0, time.UTC)))
+	require.True(t, exception.Expired(time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)))
+	require.False(t, inmemory.MeterSerialNumberException{MSN: "ESM1"}.Expired(time.Now()))
+}
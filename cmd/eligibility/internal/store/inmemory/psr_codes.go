@@ -0,0 +1,34 @@
+package inmemory
+
+import "strings"
+
+// PSRCodeStore holds the priority services register codes which require a specialist visit
+// and therefore can not be routed through the standard smart booking journey.
+type PSRCodeStore struct {
+	specialistVisitCodes map[string]struct{}
+}
+
+func NewPSRCodes(specialistVisitCodes []string) *PSRCodeStore {
+	codes := make(map[string]struct{}, len(specialistVisitCodes))
+	for _, code := range specialistVisitCodes {
+		code = strings.TrimSpace(code)
+		if code == "" {
+			continue
+		}
+		codes[code] = struct{}{}
+	}
+
+	return &PSRCodeStore{
+		specialistVisitCodes: codes,
+	}
+}
+
+// RequiresSpecialistVisit returns true if any of the provided codes is configured as requiring a specialist visit.
+func (s *PSRCodeStore) RequiresSpecialistVisit(codes []string) bool {
+	for _, code := range codes {
+		if _, ok := s.specialistVisitCodes[strings.TrimSpace(code)]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,22 @@
+package inmemory_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/store/inmemory"
+)
+
+func Test_RequiresSpecialistVisit(t *testing.T) {
+
+	psrStore := inmemory.NewPSRCodes([]string{"17", " 29", ""})
+
+	require.True(t, psrStore.RequiresSpecialistVisit([]string{"17"}))
+	require.True(t, psrStore.RequiresSpecialistVisit([]string{"02", "29"}))
+	require.False(t, psrStore.RequiresSpecialistVisit([]string{"02", "14"}))
+	require.False(t, psrStore.RequiresSpecialistVisit([]string{""}))
+	require.False(t, psrStore.RequiresSpecialistVisit(nil))
+
+	emptyStore := inmemory.NewPSRCodes(nil)
+	require.False(t, emptyStore.RequiresSpecialistVisit([]string{"17"}))
+}
@@ -0,0 +1,5 @@
+-- +migrate Up
+CREATE INDEX IF NOT EXISTS meters_msn_idx ON meters (msn);
+
+-- +migrate Down
+DROP INDEX IF EXISTS meters_msn_idx;
@@ -0,0 +1,27 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS evaluation_jobs (
+    id TEXT PRIMARY KEY,
+    selector TEXT NOT NULL,
+    status TEXT NOT NULL,
+    total INTEGER NOT NULL DEFAULT 0,
+    processed INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITHOUT TIME ZONE,
+    completed_at TIMESTAMP WITHOUT TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS evaluation_job_errors (
+    job_id TEXT NOT NULL REFERENCES evaluation_jobs(id) ON DELETE CASCADE,
+    occupancy_id TEXT NOT NULL,
+    error TEXT NOT NULL,
+
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS evaluation_job_errors_job_id_idx ON evaluation_job_errors(job_id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS evaluation_job_errors;
+DROP TABLE IF EXISTS evaluation_jobs;
@@ -0,0 +1,19 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS evaluation_history (
+    id BIGSERIAL PRIMARY KEY,
+    occupancy_id TEXT NOT NULL,
+    account_id TEXT NOT NULL,
+    evaluation_type TEXT NOT NULL,
+    reasons json,
+    event_type TEXT NOT NULL,
+    event_uuid TEXT NOT NULL,
+    occurred_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(event_uuid, evaluation_type)
+);
+
+CREATE INDEX IF NOT EXISTS evaluation_history_occupancy_id_idx ON evaluation_history(occupancy_id, occurred_at);
+
+-- +migrate Down
+DROP TABLE IF EXISTS evaluation_history;
@@ -0,0 +1,66 @@
+-- +migrate Up
+-- Postcodes are stored in their canonical form, upper case with a single space before the inward code,
+-- postcodes which aren't valid UK postcodes are stored upper case without whitespace.
+-- When several WAN coverage rows normalise to the same postcode they are merged into the first one, covered
+-- if any of them is: the upsert never updated created_at, so it doesn't tell which row is the most recent.
+WITH normalised AS (
+    SELECT p.post_code, p.wan_coverage,
+        CASE WHEN compact ~ '^(GIR0AA|[A-Z]{1,2}[0-9][A-Z0-9]?[0-9][A-Z]{2})$'
+            THEN LEFT(compact, -3) || ' ' || RIGHT(compact, 3)
+            ELSE compact
+        END AS canonical
+    FROM (SELECT post_code, wan_coverage, UPPER(REGEXP_REPLACE(post_code, '\s', '', 'g')) AS compact FROM postcodes) p
+)
+UPDATE postcodes
+SET wan_coverage = merged.wan_coverage
+FROM (
+    SELECT MIN(post_code) AS kept, bool_or(wan_coverage) AS wan_coverage
+    FROM normalised
+    GROUP BY canonical
+    HAVING COUNT(*) > 1
+) merged
+WHERE postcodes.post_code = merged.kept;
+
+DELETE FROM postcodes
+WHERE post_code IN (
+    SELECT post_code FROM (
+        SELECT post_code,
+            ROW_NUMBER() OVER (PARTITION BY canonical ORDER BY post_code) AS rn
+        FROM (
+            SELECT post_code,
+                CASE WHEN compact ~ '^(GIR0AA|[A-Z]{1,2}[0-9][A-Z0-9]?[0-9][A-Z]{2})$'
+                    THEN LEFT(compact, -3) || ' ' || RIGHT(compact, 3)
+                    ELSE compact
+                END AS canonical
+            FROM (SELECT post_code, UPPER(REGEXP_REPLACE(post_code, '\s', '', 'g')) AS compact FROM postcodes) c
+        ) n
+    ) ranked
+    WHERE rn > 1
+);
+
+UPDATE postcodes
+SET post_code = n.canonical
+FROM (
+    SELECT post_code,
+        CASE WHEN compact ~ '^(GIR0AA|[A-Z]{1,2}[0-9][A-Z0-9]?[0-9][A-Z]{2})$'
+            THEN LEFT(compact, -3) || ' ' || RIGHT(compact, 3)
+            ELSE compact
+        END AS canonical
+    FROM (SELECT post_code, UPPER(REGEXP_REPLACE(post_code, '\s', '', 'g')) AS compact FROM postcodes) c
+) n
+WHERE postcodes.post_code = n.post_code AND postcodes.post_code <> n.canonical;
+
+UPDATE sites
+SET post_code = n.canonical, updated_at = now()
+FROM (
+    SELECT id,
+        CASE WHEN compact ~ '^(GIR0AA|[A-Z]{1,2}[0-9][A-Z0-9]?[0-9][A-Z]{2})$'
+            THEN LEFT(compact, -3) || ' ' || RIGHT(compact, 3)
+            ELSE compact
+        END AS canonical
+    FROM (SELECT id, UPPER(REGEXP_REPLACE(post_code, '\s', '', 'g')) AS compact FROM sites WHERE post_code IS NOT NULL) c
+) n
+WHERE sites.id = n.id AND sites.post_code <> n.canonical;
+
+-- +migrate Down
+-- the original form of the postcodes is not kept, so they are left normalised
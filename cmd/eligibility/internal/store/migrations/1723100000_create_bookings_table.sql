@@ -0,0 +1,16 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS bookings (
+    id TEXT PRIMARY KEY,
+    occupancy_id TEXT NOT NULL,
+    status TEXT NOT NULL,
+    aborted_visits INT NOT NULL DEFAULT 0,
+    status_updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITHOUT TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS bookings_occupancy_id_idx ON bookings(occupancy_id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS bookings;
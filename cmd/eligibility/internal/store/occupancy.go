@@ -10,6 +10,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/domain"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
 )
 
 var ErrOccupancyNotFound = errors.New("occupancy not found")
@@ -71,10 +72,10 @@ func (s *OccupancyStore) GetIDsBySite(ctx context.Context, siteID string) ([]str
 }
 
 // GetIDsByPostcode gets occupancies by postcode.
-func (s *OccupancyStore) GetIDsByPostcode(ctx context.Context, postCode string) ([]string, error) {
+func (s *OccupancyStore) GetIDsByPostcode(ctx context.Context, postCode models.Postcode) ([]string, error) {
 	q := `SELECT id FROM occupancies WHERE site_id IN (SELECT id FROM sites WHERE post_code = $1);`
 
-	return s.queryOccupanciesByIdentifier(ctx, q, postCode)
+	return s.queryOccupanciesByIdentifier(ctx, q, postCode.String())
 }
 
 func (s *OccupancyStore) GetIDsByMPXN(ctx context.Context, mpxn string) ([]string, error) {
@@ -86,6 +87,18 @@ func (s *OccupancyStore) GetIDsByMPXN(ctx context.Context, mpxn string) ([]strin
 	return s.queryOccupanciesByIdentifier(ctx, q, mpxn)
 }
 
+// GetIDsByMSN gets the live occupancies with an installed meter with the given serial number.
+func (s *OccupancyStore) GetIDsByMSN(ctx context.Context, msn string) ([]string, error) {
+	q := `SELECT distinct s.occupancy_id FROM services s
+			JOIN meters m ON s.mpxn = m.mpxn
+			WHERE m.msn = $1
+			AND m.installed_at IS NOT NULL
+			AND m.uninstalled_at IS NULL
+			AND s.is_live IS TRUE;`
+
+	return s.queryOccupanciesByIdentifier(ctx, q, msn)
+}
+
 func (s *OccupancyStore) GetLiveOccupanciesPendingEvaluation(ctx context.Context) ([]string, error) {
 	var ids = make([]string, 0)
 
@@ -187,6 +200,9 @@ func (s *OccupancyStore) LoadOccupancy(ctx context.Context, occupancyID string)
 		&wanCoverage,
 	)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Occupancy{}, ErrOccupancyNotFound
+		}
 		return domain.Occupancy{}, err
 	}
 
@@ -216,6 +232,7 @@ func (s *OccupancyStore) LoadOccupancy(ctx context.Context, occupancyID string)
 		ID: occupancyAccountID,
 	}
 	if accountID.Valid {
+		occupancy.Account.PSRCodes = psrCodes
 		if optOut.Valid {
 			occupancy.Account.OptOut = optOut.Bool
 		}
@@ -225,6 +242,11 @@ func (s *OccupancyStore) LoadOccupancy(ctx context.Context, occupancyID string)
 		return domain.Occupancy{}, err
 	}
 
+	occupancy.Bookings, err = getBookingsByOccupancyID(ctx, s.pool, occupancyID)
+	if err != nil {
+		return domain.Occupancy{}, err
+	}
+
 	return occupancy, nil
 }
 
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	bookingv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/booking/v1"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/domain"
 )
 
@@ -71,6 +72,18 @@ func TestOccupancy(t *testing.T) {
 	assert.True(len(ids) == 1)
 	assert.Equal("occupancy1", ids[0])
 
+	// get by msn, only for installed meters of live services
+	_, err = store.pool.Exec(ctx, `
+		INSERT INTO meters(id, mpxn, msn, supply_type, meter_type, installed_at, uninstalled_at)
+		VALUES ('meter1', 'mpxn2', 'msn1', 'electricity', 'type', now(), NULL),
+		       ('meter2', 'mpxn3', 'msn1', 'gas', 'type', now(), NULL),
+		       ('meter3', 'mpxn4', 'msn1', 'electricity', 'type', now(), now());`)
+	assert.NoError(err)
+
+	ids, err = store.GetIDsByMSN(ctx, "msn1")
+	assert.NoError(err)
+	assert.Equal([]string{"occupancy1"}, ids)
+
 	ids, err = store.GetLiveOccupanciesPendingEvaluation(ctx)
 	assert.NoError(err)
 	assert.ElementsMatch([]string{"occupancy1", "occupancy2"}, ids)
@@ -87,7 +100,10 @@ func TestLoadOccupancy(t *testing.T) {
 	store := NewOccupancy(connect(ctx))
 	defer store.pool.Close()
 
-	_, err := store.pool.Exec(ctx, `
+	_, err := store.LoadOccupancy(ctx, "unknownOccupancyID")
+	assert.ErrorIs(err, ErrOccupancyNotFound)
+
+	_, err = store.pool.Exec(ctx, `
 	INSERT INTO occupancies(id, site_id, account_id, created_at) VALUES ('occupancyID1', 'siteID1', 'accountID1', now());
 	INSERT INTO sites(id, post_code, created_at) VALUES ('siteID1', 'postcode', now());`)
 	assert.NoError(err, "failed to prepare db")
@@ -127,6 +143,15 @@ func TestLoadOccupancy(t *testing.T) {
 	expected.Account.OptOut = true
 	assert.Equal(expected, occupancy)
 
+	_, err = store.pool.Exec(ctx, `
+	UPDATE accounts SET psr_codes = '{"17", "29"}' WHERE id = 'accountID1';`)
+	assert.NoError(err, "failed to prepare db")
+	occupancy, err = store.LoadOccupancy(ctx, "occupancyID1")
+	assert.NoError(err)
+
+	expected.Account.PSRCodes = []string{"17", "29"}
+	assert.Equal(expected, occupancy)
+
 	_, err = store.pool.Exec(ctx, `INSERT INTO eligibility (occupancy_id, account_id, reasons) VALUES ('occupancyID1', 'account1', '["ComplexTariff", "AlreadySmart"]');`)
 	assert.NoError(err)
 	occupancy, err = store.LoadOccupancy(ctx, "occupancyID1")
@@ -142,6 +167,13 @@ func TestLoadOccupancy(t *testing.T) {
 	expected.EvaluationResult.Campaignability = domain.IneligibleReasons{domain.IneligibleReasonBookingOptOut}
 	expected.EvaluationResult.CampaignabilityEvaluated = true
 	assert.Equal(expected, occupancy)
+
+	_, err = store.pool.Exec(ctx, `INSERT INTO bookings (id, occupancy_id, status, aborted_visits, status_updated_at) VALUES ('bookingID1', 'occupancyID1', 'BOOKING_STATUS_SCHEDULED', 1, now());`)
+	assert.NoError(err)
+	occupancy, err = store.LoadOccupancy(ctx, "occupancyID1")
+	assert.NoError(err)
+	expected.Bookings = []domain.Booking{{ID: "bookingID1", Status: bookingv1.BookingStatus_BOOKING_STATUS_SCHEDULED, AbortedVisits: 1}}
+	assert.Equal(expected, occupancy)
 }
 
 func TestGetLiveOccupanciesIDsByAccountID(t *testing.T) {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
 )
 
 var ErrSiteNotFound = errors.New("site not found")
@@ -17,14 +18,14 @@ type SiteStore struct {
 
 type Site struct {
 	ID       string
-	PostCode string
+	PostCode models.Postcode
 }
 
 func NewSite(pool *pgxpool.Pool) *SiteStore {
 	return &SiteStore{pool: pool}
 }
 
-func (s *SiteStore) Add(ctx context.Context, id, postCode string, at time.Time) error {
+func (s *SiteStore) Add(ctx context.Context, id string, postCode models.Postcode, at time.Time) error {
 	q := `
 	INSERT INTO sites (id, post_code, created_at)
 	VALUES ($1, $2, $3)
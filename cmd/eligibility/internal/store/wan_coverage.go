@@ -6,6 +6,7 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
 )
 
 var ErrPostCodeNotFound = errors.New("post code not found")
@@ -18,7 +19,7 @@ func NewPostCode(pool *pgxpool.Pool) *PostCodeStore {
 	return &PostCodeStore{pool: pool}
 }
 
-func (s *PostCodeStore) AddWanCoverage(ctx context.Context, postCode string, covered bool) error {
+func (s *PostCodeStore) AddWanCoverage(ctx context.Context, postCode models.Postcode, covered bool) error {
 	q := `
 	INSERT INTO postcodes (post_code, wan_coverage)
 	VALUES ($1, $2)
@@ -31,7 +32,7 @@ func (s *PostCodeStore) AddWanCoverage(ctx context.Context, postCode string, cov
 	return err
 }
 
-func (s *PostCodeStore) GetWanCoverage(ctx context.Context, postCode string) (bool, error) {
+func (s *PostCodeStore) GetWanCoverage(ctx context.Context, postCode models.Postcode) (bool, error) {
 	var covered bool
 	if err := s.pool.QueryRow(ctx, `SELECT wan_coverage FROM postcodes WHERE post_code = $1`, postCode).
 		Scan(&covered); err != nil {
@@ -3,6 +3,7 @@ package main
 import (
 	"log/slog"
 	"os"
+	"time"
 
 	"github.com/urfave/cli/v2"
 	"github.com/utilitywarehouse/energy-pkg/app"
@@ -24,7 +25,9 @@ const (
 
 	altHanTopic       = "alt-han-events-topic"
 	optOutTopic       = "opt-out-events-topic"
+	psrTopic          = "psr-events-topic"
 	bookingRefTopic   = "booking-reference-events-topic"
+	bookingTopic      = "booking-events-topic"
 	meterTopic        = "meter-events-topic"
 	meterpointTopic   = "meterpoint-events-topic"
 	occupancyTopic    = "occupancy-events-topic"
@@ -37,15 +40,46 @@ const (
 	stateRebuild = "state-rebuild"
 
 	//eligibility
-	msnExceptionFilePath = "msn-exception-file-path"
+	msnExceptionFilePath       = "msn-exception-file-path"
+	msnExceptionReloadInterval = "msn-exception-reload-interval"
+	psrSpecialistVisitCodes    = "psr-specialist-visit-codes"
+	eligibilityRulesFilePath   = "eligibility-rules-file-path"
+
+	// meter catalogue
+	unsupportedSSCFilePath        = "unsupported-ssc-file-path"
+	standardMeterCapacityFilePath = "standard-meter-capacity-file-path"
+	meterCatalogueReloadInterval  = "meter-catalogue-reload-interval"
+
+	// dry run
+	candidateRulesFilePath                 = "candidate-rules-file-path"
+	candidateUnsupportedSSCFilePath        = "candidate-unsupported-ssc-file-path"
+	candidateStandardMeterCapacityFilePath = "candidate-standard-meter-capacity-file-path"
+	dryRunReportFilePath                   = "dry-run-report-file-path"
+	dryRunChangesFilePath                  = "dry-run-changes-file-path"
+	dryRunWorkers                          = "dry-run-workers"
 
 	// gRPC
-	grpcPort    = "grpc-port"
-	xoserveHost = "xoserve-host"
-	ecoesHost   = "ecoes-host"
+	grpcPort                       = "grpc-port"
+	xoserveHost                    = "xoserve-host"
+	ecoesHost                      = "ecoes-host"
+	ecoesTimeout                   = "ecoes-timeout"
+	xoserveTimeout                 = "xoserve-timeout"
+	meterpointStoreTimeout         = "meterpoint-store-timeout"
+	thirdPartyMaxRetries           = "third-party-max-retries"
+	thirdPartyRetryBackoff         = "third-party-retry-backoff"
+	circuitBreakerFailureThreshold = "circuit-breaker-failure-threshold"
+	circuitBreakerOpenDuration     = "circuit-breaker-open-duration"
+	thirdPartyCacheSize            = "third-party-cache-size"
+	thirdPartyCacheRedisAddr       = "third-party-cache-redis-addr"
+	technicalDetailsCacheTTL       = "technical-details-cache-ttl"
+	relatedMPANsCacheTTL           = "related-mpans-cache-ttl"
+	notFoundCacheTTL               = "not-found-cache-ttl"
+	internalHTTPPort               = "internal-http-port"
 
 	// http
-	httpPort = "http-port"
+	httpPort               = "http-port"
+	evaluationJobWorkers   = "evaluation-job-workers"
+	evaluationJobRateLimit = "evaluation-job-rate-limit"
 
 	// BigQuery
 	bigQueryProjectID                         = "big-query-project-id"
@@ -86,6 +120,12 @@ func main() {
 						EnvVars: []string{"HTTP_PORT"},
 						Value:   8091,
 					},
+					&cli.IntFlag{
+						Name:    internalHTTPPort,
+						Usage:   "The port to listen on for the internal http endpoints, e.g. the cache invalidation, it must not be exposed publicly",
+						EnvVars: []string{"INTERNAL_HTTP_PORT"},
+						Value:   8092,
+					},
 					&cli.StringFlag{
 						Name:     xoserveHost,
 						Usage:    "The xoserve host endpoint address",
@@ -98,6 +138,93 @@ func main() {
 						EnvVars:  []string{"ECOES_HOST"},
 						Required: true,
 					},
+					&cli.DurationFlag{
+						Name:    ecoesTimeout,
+						Usage:   "The deadline of each ECOES lookup, including its retries",
+						EnvVars: []string{"ECOES_TIMEOUT"},
+						Value:   3 * time.Second,
+					},
+					&cli.DurationFlag{
+						Name:    xoserveTimeout,
+						Usage:   "The deadline of each Xoserve lookup, including its retries",
+						EnvVars: []string{"XOSERVE_TIMEOUT"},
+						Value:   3 * time.Second,
+					},
+					&cli.DurationFlag{
+						Name:    meterpointStoreTimeout,
+						Usage:   "The deadline of the WAN coverage and alt-HAN lookups",
+						EnvVars: []string{"METERPOINT_STORE_TIMEOUT"},
+						Value:   time.Second,
+					},
+					&cli.IntFlag{
+						Name:    thirdPartyMaxRetries,
+						Usage:   "How many times the ECOES and Xoserve calls failing with a transient error are retried",
+						EnvVars: []string{"THIRD_PARTY_MAX_RETRIES"},
+						Value:   2,
+					},
+					&cli.DurationFlag{
+						Name:    thirdPartyRetryBackoff,
+						Usage:   "The wait before the first retry, doubled for each following retry",
+						EnvVars: []string{"THIRD_PARTY_RETRY_BACKOFF"},
+						Value:   100 * time.Millisecond,
+					},
+					&cli.IntFlag{
+						Name:    circuitBreakerFailureThreshold,
+						Usage:   "The consecutive transient failures after which ECOES or Xoserve stop being called",
+						EnvVars: []string{"CIRCUIT_BREAKER_FAILURE_THRESHOLD"},
+						Value:   5,
+					},
+					&cli.DurationFlag{
+						Name:    circuitBreakerOpenDuration,
+						Usage:   "How long ECOES or Xoserve stop being called before a trial call is made",
+						EnvVars: []string{"CIRCUIT_BREAKER_OPEN_DURATION"},
+						Value:   30 * time.Second,
+					},
+					&cli.IntFlag{
+						Name:    thirdPartyCacheSize,
+						Usage:   "The number of ECOES and Xoserve results cached in memory",
+						EnvVars: []string{"THIRD_PARTY_CACHE_SIZE"},
+						Value:   10000,
+					},
+					&cli.StringFlag{
+						Name:    thirdPartyCacheRedisAddr,
+						Usage:   "The address of the redis sharing the cached ECOES and Xoserve results between instances, only memory is used if not provided",
+						EnvVars: []string{"THIRD_PARTY_CACHE_REDIS_ADDR"},
+					},
+					&cli.DurationFlag{
+						Name:    technicalDetailsCacheTTL,
+						Usage:   "How long the ECOES and Xoserve technical details are cached, 0 disables caching",
+						EnvVars: []string{"TECHNICAL_DETAILS_CACHE_TTL"},
+						Value:   6 * time.Hour,
+					},
+					&cli.DurationFlag{
+						Name:    relatedMPANsCacheTTL,
+						Usage:   "How long the ECOES related MPANs are cached, 0 disables caching",
+						EnvVars: []string{"RELATED_MPANS_CACHE_TTL"},
+						Value:   6 * time.Hour,
+					},
+					&cli.DurationFlag{
+						Name:    notFoundCacheTTL,
+						Usage:   "How long the meterpoints not found by ECOES or Xoserve are cached, 0 disables negative caching",
+						EnvVars: []string{"NOT_FOUND_CACHE_TTL"},
+						Value:   15 * time.Minute,
+					},
+					&cli.StringFlag{
+						Name:    unsupportedSSCFilePath,
+						Usage:   "Path to the TSV of unsupported SSCs per profile class, the default list is used if not provided",
+						EnvVars: []string{"UNSUPPORTED_SSC_FILE_PATH"},
+					},
+					&cli.StringFlag{
+						Name:    standardMeterCapacityFilePath,
+						Usage:   "Path to the list of standard gas meter capacities, the default list is used if not provided",
+						EnvVars: []string{"STANDARD_METER_CAPACITY_FILE_PATH"},
+					},
+					&cli.DurationFlag{
+						Name:    meterCatalogueReloadInterval,
+						Usage:   "How often the meter catalogue files are checked for changes",
+						EnvVars: []string{"METER_CATALOGUE_RELOAD_INTERVAL"},
+						Value:   time.Minute,
+					},
 				),
 				Before: app.Before,
 				Action: runGRPCApi,
@@ -113,6 +240,17 @@ func main() {
 						EnvVars: []string{"HTTP_PORT"},
 						Value:   8091,
 					},
+					&cli.IntFlag{
+						Name:    evaluationJobWorkers,
+						Usage:   "The number of occupancies evaluated concurrently by each evaluation job",
+						EnvVars: []string{"EVALUATION_JOB_WORKERS"},
+						Value:   10,
+					},
+					&cli.Float64Flag{
+						Name:    evaluationJobRateLimit,
+						Usage:   "The maximum number of evaluations per second of each evaluation job, 0 for no limit",
+						EnvVars: []string{"EVALUATION_JOB_RATE_LIMIT"},
+					},
 					&cli.StringFlag{
 						Name:     postgresDSN,
 						EnvVars:  []string{"POSTGRES_DSN"},
@@ -138,6 +276,43 @@ func main() {
 						EnvVars:  []string{"BOOKING_JOURNEY_ELIGIBILITY_EVENTS_TOPIC"},
 						Required: true,
 					},
+					&cli.StringFlag{
+						Name:     msnExceptionFilePath,
+						EnvVars:  []string{"MSN_EXCEPTION_FILE_PATH"},
+						Required: true,
+					},
+					&cli.DurationFlag{
+						Name:    msnExceptionReloadInterval,
+						Usage:   "How often the meter serial number exception file is checked for changes and expired exceptions",
+						EnvVars: []string{"MSN_EXCEPTION_RELOAD_INTERVAL"},
+						Value:   time.Minute,
+					},
+					&cli.StringSliceFlag{
+						Name:    psrSpecialistVisitCodes,
+						Usage:   "PSR codes for which the customer requires a specialist visit and is not eligible for smart booking",
+						EnvVars: []string{"PSR_SPECIALIST_VISIT_CODES"},
+					},
+					&cli.StringFlag{
+						Name:    eligibilityRulesFilePath,
+						Usage:   "Path to the YAML or JSON eligibility rule set, the default rule set is used if not provided",
+						EnvVars: []string{"ELIGIBILITY_RULES_FILE_PATH"},
+					},
+					&cli.StringFlag{
+						Name:    unsupportedSSCFilePath,
+						Usage:   "Path to the TSV of unsupported SSCs per profile class, the default list is used if not provided",
+						EnvVars: []string{"UNSUPPORTED_SSC_FILE_PATH"},
+					},
+					&cli.StringFlag{
+						Name:    standardMeterCapacityFilePath,
+						Usage:   "Path to the list of standard gas meter capacities, the default list is used if not provided",
+						EnvVars: []string{"STANDARD_METER_CAPACITY_FILE_PATH"},
+					},
+					&cli.DurationFlag{
+						Name:    meterCatalogueReloadInterval,
+						Usage:   "How often the meter catalogue files are checked for changes",
+						EnvVars: []string{"METER_CATALOGUE_RELOAD_INTERVAL"},
+						Value:   time.Minute,
+					},
 				),
 				Before: app.Before,
 				Action: runHTTPApi,
@@ -185,11 +360,21 @@ func main() {
 						EnvVars:  []string{"OPT_OUT_EVENTS_TOPIC"},
 						Required: true,
 					},
+					&cli.StringFlag{
+						Name:    psrTopic,
+						Usage:   "The account PSR events topic, the PSR events are not consumed when it is not set",
+						EnvVars: []string{"PSR_EVENTS_TOPIC"},
+					},
 					&cli.StringFlag{
 						Name:     bookingRefTopic,
 						EnvVars:  []string{"BOOKING_REF_EVENTS_TOPIC"},
 						Required: true,
 					},
+					&cli.StringFlag{
+						Name:    bookingTopic,
+						Usage:   "The booking-api events topic, the booking events are not consumed when it is not set",
+						EnvVars: []string{"BOOKING_EVENTS_TOPIC"},
+					},
 					&cli.StringFlag{
 						Name:     meterTopic,
 						EnvVars:  []string{"METER_EVENTS_TOPIC"},
@@ -240,10 +425,124 @@ func main() {
 						EnvVars:  []string{"MSN_EXCEPTION_FILE_PATH"},
 						Required: true,
 					},
+					&cli.DurationFlag{
+						Name:    msnExceptionReloadInterval,
+						Usage:   "How often the meter serial number exception file is checked for changes and expired exceptions",
+						EnvVars: []string{"MSN_EXCEPTION_RELOAD_INTERVAL"},
+						Value:   time.Minute,
+					},
+					&cli.StringSliceFlag{
+						Name:    psrSpecialistVisitCodes,
+						Usage:   "PSR codes for which the customer requires a specialist visit and is not eligible for smart booking",
+						EnvVars: []string{"PSR_SPECIALIST_VISIT_CODES"},
+					},
+					&cli.StringFlag{
+						Name:    eligibilityRulesFilePath,
+						Usage:   "Path to the YAML or JSON eligibility rule set, the default rule set is used if not provided",
+						EnvVars: []string{"ELIGIBILITY_RULES_FILE_PATH"},
+					},
+					&cli.StringFlag{
+						Name:    unsupportedSSCFilePath,
+						Usage:   "Path to the TSV of unsupported SSCs per profile class, the default list is used if not provided",
+						EnvVars: []string{"UNSUPPORTED_SSC_FILE_PATH"},
+					},
+					&cli.StringFlag{
+						Name:    standardMeterCapacityFilePath,
+						Usage:   "Path to the list of standard gas meter capacities, the default list is used if not provided",
+						EnvVars: []string{"STANDARD_METER_CAPACITY_FILE_PATH"},
+					},
+					&cli.DurationFlag{
+						Name:    meterCatalogueReloadInterval,
+						Usage:   "How often the meter catalogue files are checked for changes",
+						EnvVars: []string{"METER_CATALOGUE_RELOAD_INTERVAL"},
+						Value:   time.Minute,
+					},
 				),
 				Before: app.Before,
 				Action: runEvaluator,
 			},
+			{
+				Name:  "validate-rules",
+				Usage: "validate an eligibility rule set file, failing on unknown fields, predicates or reasons",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     eligibilityRulesFilePath,
+						EnvVars:  []string{"ELIGIBILITY_RULES_FILE_PATH"},
+						Required: true,
+					},
+				},
+				Action: runValidateRules,
+			},
+			{
+				Name:  "dry-run",
+				Usage: "evaluate all live occupancies against a candidate rule set or meter catalogue without publishing and report the differences",
+				Flags: app.DefaultFlags().WithCustom(
+					&cli.StringFlag{
+						Name:     postgresDSN,
+						EnvVars:  []string{"POSTGRES_DSN"},
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     msnExceptionFilePath,
+						EnvVars:  []string{"MSN_EXCEPTION_FILE_PATH"},
+						Required: true,
+					},
+					&cli.StringSliceFlag{
+						Name:    psrSpecialistVisitCodes,
+						Usage:   "PSR codes for which the customer requires a specialist visit and is not eligible for smart booking",
+						EnvVars: []string{"PSR_SPECIALIST_VISIT_CODES"},
+					},
+					&cli.StringFlag{
+						Name:    eligibilityRulesFilePath,
+						Usage:   "Path to the rule set currently in use, the default rule set is used if not provided",
+						EnvVars: []string{"ELIGIBILITY_RULES_FILE_PATH"},
+					},
+					&cli.StringFlag{
+						Name:    unsupportedSSCFilePath,
+						Usage:   "Path to the TSV of unsupported SSCs per profile class, the default list is used if not provided",
+						EnvVars: []string{"UNSUPPORTED_SSC_FILE_PATH"},
+					},
+					&cli.StringFlag{
+						Name:    standardMeterCapacityFilePath,
+						Usage:   "Path to the list of standard gas meter capacities, the default list is used if not provided",
+						EnvVars: []string{"STANDARD_METER_CAPACITY_FILE_PATH"},
+					},
+					&cli.StringFlag{
+						Name:    candidateRulesFilePath,
+						Usage:   "Path to the YAML or JSON candidate rule set, the rule set in use is used if not provided",
+						EnvVars: []string{"CANDIDATE_RULES_FILE_PATH"},
+					},
+					&cli.StringFlag{
+						Name:    candidateUnsupportedSSCFilePath,
+						Usage:   "Path to the candidate TSV of unsupported SSCs per profile class, the list in use is used if not provided",
+						EnvVars: []string{"CANDIDATE_UNSUPPORTED_SSC_FILE_PATH"},
+					},
+					&cli.StringFlag{
+						Name:    candidateStandardMeterCapacityFilePath,
+						Usage:   "Path to the candidate list of standard gas meter capacities, the list in use is used if not provided",
+						EnvVars: []string{"CANDIDATE_STANDARD_METER_CAPACITY_FILE_PATH"},
+					},
+					&cli.StringFlag{
+						Name:    dryRunReportFilePath,
+						Usage:   "Path of the JSON report with the counts of status changes and reasons gained or lost",
+						EnvVars: []string{"DRY_RUN_REPORT_FILE_PATH"},
+						Value:   "dry_run_report.json",
+					},
+					&cli.StringFlag{
+						Name:    dryRunChangesFilePath,
+						Usage:   "Path of the CSV with the occupancies changing status",
+						EnvVars: []string{"DRY_RUN_CHANGES_FILE_PATH"},
+						Value:   "dry_run_changes.csv",
+					},
+					&cli.IntFlag{
+						Name:    dryRunWorkers,
+						EnvVars: []string{"DRY_RUN_WORKERS"},
+						Value:   10,
+					},
+				),
+				Before: app.Before,
+				Action: runDryRun,
+			},
 			{
 				Name: "projector",
 				Flags: app.DefaultFlags().WithKafkaRequired().WithCustom(
@@ -40,6 +40,7 @@ func runProjector(c *cli.Context) error {
 	eligibilityDB := store.NewEligibility(pool)
 	suppliabilityDB := store.NewSuppliability(pool)
 	campaignabilityDB := store.NewCampaignability(pool)
+	evaluationHistoryDB := store.NewEvaluationHistory(pool)
 
 	g, ctx := errgroup.WithContext(ctx)
 
@@ -70,15 +71,15 @@ func runProjector(c *cli.Context) error {
 
 	g.Go(func() error {
 		defer slog.Info("eligibility events consumer finished")
-		return substratemessage.BatchConsumer(ctx, c.Int(batchSize), time.Second, eligibilitySource, consumer.HandleEligibility(eligibilityDB))
+		return substratemessage.BatchConsumer(ctx, c.Int(batchSize), time.Second, eligibilitySource, consumer.HandleEligibility(eligibilityDB, evaluationHistoryDB))
 	})
 	g.Go(func() error {
 		defer slog.Info("suppliability events consumer finished")
-		return substratemessage.BatchConsumer(ctx, c.Int(batchSize), time.Second, suppliabilitySource, consumer.HandleSuppliability(suppliabilityDB))
+		return substratemessage.BatchConsumer(ctx, c.Int(batchSize), time.Second, suppliabilitySource, consumer.HandleSuppliability(suppliabilityDB, evaluationHistoryDB))
 	})
 	g.Go(func() error {
 		defer slog.Info("campaignability events consumer finished")
-		return substratemessage.BatchConsumer(ctx, c.Int(batchSize), time.Second, campaignabilitySource, consumer.HandleCampaignability(campaignabilityDB))
+		return substratemessage.BatchConsumer(ctx, c.Int(batchSize), time.Second, campaignabilitySource, consumer.HandleCampaignability(campaignabilityDB, evaluationHistoryDB))
 	})
 
 	sigChan := make(chan os.Signal, 1)
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/urfave/cli/v2"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/eligibility/internal/evaluation"
+)
+
+func runValidateRules(c *cli.Context) error {
+	ruleSet, err := evaluation.LoadRuleSet(c.String(eligibilityRulesFilePath))
+	if err != nil {
+		return fmt.Errorf("rule set %s is not valid: %w", c.String(eligibilityRulesFilePath), err)
+	}
+
+	slog.Info("rule set is valid",
+		"path", c.String(eligibilityRulesFilePath),
+		"version", ruleSet.Version,
+		"campaignability_rules", len(ruleSet.Campaignability),
+		"eligibility_rules", len(ruleSet.Eligibility),
+		"suppliability_rules", len(ruleSet.Suppliability))
+
+	return nil
+}
+
+// loadRuleSet returns the rule set configured for the command, or the default one if none is provided.
+func loadRuleSet(c *cli.Context) (*evaluation.RuleSet, error) {
+	ruleSet := evaluation.DefaultRuleSet()
+	if path := c.String(eligibilityRulesFilePath); path != "" {
+		var err error
+		ruleSet, err = evaluation.LoadRuleSet(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load eligibility rule set, %w", err)
+		}
+	}
+	slog.Info("loaded eligibility rule set", "version", ruleSet.Version)
+
+	return ruleSet, nil
+}
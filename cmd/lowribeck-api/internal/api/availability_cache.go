@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/lowribeck-api/internal/lowribeck"
+	"github.com/utilitywarehouse/energy-smart-booking/cmd/lowribeck-api/internal/metrics"
+	"google.golang.org/protobuf/proto"
+)
+
+// AvailabilityCache keeps the slots LowriBeck returned for a short time, so that customers paging
+// back and forth through the calendar don't call getCalendarAvailability every time. The entries
+// are tagged with the booking reference or meterpoints they were requested for, which allows
+// dropping them once a booking changes the availability. The cache is per replica and the other
+// replicas are not told about the invalidations, so their entries can be stale for up to the ttl.
+type AvailabilityCache struct {
+	ttl  time.Duration
+	size int
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]availabilityEntry
+	tagged  map[string]map[string]struct{}
+}
+
+type availabilityEntry struct {
+	value     proto.Message
+	tags      []string
+	latency   time.Duration
+	expiresAt time.Time
+}
+
+// NewAvailabilityCache creates a cache holding up to size entries for ttl.
+func NewAvailabilityCache(ttl time.Duration, size int) *AvailabilityCache {
+	if size < 1 {
+		size = 1
+	}
+	return &AvailabilityCache{
+		ttl:     ttl,
+		size:    size,
+		now:     time.Now,
+		entries: map[string]availabilityEntry{},
+		tagged:  map[string]map[string]struct{}{},
+	}
+}
+
+func (c *AvailabilityCache) get(key string) (proto.Message, time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, 0, false
+	}
+	if !c.now().Before(entry.expiresAt) {
+		c.remove(key)
+		return nil, 0, false
+	}
+
+	return proto.Clone(entry.value), entry.latency, true
+}
+
+func (c *AvailabilityCache) set(key string, value proto.Message, latency time.Duration, tags []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.size {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				c.remove(k)
+			}
+		}
+		if len(c.entries) >= c.size {
+			// the entries are short lived, the new one is simply not cached until some expire
+			return
+		}
+	}
+
+	c.remove(key)
+	c.entries[key] = availabilityEntry{
+		value:     proto.Clone(value),
+		tags:      tags,
+		latency:   latency,
+		expiresAt: now.Add(c.ttl),
+	}
+	for _, tag := range tags {
+		if c.tagged[tag] == nil {
+			c.tagged[tag] = map[string]struct{}{}
+		}
+		c.tagged[tag][key] = struct{}{}
+	}
+}
+
+// Invalidate drops the entries requested for any of the tags.
+func (c *AvailabilityCache) Invalidate(tags ...string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, tag := range tags {
+		for key := range c.tagged[tag] {
+			c.remove(key)
+		}
+	}
+}
+
+// remove drops an entry and its tags, c.mu must be held.
+func (c *AvailabilityCache) remove(key string) {
+	entry, ok := c.entries[key]
+	if !ok {
+		return
+	}
+	delete(c.entries, key)
+	for _, tag := range entry.tags {
+		delete(c.tagged[tag], key)
+		if len(c.tagged[tag]) == 0 {
+			delete(c.tagged, tag)
+		}
+	}
+}
+
+func referenceTag(reference string) string {
+	return "reference:" + reference
+}
+
+func mpanTag(mpan string) string {
+	return "mpan:" + mpan
+}
+
+func mprnTag(mprn string) string {
+	return "mprn:" + mprn
+}
+
+func availabilityKey(req *lowribeck.GetCalendarAvailabilityRequest) string {
+	return fmt.Sprintf("%s|%s|%s|%s|%s|%s", req.ReferenceID, req.PostCode, req.Mpan, req.Mprn, req.ElecJobTypeCode, req.GasJobTypeCode)
+}
+
+// pointOfSaleTags returns the tags of the meterpoints of a point of sale request.
+func pointOfSaleTags(mpan, mprn string) []string {
+	tags := []string{mpanTag(mpan)}
+	if mprn != "" {
+		tags = append(tags, mprnTag(mprn))
+	}
+	return tags
+}
+
+// cachedAvailability returns the cached slots of the request or fetches and caches them, only
+// successful responses are cached. A nil cache fetches every time.
+func cachedAvailability[T proto.Message](ctx context.Context, c *AvailabilityCache, endpoint string, req *lowribeck.GetCalendarAvailabilityRequest, tags []string, fetch func(context.Context) (T, error)) (T, error) {
+	if c == nil {
+		return fetch(ctx)
+	}
+
+	key := availabilityKey(req)
+	if value, latency, ok := c.get(key); ok {
+		if cached, ok := value.(T); ok {
+			metrics.AvailabilityCacheRequests.WithLabelValues(endpoint, "hit").Inc()
+			metrics.AvailabilityCacheLatencySaved.WithLabelValues(endpoint).Add(latency.Seconds())
+			return cached, nil
+		}
+	}
+	metrics.AvailabilityCacheRequests.WithLabelValues(endpoint, "miss").Inc()
+
+	start := time.Now()
+	value, err := fetch(ctx)
+	if err != nil {
+		return value, err
+	}
+	c.set(key, value, time.Since(start), tags)
+
+	return value, nil
+}
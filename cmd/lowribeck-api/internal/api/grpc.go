@@ -31,6 +31,7 @@ type Client interface {
 	GetCalendarAvailability(context.Context, *lowribeck.GetCalendarAvailabilityRequest) (*lowribeck.GetCalendarAvailabilityResponse, error)
 	CreateBooking(context.Context, *lowribeck.CreateBookingRequest) (*lowribeck.CreateBookingResponse, error)
 	UpdateContactDetails(context.Context, *lowribeck.UpdateContactDetailsRequest) (*lowribeck.UpdateContactDetailsResponse, error)
+	CancelBooking(context.Context, *lowribeck.CancelBookingRequest) (*lowribeck.CancelBookingResponse, error)
 
 	// Point Of Sale Methods
 	GetCalendarAvailabilityPointOfSale(context.Context, *lowribeck.GetCalendarAvailabilityRequest) (*lowribeck.GetCalendarAvailabilityResponse, error)
@@ -44,6 +45,8 @@ type Mapper interface {
 	BookingResponse(*lowribeck.CreateBookingResponse) (*contract.CreateBookingResponse, error)
 	UpdateContactDetailsRequest(uint32, *contract.UpdateContactDetailsRequest) *lowribeck.UpdateContactDetailsRequest
 	UpdateContactDetailsResponse(*lowribeck.UpdateContactDetailsResponse) (*contract.UpdateContactDetailsResponse, error)
+	CancelBookingRequest(uint32, *contract.CancelBookingRequest) *lowribeck.CancelBookingRequest
+	CancelBookingResponse(*lowribeck.CancelBookingResponse) (*contract.CancelBookingResponse, error)
 
 	//Point Of Sale Methods
 	AvailabilityRequestPointOfSale(uint32, *contract.GetAvailableSlotsPointOfSaleRequest) (*lowribeck.GetCalendarAvailabilityRequest, error)
@@ -53,9 +56,10 @@ type Mapper interface {
 }
 
 type LowriBeckAPI struct {
-	client Client
-	mapper Mapper
-	auth   Auth
+	client            Client
+	mapper            Mapper
+	auth              Auth
+	availabilityCache *AvailabilityCache
 	contract.UnimplementedLowriBeckAPIServer
 }
 
@@ -67,6 +71,12 @@ func New(c Client, m Mapper, a Auth) *LowriBeckAPI {
 	}
 }
 
+// WithAvailabilityCache caches the available slots, which are otherwise requested from LowriBeck every time.
+func (l *LowriBeckAPI) WithAvailabilityCache(c *AvailabilityCache) *LowriBeckAPI {
+	l.availabilityCache = c
+	return l
+}
+
 func (l *LowriBeckAPI) GetAvailableSlots(ctx context.Context, req *contract.GetAvailableSlotsRequest) (*contract.GetAvailableSlotsResponse, error) {
 
 	err := l.validateCredentials(ctx, auth.GetAction)
@@ -81,18 +91,22 @@ func (l *LowriBeckAPI) GetAvailableSlots(ctx context.Context, req *contract.GetA
 
 	requestID := uuid.New().ID()
 	availabilityReq := l.mapper.AvailabilityRequest(requestID, req)
-	resp, err := l.client.GetCalendarAvailability(ctx, availabilityReq)
-	if err != nil {
-		slog.Error("error making get available slots request", "error", err, "request_id", requestID, "reference", req.GetReference(), "postcode", req.GetPostcode())
-		return nil, status.Errorf(codes.Internal, "error making get available slots request: %v", err)
-	}
 
-	mappedResp, mappedErr := l.mapper.AvailableSlotsResponse(resp)
-	if mappedErr != nil {
-		slog.Error("error in get available slots response", "error", mappedErr, "request_id", requestID, "reference", req.GetReference(), "postcode", req.GetPostcode())
-		return nil, getStatusFromError("error making get available slots request: %v", metrics.GetAvailableSlots, mappedErr)
-	}
-	return mappedResp, nil
+	return cachedAvailability(ctx, l.availabilityCache, metrics.GetAvailableSlots, availabilityReq, []string{referenceTag(availabilityReq.ReferenceID)},
+		func(ctx context.Context) (*contract.GetAvailableSlotsResponse, error) {
+			resp, err := l.client.GetCalendarAvailability(ctx, availabilityReq)
+			if err != nil {
+				slog.Error("error making get available slots request", "error", err, "request_id", requestID, "reference", req.GetReference(), "postcode", req.GetPostcode())
+				return nil, status.Errorf(codes.Internal, "error making get available slots request: %v", err)
+			}
+
+			mappedResp, mappedErr := l.mapper.AvailableSlotsResponse(resp)
+			if mappedErr != nil {
+				slog.Error("error in get available slots response", "error", mappedErr, "request_id", requestID, "reference", req.GetReference(), "postcode", req.GetPostcode())
+				return nil, getStatusFromError("error making get available slots request: %v", metrics.GetAvailableSlots, mappedErr)
+			}
+			return mappedResp, nil
+		})
 }
 
 func (l *LowriBeckAPI) CreateBooking(ctx context.Context, req *contract.CreateBookingRequest) (*contract.CreateBookingResponse, error) {
@@ -124,6 +138,9 @@ func (l *LowriBeckAPI) CreateBooking(ctx context.Context, req *contract.CreateBo
 		slog.Error("error in booking response", "request_id", requestID, "reference", req.GetReference(), "postcode", req.GetPostcode(), "error", mappedErr)
 		return nil, getStatusFromError("error making booking request: %v", metrics.CreateBooking, mappedErr)
 	}
+	// the booked slot is no longer available
+	l.availabilityCache.Invalidate(referenceTag(bookingReq.ReferenceID))
+
 	return mappedResp, nil
 }
 
@@ -142,25 +159,31 @@ func (l *LowriBeckAPI) GetAvailableSlotsPointOfSale(ctx context.Context, req *co
 	requestID := uuid.New().ID()
 	availableSlotsRequest, err := l.mapper.AvailabilityRequestPointOfSale(requestID, req)
 	if err != nil {
+		slog.Error("error mapping get available slots point of sale request", "mpan", req.Mpan, "mprn", req.Mprn, "electricity_tariff", req.ElectricityTariffType.String(), "gas_tariff", req.GasTariffType.String(), "postcode", req.GetPostcode(), "error", err)
 		if errors.Is(err, mapper.ErrInvalidElectricityTariffType) ||
 			errors.Is(err, mapper.ErrInvalidGasTariffType) {
-			slog.Error("error mapping booking point of sale request", "mpan", req.Mpan, "mprn", req.Mprn, "electricity_tariff", req.ElectricityTariffType.String(), "gas_tariff", req.GasTariffType.String(), "postcode", req.GetPostcode(), "error", err)
 			return nil, status.Errorf(codes.Internal, "error making get available slots point of sale: %v", err)
 		}
+		return nil, getStatusFromError("error mapping get available slots point of sale request: %v", metrics.GetAvailableSlots, err)
 	}
 
-	resp, err := l.client.GetCalendarAvailabilityPointOfSale(ctx, availableSlotsRequest)
-	if err != nil {
-		slog.Error("error making get available slots for point of sale", "request_id", requestID, "mpan", req.Mpan, "mprn", req.Mprn, "electricity_tariff", req.ElectricityTariffType.String(), "gas_tariff", req.GasTariffType.String(), "postcode", req.GetPostcode(), "error", err)
-		return nil, status.Errorf(codes.Internal, "error making get available slots point of sale request: %v", err)
-	}
+	tags := pointOfSaleTags(availableSlotsRequest.Mpan, availableSlotsRequest.Mprn)
 
-	mappedResp, mappedErr := l.mapper.AvailableSlotsPointOfSaleResponse(resp)
-	if mappedErr != nil {
-		slog.Error("error in get available slots for point of sale", "request_id", requestID, "mpan", req.Mpan, "mprn", req.Mprn, "electricity_tariff", req.ElectricityTariffType.String(), "gas_tariff", req.GasTariffType.String(), "postcode", req.GetPostcode(), "error", mappedErr)
-		return nil, getStatusFromError("error making get available slots point of sale request: %v", metrics.GetAvailableSlots, mappedErr)
-	}
-	return mappedResp, nil
+	return cachedAvailability(ctx, l.availabilityCache, metrics.GetAvailableSlotsPOS, availableSlotsRequest, tags,
+		func(ctx context.Context) (*contract.GetAvailableSlotsPointOfSaleResponse, error) {
+			resp, err := l.client.GetCalendarAvailabilityPointOfSale(ctx, availableSlotsRequest)
+			if err != nil {
+				slog.Error("error making get available slots for point of sale", "request_id", requestID, "mpan", req.Mpan, "mprn", req.Mprn, "electricity_tariff", req.ElectricityTariffType.String(), "gas_tariff", req.GasTariffType.String(), "postcode", req.GetPostcode(), "error", err)
+				return nil, status.Errorf(codes.Internal, "error making get available slots point of sale request: %v", err)
+			}
+
+			mappedResp, mappedErr := l.mapper.AvailableSlotsPointOfSaleResponse(resp)
+			if mappedErr != nil {
+				slog.Error("error in get available slots for point of sale", "request_id", requestID, "mpan", req.Mpan, "mprn", req.Mprn, "electricity_tariff", req.ElectricityTariffType.String(), "gas_tariff", req.GasTariffType.String(), "postcode", req.GetPostcode(), "error", mappedErr)
+				return nil, getStatusFromError("error making get available slots point of sale request: %v", metrics.GetAvailableSlots, mappedErr)
+			}
+			return mappedResp, nil
+		})
 }
 
 func (l *LowriBeckAPI) CreateBookingPointOfSale(ctx context.Context, req *contract.CreateBookingPointOfSaleRequest) (*contract.CreateBookingPointOfSaleResponse, error) {
@@ -183,6 +206,9 @@ func (l *LowriBeckAPI) CreateBookingPointOfSale(ctx context.Context, req *contra
 			errors.Is(err, mapper.ErrInvalidGasTariffType) {
 			return nil, status.Errorf(codes.Internal, "error mapping point of sale booking request: %v", err)
 		}
+		if invErr, ok := err.(*mapper.InvalidRequestError); ok {
+			return nil, getStatusFromError("error mapping point of sale booking request: %v", metrics.CreateBooking, invErr)
+		}
 		return nil, status.Errorf(codes.InvalidArgument, "error mapping point of sale booking request: %v", err)
 	}
 	resp, err := l.client.CreateBookingPointOfSale(ctx, bookingReq)
@@ -196,6 +222,8 @@ func (l *LowriBeckAPI) CreateBookingPointOfSale(ctx context.Context, req *contra
 		slog.Error("error in booking point of sale request", "request_id", requestID, "mpan", req.Mpan, "mprn", req.Mprn, "elec_tariff", req.ElectricityTariffType.String(), "gas_tariff", req.GasTariffType.String(), "postcode", req.SiteAddress.Paf.GetPostcode(), "error", mappedErr)
 		return nil, getStatusFromError("error making booking point of sale request: %v", metrics.CreateBooking, mappedErr)
 	}
+	l.availabilityCache.Invalidate(pointOfSaleTags(bookingReq.Mpan, bookingReq.Mprn)...)
+
 	return mappedResp, nil
 }
 
@@ -228,6 +256,38 @@ func (l *LowriBeckAPI) UpdateContactDetails(ctx context.Context, req *contract.U
 	return mappedResp, nil
 }
 
+func (l *LowriBeckAPI) CancelBooking(ctx context.Context, req *contract.CancelBookingRequest) (*contract.CancelBookingResponse, error) {
+
+	err := l.validateCredentials(ctx, auth.UpdateAction)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrUserUnauthorised):
+			return nil, status.Errorf(codes.PermissionDenied, "user does not have access to this action, %s", err)
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to validate credentials")
+		}
+	}
+
+	requestID := uuid.New().ID()
+	cancelReq := l.mapper.CancelBookingRequest(requestID, req)
+
+	resp, err := l.client.CancelBooking(ctx, cancelReq)
+	if err != nil {
+		slog.Error("error making cancel booking request", "request_id", requestID, "reference", req.GetReference(), "error", err)
+		return nil, status.Errorf(codes.Internal, "error making cancel booking request: %v", err)
+	}
+
+	mappedResp, mappedErr := l.mapper.CancelBookingResponse(resp)
+	if mappedErr != nil {
+		slog.Error("error in cancel booking response", "request_id", requestID, "reference", req.GetReference(), "error", mappedErr)
+		return nil, getStatusFromError("error making cancel booking request: %v", metrics.CancelBooking, mappedErr)
+	}
+	// the cancelled slot is available again
+	l.availabilityCache.Invalidate(referenceTag(cancelReq.ReferenceID))
+
+	return mappedResp, nil
+}
+
 func createInvalidRequestError(msg, endpoint string, invErr *mapper.InvalidRequestError) (error, error) {
 	var param contract.Parameters
 	switch invErr.GetParameter() {
@@ -275,6 +335,10 @@ func getStatusFromError(formatMessage, endpoint string, err error) error {
 		metrics.LBErrorsCount.WithLabelValues(metrics.AppointmentAlreadyExists, endpoint).Inc()
 		return status.Errorf(codes.AlreadyExists, formatMessage, err)
 
+	case errors.Is(err, mapper.ErrAppointmentAlreadyCancelled):
+		metrics.LBErrorsCount.WithLabelValues(metrics.AppointmentAlreadyCancelled, endpoint).Inc()
+		return status.Errorf(codes.FailedPrecondition, formatMessage, err)
+
 	case errors.Is(err, mapper.ErrAppointmentOutOfRange):
 		metrics.LBErrorsCount.WithLabelValues(metrics.AppointmentOutOfRange, endpoint).Inc()
 		return status.Errorf(codes.OutOfRange, formatMessage, err)
@@ -305,6 +305,87 @@ func Test_GetAvailableSlots_Unauthorised(t *testing.T) {
 	}
 }
 
+func Test_GetAvailableSlots_Cached(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	defer ctrl.Finish()
+
+	client := mocks.NewMockClient(ctrl)
+	mAuth := mocks.NewMockAuth(ctrl)
+	mapper := &fakeMapper{}
+
+	myAPIHandler := api.New(client, mapper, mAuth).WithAvailabilityCache(api.NewAvailabilityCache(time.Minute, 10))
+
+	mAuth.EXPECT().Authorize(ctx, gomock.Any()).Return(true, nil).AnyTimes()
+
+	mapper.availabilityRequest = &lowribeck.GetCalendarAvailabilityRequest{
+		PostCode:    "postcode",
+		ReferenceID: "reference",
+	}
+	mapper.availabilityResponse = &contract.GetAvailableSlotsResponse{
+		Slots: []*contract.BookingSlot{
+			{
+				Date: &date.Date{
+					Year:  2023,
+					Month: 10,
+					Day:   1,
+				},
+				StartTime: 10,
+				EndTime:   12,
+			},
+		},
+	}
+	mapper.bookingRequest = &lowribeck.CreateBookingRequest{
+		PostCode:    "postcode",
+		ReferenceID: "reference",
+	}
+	mapper.bookingResponse = &contract.CreateBookingResponse{
+		Success: true,
+	}
+
+	req := &contract.GetAvailableSlotsRequest{
+		Postcode:  "postcode",
+		Reference: "reference",
+	}
+
+	// the second call is answered by the cache
+	client.EXPECT().GetCalendarAvailability(ctx, mapper.availabilityRequest).Return(&lowribeck.GetCalendarAvailabilityResponse{}, nil).Times(1)
+
+	for range 2 {
+		result, err := myAPIHandler.GetAvailableSlots(ctx, req)
+		assert.NoError(err)
+		assert.Empty(cmp.Diff(mapper.availabilityResponse, result, protocmp.Transform()))
+	}
+
+	// a booking for the reference drops its cached slots
+	client.EXPECT().CreateBooking(ctx, mapper.bookingRequest).Return(&lowribeck.CreateBookingResponse{}, nil)
+	_, err := myAPIHandler.CreateBooking(ctx, &contract.CreateBookingRequest{
+		Postcode:  "postcode",
+		Reference: "reference",
+	})
+	assert.NoError(err)
+
+	client.EXPECT().GetCalendarAvailability(ctx, mapper.availabilityRequest).Return(&lowribeck.GetCalendarAvailabilityResponse{}, nil).Times(1)
+	_, err = myAPIHandler.GetAvailableSlots(ctx, req)
+	assert.NoError(err)
+
+	// errors are not cached
+	mapper.availabilityError = errOops
+	client.EXPECT().CreateBooking(ctx, mapper.bookingRequest).Return(&lowribeck.CreateBookingResponse{}, nil)
+	_, err = myAPIHandler.CreateBooking(ctx, &contract.CreateBookingRequest{
+		Postcode:  "postcode",
+		Reference: "reference",
+	})
+	assert.NoError(err)
+
+	client.EXPECT().GetCalendarAvailability(ctx, mapper.availabilityRequest).Return(&lowribeck.GetCalendarAvailabilityResponse{}, nil).Times(2)
+	for range 2 {
+		_, err = myAPIHandler.GetAvailableSlots(ctx, req)
+		assert.Error(err)
+	}
+}
+
 func Test_CreateBooking(t *testing.T) {
 	now := time.Now().UTC().Format("02/01/2006 15:04:05")
 
@@ -1596,6 +1677,235 @@ func Test_UpdateContactDetails_Unauthorised(t *testing.T) {
 	}
 }
 
+func Test_CancelBooking(t *testing.T) {
+	now := time.Now().UTC().Format("02/01/2006 15:04:05")
+
+	testCases := []struct {
+		desc          string
+		req           *lowribeck.CancelBookingRequest
+		clientResp    *lowribeck.CancelBookingResponse
+		mapperErr     error
+		expected      *contract.CancelBookingResponse
+		expectedError error
+		setup         func(context.Context, *mocks.MockAuth)
+	}{
+		{
+			desc: "Valid",
+			req: &lowribeck.CancelBookingRequest{
+				ReferenceID: "reference",
+				CreatedDate: now,
+			},
+			clientResp: &lowribeck.CancelBookingResponse{
+				ResponseCode: "C01",
+			},
+			expected: &contract.CancelBookingResponse{
+				Success: true,
+			},
+			setup: func(ctx context.Context, mAuth *mocks.MockAuth) {
+				mAuth.EXPECT().Authorize(ctx,
+					&auth.PolicyParams{
+						Action:     "update",
+						Resource:   "uw.energy.v1.lowribeck-wrapper-api",
+						ResourceID: "lowribeck-api",
+					}).Return(true, nil)
+			},
+		},
+		{
+			desc:          "Invalid reference",
+			mapperErr:     mapper.NewInvalidRequestError(mapper.InvalidReference),
+			expectedError: status.Error(codes.InvalidArgument, "error making cancel booking request: invalid request [reference]"),
+			setup: func(ctx context.Context, mAuth *mocks.MockAuth) {
+				mAuth.EXPECT().Authorize(ctx,
+					&auth.PolicyParams{
+						Action:     "update",
+						Resource:   "uw.energy.v1.lowribeck-wrapper-api",
+						ResourceID: "lowribeck-api",
+					}).Return(true, nil)
+			},
+		},
+		{
+			desc:          "Appointment not found",
+			mapperErr:     mapper.ErrAppointmentNotFound,
+			expectedError: status.Error(codes.NotFound, "error making cancel booking request: no appointments found"),
+			setup: func(ctx context.Context, mAuth *mocks.MockAuth) {
+				mAuth.EXPECT().Authorize(ctx,
+					&auth.PolicyParams{
+						Action:     "update",
+						Resource:   "uw.energy.v1.lowribeck-wrapper-api",
+						ResourceID: "lowribeck-api",
+					}).Return(true, nil)
+			},
+		},
+		{
+			desc:          "Appointment already cancelled",
+			mapperErr:     mapper.ErrAppointmentAlreadyCancelled,
+			expectedError: status.Error(codes.FailedPrecondition, "error making cancel booking request: appointment already cancelled"),
+			setup: func(ctx context.Context, mAuth *mocks.MockAuth) {
+				mAuth.EXPECT().Authorize(ctx,
+					&auth.PolicyParams{
+						Action:     "update",
+						Resource:   "uw.energy.v1.lowribeck-wrapper-api",
+						ResourceID: "lowribeck-api",
+					}).Return(true, nil)
+			},
+		},
+		{
+			desc:          "Appointment out of range",
+			mapperErr:     mapper.ErrAppointmentOutOfRange,
+			expectedError: status.Error(codes.OutOfRange, "error making cancel booking request: appointment out of range"),
+			setup: func(ctx context.Context, mAuth *mocks.MockAuth) {
+				mAuth.EXPECT().Authorize(ctx,
+					&auth.PolicyParams{
+						Action:     "update",
+						Resource:   "uw.energy.v1.lowribeck-wrapper-api",
+						ResourceID: "lowribeck-api",
+					}).Return(true, nil)
+			},
+		},
+		{
+			desc:          "Unknown error",
+			mapperErr:     mapper.ErrUnknownError,
+			expectedError: status.Error(codes.Internal, "error making cancel booking request: unknown error"),
+			setup: func(ctx context.Context, mAuth *mocks.MockAuth) {
+				mAuth.EXPECT().Authorize(ctx,
+					&auth.PolicyParams{
+						Action:     "update",
+						Resource:   "uw.energy.v1.lowribeck-wrapper-api",
+						ResourceID: "lowribeck-api",
+					}).Return(true, nil)
+			},
+		},
+	}
+
+	assert := assert.New(t)
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	defer ctrl.Finish()
+
+	client := mocks.NewMockClient(ctrl)
+	mAuth := mocks.NewMockAuth(ctrl)
+	mapper := &fakeMapper{}
+
+	myAPIHandler := api.New(client, mapper, mAuth)
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(_ *testing.T) {
+			mapper.cancelBookingRequest = tc.req
+			mapper.cancelBookingResponse = tc.expected
+			mapper.cancelBookingError = tc.mapperErr
+
+			tc.setup(ctx, mAuth)
+
+			client.EXPECT().CancelBooking(ctx, tc.req).Return(tc.clientResp, nil)
+
+			result, err := myAPIHandler.CancelBooking(ctx, &contract.CancelBookingRequest{
+				Reference: "reference",
+			})
+
+			if tc.expectedError == nil {
+				assert.NoError(err, tc.desc)
+				diff := cmp.Diff(tc.expected, result, protocmp.Transform(), cmpopts.IgnoreUnexported())
+				assert.Empty(diff, tc.desc)
+			} else {
+				assert.EqualError(err, tc.expectedError.Error(), tc.desc)
+			}
+		})
+	}
+}
+
+func Test_CancelBooking_ClientError(t *testing.T) {
+	assert := assert.New(t)
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	defer ctrl.Finish()
+
+	client := mocks.NewMockClient(ctrl)
+	mAuth := mocks.NewMockAuth(ctrl)
+	mapper := &fakeMapper{}
+
+	myAPIHandler := api.New(client, mapper, mAuth)
+
+	errorMessage := "received status code [500] (expected 200): Internal error has occurred, could not complete appointmentManagement Cancel request. The error has been logged."
+
+	mAuth.EXPECT().Authorize(ctx,
+		&auth.PolicyParams{
+			Action:     "update",
+			Resource:   "uw.energy.v1.lowribeck-wrapper-api",
+			ResourceID: "lowribeck-api",
+		}).Return(true, nil)
+
+	req := &lowribeck.CancelBookingRequest{
+		ReferenceID: "reference",
+		CreatedDate: time.Now().UTC().Format("02/01/2006 15:04:05"),
+	}
+	mapper.cancelBookingRequest = req
+
+	client.EXPECT().CancelBooking(ctx, req).Return(nil, errors.New(errorMessage))
+
+	_, err := myAPIHandler.CancelBooking(ctx, &contract.CancelBookingRequest{
+		Reference: "reference",
+	})
+
+	assert.EqualError(err, "rpc error: code = Internal desc = error making cancel booking request: "+errorMessage)
+}
+
+func Test_CancelBooking_Unauthorised(t *testing.T) {
+
+	testCases := []struct {
+		desc          string
+		expectedError error
+		setup         func(context.Context, *mocks.MockAuth)
+	}{
+		{
+			desc:          "Unauthorised",
+			expectedError: status.Errorf(codes.PermissionDenied, "user does not have access to this action, %s", api.ErrUserUnauthorised),
+			setup: func(ctx context.Context, mAuth *mocks.MockAuth) {
+				mAuth.EXPECT().Authorize(ctx,
+					&auth.PolicyParams{
+						Action:     "update",
+						Resource:   "uw.energy.v1.lowribeck-wrapper-api",
+						ResourceID: "lowribeck-api",
+					}).Return(false, nil)
+			},
+		},
+		{
+			desc:          "Internal error",
+			expectedError: status.Error(codes.Internal, "failed to validate credentials"),
+			setup: func(ctx context.Context, mAuth *mocks.MockAuth) {
+				mAuth.EXPECT().Authorize(ctx,
+					&auth.PolicyParams{
+						Action:     "update",
+						Resource:   "uw.energy.v1.lowribeck-wrapper-api",
+						ResourceID: "lowribeck-api",
+					}).Return(false, errOops)
+			},
+		},
+	}
+
+	assert := assert.New(t)
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	defer ctrl.Finish()
+
+	client := mocks.NewMockClient(ctrl)
+	mAuth := mocks.NewMockAuth(ctrl)
+	mapper := &fakeMapper{}
+
+	myAPIHandler := api.New(client, mapper, mAuth)
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(_ *testing.T) {
+			tc.setup(ctx, mAuth)
+
+			_, err := myAPIHandler.CancelBooking(ctx, &contract.CancelBookingRequest{
+				Reference: "reference",
+			})
+
+			assert.EqualError(err, tc.expectedError.Error(), tc.desc)
+		})
+	}
+}
+
 type fakeMapper struct {
 	availabilityRequest  *lowribeck.GetCalendarAvailabilityRequest
 	availabilityResponse *contract.GetAvailableSlotsResponse
@@ -1608,6 +1918,10 @@ type fakeMapper struct {
 	updateContactResponse *contract.UpdateContactDetailsResponse
 	updateContactError    error
 
+	cancelBookingRequest  *lowribeck.CancelBookingRequest
+	cancelBookingResponse *contract.CancelBookingResponse
+	cancelBookingError    error
+
 	availabilityPointOfSaleResponse *contract.GetAvailableSlotsPointOfSaleResponse
 	bookingPointOfSaleResponse      *contract.CreateBookingPointOfSaleResponse
 }
@@ -1663,3 +1977,13 @@ func (f *fakeMapper) UpdateContactDetailsResponse(_ *lowribeck.UpdateContactDeta
 	}
 	return f.updateContactResponse, nil
 }
+
+func (f *fakeMapper) CancelBookingRequest(_ uint32, _ *contract.CancelBookingRequest) *lowribeck.CancelBookingRequest {
+	return f.cancelBookingRequest
+}
+func (f *fakeMapper) CancelBookingResponse(_ *lowribeck.CancelBookingResponse) (*contract.CancelBookingResponse, error) {
+	if f.cancelBookingError != nil {
+		return nil, f.cancelBookingError
+	}
+	return f.cancelBookingResponse, nil
+}
@@ -75,6 +75,21 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// CancelBooking mocks base method.
+func (m *MockClient) CancelBooking(arg0 context.Context, arg1 *lowribeck.CancelBookingRequest) (*lowribeck.CancelBookingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelBooking", arg0, arg1)
+	ret0, _ := ret[0].(*lowribeck.CancelBookingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelBooking indicates an expected call of CancelBooking.
+func (mr *MockClientMockRecorder) CancelBooking(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelBooking", reflect.TypeOf((*MockClient)(nil).CancelBooking), arg0, arg1)
+}
+
 // CreateBooking mocks base method.
 func (m *MockClient) CreateBooking(arg0 context.Context, arg1 *lowribeck.CreateBookingRequest) (*lowribeck.CreateBookingResponse, error) {
 	m.ctrl.T.Helper()
@@ -292,6 +307,35 @@ func (mr *MockMapperMockRecorder) BookingResponsePointOfSale(resp interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BookingResponsePointOfSale", reflect.TypeOf((*MockMapper)(nil).BookingResponsePointOfSale), resp)
 }
 
+// CancelBookingRequest mocks base method.
+func (m *MockMapper) CancelBookingRequest(arg0 uint32, arg1 *lowribeckv1.CancelBookingRequest) *lowribeck.CancelBookingRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelBookingRequest", arg0, arg1)
+	ret0, _ := ret[0].(*lowribeck.CancelBookingRequest)
+	return ret0
+}
+
+// CancelBookingRequest indicates an expected call of CancelBookingRequest.
+func (mr *MockMapperMockRecorder) CancelBookingRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelBookingRequest", reflect.TypeOf((*MockMapper)(nil).CancelBookingRequest), arg0, arg1)
+}
+
+// CancelBookingResponse mocks base method.
+func (m *MockMapper) CancelBookingResponse(arg0 *lowribeck.CancelBookingResponse) (*lowribeckv1.CancelBookingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelBookingResponse", arg0)
+	ret0, _ := ret[0].(*lowribeckv1.CancelBookingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelBookingResponse indicates an expected call of CancelBookingResponse.
+func (mr *MockMapperMockRecorder) CancelBookingResponse(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelBookingResponse", reflect.TypeOf((*MockMapper)(nil).CancelBookingResponse), arg0)
+}
+
 // UpdateContactDetailsRequest mocks base method.
 func (m *MockMapper) UpdateContactDetailsRequest(arg0 uint32, arg1 *lowribeckv1.UpdateContactDetailsRequest) *lowribeck.UpdateContactDetailsRequest {
 	m.ctrl.T.Helper()
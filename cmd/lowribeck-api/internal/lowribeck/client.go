@@ -28,6 +28,7 @@ const (
 	availabilityURL  = "appointmentManagement/getCalendarAvailability"
 	bookingURL       = "appointmentManagement/book"
 	updateContactURL = "appointmentManagement/updateContact"
+	cancelURL        = "appointmentManagement/cancel"
 	healthCheckURL   = "health/get"
 )
 
@@ -212,6 +213,37 @@ func (c *Client) UpdateContactDetails(ctx context.Context, req *UpdateContactDet
 	return &ucr, nil
 }
 
+func (c *Client) CancelBooking(ctx context.Context, req *CancelBookingRequest) (_ *CancelBookingResponse, err error) {
+	ctx, span := tracing.Start(ctx, fmt.Sprintf("LowriBeck.%s", cancelURL),
+		trace.WithAttributes(attribute.String("lowribeck.reference", req.ReferenceID)),
+	)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal request: %w", err)
+	}
+
+	span.AddEvent("request", trace.WithAttributes(attribute.String("req", string(payload))))
+
+	responseBody, err := c.doRequest(ctx, payload, cancelURL)
+	if err != nil {
+		return nil, err
+	}
+
+	span.AddEvent("response", trace.WithAttributes(attribute.String("resp", string(responseBody))))
+
+	var cr CancelBookingResponse
+	if err = json.Unmarshal(responseBody, &cr); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal cancel booking response body: %w", err)
+	}
+
+	return &cr, nil
+}
+
 func (c *Client) doRequest(ctx context.Context, payload []byte, endpoint string) (_ []byte, err error) {
 
 	request, err := http.NewRequestWithContext(
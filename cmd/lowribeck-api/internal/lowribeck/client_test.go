@@ -212,3 +212,39 @@ func Test_UpdateContactDetails(t *testing.T) {
 		t.Fatal(diff)
 	}
 }
+
+func Test_CancelBooking(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/appointmentManagement/cancel" {
+			t.Errorf("Expected to request '/appointmentManagement/cancel', got: %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"ResponseCode": "C01","ResponseMessage": "Cancellation Confirmed"}`))
+	}))
+	defer server.Close()
+
+	client := lowribeck.New(server.Client(), "", "", server.URL+"/")
+
+	assert := assert.New(t)
+
+	expectedResult := &lowribeck.CancelBookingResponse{
+		ResponseCode:    "C01",
+		ResponseMessage: "Cancellation Confirmed",
+	}
+
+	resp, err := client.CancelBooking(context.Background(), &lowribeck.CancelBookingRequest{
+		RequestID:       "req-1",
+		SendingSystem:   "uw",
+		ReceivingSystem: "lb",
+		ReferenceID:     "ref-id-1",
+		CancelReason:    "customer request",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff := cmp.Diff(expectedResult, resp, protocmp.Transform(), cmpopts.IgnoreUnexported())
+	if !assert.Empty(diff) {
+		t.Fatal(diff)
+	}
+}
@@ -114,3 +114,22 @@ type UpdateContactDetailsResponse struct {
 	ResponseMessage string `json:"ResponseMessage,omitempty"`
 	ResponseCode    string `json:"ResponseCode,omitempty"`
 }
+
+type CancelBookingRequest struct {
+	RequestID       string `json:"RequestId,omitempty"`
+	SendingSystem   string `json:"SendingSystem,omitempty"`
+	ReceivingSystem string `json:"ReceivingSystem,omitempty"`
+	CreatedDate     string `json:"CreatedDate,omitempty"`
+	ReferenceID     string `json:"ReferenceId,omitempty"`
+	CancelReason    string `json:"CancelReason,omitempty"`
+}
+
+type CancelBookingResponse struct {
+	RequestID       string `json:"RequestId,omitempty"`
+	ReferenceID     string `json:"ReferenceId,omitempty"`
+	SendingSystem   string `json:"SendingSystem,omitempty"`
+	ReceivingSystem string `json:"ReceivingSystem,omitempty"`
+	CreatedDate     string `json:"CreatedDate,omitempty"`
+	ResponseMessage string `json:"ResponseMessage,omitempty"`
+	ResponseCode    string `json:"ResponseCode,omitempty"`
+}
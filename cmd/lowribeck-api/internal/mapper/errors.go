@@ -9,6 +9,7 @@ var (
 	ErrAppointmentNotFound           = errors.New("no appointments found")
 	ErrAppointmentOutOfRange         = errors.New("appointment out of range")
 	ErrAppointmentAlreadyExists      = errors.New("appointment already exists")
+	ErrAppointmentAlreadyCancelled   = errors.New("appointment already cancelled")
 	ErrInternalError                 = errors.New("internal server error")
 	ErrInvalidJobTypeCode            = errors.New("invalid job type code")
 	ErrInvalidGasJobTypeCode         = errors.New("invalid gas job type code")
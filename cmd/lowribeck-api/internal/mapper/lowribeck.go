@@ -8,6 +8,7 @@ import (
 
 	lowribeckv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/third_party/lowribeck/v1"
 	"github.com/utilitywarehouse/energy-smart-booking/cmd/lowribeck-api/internal/lowribeck"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/validation"
 	"google.golang.org/genproto/googleapis/type/date"
 )
 
@@ -57,6 +58,10 @@ func (lb LowriBeck) AvailabilityRequest(id uint32, req *lowribeckv1.GetAvailable
 }
 
 func (lb LowriBeck) AvailabilityRequestPointOfSale(id uint32, req *lowribeckv1.GetAvailableSlotsPointOfSaleRequest) (*lowribeck.GetCalendarAvailabilityRequest, error) {
+	mpan, mprn, postcode, err := mapMeterpoints(req.GetMpan(), req.GetMprn(), req.GetPostcode())
+	if err != nil {
+		return nil, err
+	}
 
 	elecJobTypeCode, gasJobTypeCode, err := lb.mapTariffTypeToJobType(req.GetElectricityTariffType(), req.GetGasTariffType())
 	if err != nil {
@@ -64,8 +69,8 @@ func (lb LowriBeck) AvailabilityRequestPointOfSale(id uint32, req *lowribeckv1.G
 	}
 
 	request := &lowribeck.GetCalendarAvailabilityRequest{
-		PostCode:        req.GetPostcode(),
-		Mpan:            req.GetMpan(),
+		PostCode:        postcode,
+		Mpan:            mpan,
 		ElecJobTypeCode: elecJobTypeCode,
 		SendingSystem:   lb.sendingSystem,
 		ReceivingSystem: lb.receivingSystem,
@@ -74,8 +79,8 @@ func (lb LowriBeck) AvailabilityRequestPointOfSale(id uint32, req *lowribeckv1.G
 		RequestID: fmt.Sprintf("%d", id),
 	}
 
-	if req.GetMprn() != "" {
-		request.Mprn = req.GetMprn()
+	if mprn != "" {
+		request.Mprn = mprn
 	}
 
 	if req.GetGasTariffType() != lowribeckv1.TariffType_TARIFF_TYPE_UNKNOWN {
@@ -139,6 +144,11 @@ func (lb LowriBeck) BookingRequest(id uint32, req *lowribeckv1.CreateBookingRequ
 }
 
 func (lb LowriBeck) BookingRequestPointOfSale(id uint32, req *lowribeckv1.CreateBookingPointOfSaleRequest) (*lowribeck.CreateBookingRequest, error) {
+	mpan, mprn, postcode, err := mapMeterpoints(req.GetMpan(), req.GetMprn(), req.GetSiteAddress().GetPaf().GetPostcode())
+	if err != nil {
+		return nil, err
+	}
+
 	appDate, appTime, err := mapBookingSlot(req.GetSlot())
 	if err != nil {
 		return nil, err
@@ -158,8 +168,8 @@ func (lb LowriBeck) BookingRequestPointOfSale(id uint32, req *lowribeckv1.Create
 		DependantLocality:       req.SiteAddress.Paf.GetDependentLocality(),
 		PostTown:                req.SiteAddress.Paf.GetPostTown(),
 		County:                  "", // There is no County in the PAF format
-		PostCode:                req.SiteAddress.Paf.GetPostcode(),
-		Mpan:                    req.GetMpan(),
+		PostCode:                postcode,
+		Mpan:                    mpan,
 		ElecJobTypeCode:         elecJobTypeCode,
 		AppointmentDate:         appDate,
 		AppointmentTime:         appTime,
@@ -174,8 +184,8 @@ func (lb LowriBeck) BookingRequestPointOfSale(id uint32, req *lowribeckv1.Create
 		RequestID: fmt.Sprintf("%d", id),
 	}
 
-	if req.GetMprn() != "" {
-		request.Mprn = req.GetMprn()
+	if mprn != "" {
+		request.Mprn = mprn
 	}
 
 	if req.GetGasTariffType() != lowribeckv1.TariffType_TARIFF_TYPE_UNKNOWN {
@@ -231,6 +241,28 @@ func (lb LowriBeck) UpdateContactDetailsResponse(resp *lowribeck.UpdateContactDe
 	}, nil
 }
 
+func (lb LowriBeck) CancelBookingRequest(id uint32, req *lowribeckv1.CancelBookingRequest) *lowribeck.CancelBookingRequest {
+	return &lowribeck.CancelBookingRequest{
+		ReferenceID:     req.GetReference(),
+		CancelReason:    req.GetReason(),
+		SendingSystem:   lb.sendingSystem,
+		ReceivingSystem: lb.receivingSystem,
+		CreatedDate:     time.Now().UTC().Format(requestTimeFormat),
+		// An ID sent to LB which they return in the response and can be used for debugging issues with them
+		RequestID: fmt.Sprintf("%d", id),
+	}
+}
+
+func (lb LowriBeck) CancelBookingResponse(resp *lowribeck.CancelBookingResponse) (*lowribeckv1.CancelBookingResponse, error) {
+	err := mapCancelBookingResponseCodes(resp.ResponseCode, resp.ResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	return &lowribeckv1.CancelBookingResponse{
+		Success: true,
+	}, nil
+}
+
 func mapAvailabilitySlots(availabilityResults []lowribeck.AvailabilitySlot) ([]*lowribeckv1.BookingSlot, error) {
 	var err error
 	slots := make([]*lowribeckv1.BookingSlot, len(availabilityResults))
@@ -440,6 +472,54 @@ func mapUpdateContactDetailsResponseCodes(responseCode, responseMessage string)
 	return fmt.Errorf("%w [%s]", ErrUnknownError, responseMessage)
 }
 
+func mapCancelBookingResponseCodes(responseCode, responseMessage string) error {
+	switch responseCode {
+	// C01 - Cancellation confirmed
+	case "C01":
+		return nil
+	// C02 - Invalid Reference ID
+	case "C02":
+		return NewInvalidRequestError(InvalidReference)
+	case "C03":
+		switch responseMessage {
+		// C03 - No jobs found
+		case "No jobs found":
+			return ErrAppointmentNotFound
+		// C03 - Appointment already cancelled
+		case "Appointment already cancelled":
+			return ErrAppointmentAlreadyCancelled
+		}
+	// C04 - Cancellation request sent outside agreed time parameter
+	case "C04":
+		return ErrAppointmentOutOfRange
+	// C05 - Site status not suitable for request
+	case "C05":
+		return NewInvalidRequestError(InvalidSite)
+	}
+	return fmt.Errorf("%w [%s]", ErrUnknownError, responseMessage)
+}
+
+// mapMeterpoints validates the meterpoint numbers and postcode of a point of sale request, before
+// they are sent to LowriBeck, and returns them normalised. The mprn is optional.
+func mapMeterpoints(mpan, mprn, postcode string) (string, string, string, error) {
+	mpan, err := validation.MPAN(mpan)
+	if err != nil {
+		return "", "", "", NewInvalidRequestError(InvalidMPAN)
+	}
+	if mprn != "" {
+		mprn, err = validation.MPRN(mprn)
+		if err != nil {
+			return "", "", "", NewInvalidRequestError(InvalidMPRN)
+		}
+	}
+	postcode, err = validation.Postcode(postcode)
+	if err != nil {
+		return "", "", "", NewInvalidRequestError(InvalidPostcode)
+	}
+
+	return mpan, mprn, postcode, nil
+}
+
 func mapBookingSlot(slot *lowribeckv1.BookingSlot) (string, string, error) {
 	if slot == nil {
 		return "", "", fmt.Errorf("invalid booking slot")
@@ -373,8 +373,8 @@ func TestMapAvailableSlotsPointOfSaleResponse(t *testing.T) {
 			input: inputParams{
 				id: 1,
 				req: &lowribeckv1.GetAvailableSlotsPointOfSaleRequest{
-					Postcode:              "ZE 11",
-					Mpan:                  "mpan-1",
+					Postcode:              "ZE1 1AA",
+					Mpan:                  "2199996734008",
 					Mprn:                  "",
 					ElectricityTariffType: lowribeckv1.TariffType_TARIFF_TYPE_CREDIT,
 					GasTariffType:         lowribeckv1.TariffType_TARIFF_TYPE_UNKNOWN,
@@ -385,8 +385,8 @@ func TestMapAvailableSlotsPointOfSaleResponse(t *testing.T) {
 				RequestID:       "1",
 				SendingSystem:   "sendingSystem",
 				ReceivingSystem: "receivingSystem",
-				PostCode:        "ZE 11",
-				Mpan:            "mpan-1",
+				PostCode:        "ZE1 1AA",
+				Mpan:            "2199996734008",
 				Mprn:            "",
 				ElecJobTypeCode: "crElec",
 				GasJobTypeCode:  "",
@@ -398,8 +398,8 @@ func TestMapAvailableSlotsPointOfSaleResponse(t *testing.T) {
 			input: inputParams{
 				id: 1,
 				req: &lowribeckv1.GetAvailableSlotsPointOfSaleRequest{
-					Postcode:              "ZE 11",
-					Mpan:                  "mpan-1",
+					Postcode:              "ZE1 1AA",
+					Mpan:                  "2199996734008",
 					Mprn:                  "",
 					ElectricityTariffType: lowribeckv1.TariffType_TARIFF_TYPE_PREPAYMENT,
 					GasTariffType:         lowribeckv1.TariffType_TARIFF_TYPE_UNKNOWN,
@@ -410,8 +410,8 @@ func TestMapAvailableSlotsPointOfSaleResponse(t *testing.T) {
 				RequestID:       "1",
 				SendingSystem:   "sendingSystem",
 				ReceivingSystem: "receivingSystem",
-				PostCode:        "ZE 11",
-				Mpan:            "mpan-1",
+				PostCode:        "ZE1 1AA",
+				Mpan:            "2199996734008",
 				Mprn:            "",
 				ElecJobTypeCode: "ppmElec",
 				GasJobTypeCode:  "",
@@ -423,9 +423,9 @@ func TestMapAvailableSlotsPointOfSaleResponse(t *testing.T) {
 			input: inputParams{
 				id: 1,
 				req: &lowribeckv1.GetAvailableSlotsPointOfSaleRequest{
-					Postcode:              "ZE 11",
-					Mpan:                  "mpan-1",
-					Mprn:                  "mprn-1",
+					Postcode:              "ZE1 1AA",
+					Mpan:                  "2199996734008",
+					Mprn:                  "2724968810",
 					ElectricityTariffType: lowribeckv1.TariffType_TARIFF_TYPE_CREDIT,
 					GasTariffType:         lowribeckv1.TariffType_TARIFF_TYPE_CREDIT,
 				},
@@ -435,9 +435,9 @@ func TestMapAvailableSlotsPointOfSaleResponse(t *testing.T) {
 				RequestID:       "1",
 				SendingSystem:   "sendingSystem",
 				ReceivingSystem: "receivingSystem",
-				PostCode:        "ZE 11",
-				Mpan:            "mpan-1",
-				Mprn:            "mprn-1",
+				PostCode:        "ZE1 1AA",
+				Mpan:            "2199996734008",
+				Mprn:            "2724968810",
 				ElecJobTypeCode: "crElec",
 				GasJobTypeCode:  "crGas",
 				CreatedDate:     time.Now().UTC().Format(requestTimeFormat),
@@ -448,9 +448,9 @@ func TestMapAvailableSlotsPointOfSaleResponse(t *testing.T) {
 			input: inputParams{
 				id: 1,
 				req: &lowribeckv1.GetAvailableSlotsPointOfSaleRequest{
-					Postcode:              "ZE 11",
-					Mpan:                  "mpan-1",
-					Mprn:                  "mprn-1",
+					Postcode:              "ZE1 1AA",
+					Mpan:                  "2199996734008",
+					Mprn:                  "2724968810",
 					ElectricityTariffType: lowribeckv1.TariffType_TARIFF_TYPE_CREDIT,
 					GasTariffType:         lowribeckv1.TariffType_TARIFF_TYPE_PREPAYMENT,
 				},
@@ -460,9 +460,9 @@ func TestMapAvailableSlotsPointOfSaleResponse(t *testing.T) {
 				RequestID:       "1",
 				SendingSystem:   "sendingSystem",
 				ReceivingSystem: "receivingSystem",
-				PostCode:        "ZE 11",
-				Mpan:            "mpan-1",
-				Mprn:            "mprn-1",
+				PostCode:        "ZE1 1AA",
+				Mpan:            "2199996734008",
+				Mprn:            "2724968810",
 				ElecJobTypeCode: "crElec",
 				GasJobTypeCode:  "ppmGas",
 				CreatedDate:     time.Now().UTC().Format(requestTimeFormat),
@@ -473,9 +473,9 @@ func TestMapAvailableSlotsPointOfSaleResponse(t *testing.T) {
 			input: inputParams{
 				id: 1,
 				req: &lowribeckv1.GetAvailableSlotsPointOfSaleRequest{
-					Postcode:              "ZE 11",
-					Mpan:                  "mpan-1",
-					Mprn:                  "mprn-1",
+					Postcode:              "ZE1 1AA",
+					Mpan:                  "2199996734008",
+					Mprn:                  "2724968810",
 					ElectricityTariffType: lowribeckv1.TariffType_TARIFF_TYPE_UNKNOWN,
 					GasTariffType:         lowribeckv1.TariffType_TARIFF_TYPE_PREPAYMENT,
 				},
@@ -483,6 +483,72 @@ func TestMapAvailableSlotsPointOfSaleResponse(t *testing.T) {
 			expectedError: mapper.ErrInvalidElectricityTariffType,
 			expected:      nil,
 		},
+		{
+			desc: "Success - normalises the meterpoint numbers and postcode",
+			input: inputParams{
+				id: 1,
+				req: &lowribeckv1.GetAvailableSlotsPointOfSaleRequest{
+					Postcode:              "ze11aa",
+					Mpan:                  "21 9999 6734 008",
+					Mprn:                  "2724 968 810",
+					ElectricityTariffType: lowribeckv1.TariffType_TARIFF_TYPE_CREDIT,
+					GasTariffType:         lowribeckv1.TariffType_TARIFF_TYPE_CREDIT,
+				},
+			},
+			expectedError: nil,
+			expected: &lowribeck.GetCalendarAvailabilityRequest{
+				RequestID:       "1",
+				SendingSystem:   "sendingSystem",
+				ReceivingSystem: "receivingSystem",
+				PostCode:        "ZE1 1AA",
+				Mpan:            "2199996734008",
+				Mprn:            "2724968810",
+				ElecJobTypeCode: "crElec",
+				GasJobTypeCode:  "crGas",
+				CreatedDate:     time.Now().UTC().Format(requestTimeFormat),
+			},
+		},
+		{
+			desc: "should error because the mpan check digit is wrong",
+			input: inputParams{
+				id: 1,
+				req: &lowribeckv1.GetAvailableSlotsPointOfSaleRequest{
+					Postcode:              "ZE1 1AA",
+					Mpan:                  "2199996734009",
+					ElectricityTariffType: lowribeckv1.TariffType_TARIFF_TYPE_CREDIT,
+				},
+			},
+			expectedError: mapper.NewInvalidRequestError(mapper.InvalidMPAN),
+			expected:      nil,
+		},
+		{
+			desc: "should error because the mprn check digits are wrong",
+			input: inputParams{
+				id: 1,
+				req: &lowribeckv1.GetAvailableSlotsPointOfSaleRequest{
+					Postcode:              "ZE1 1AA",
+					Mpan:                  "2199996734008",
+					Mprn:                  "2724968811",
+					ElectricityTariffType: lowribeckv1.TariffType_TARIFF_TYPE_CREDIT,
+					GasTariffType:         lowribeckv1.TariffType_TARIFF_TYPE_CREDIT,
+				},
+			},
+			expectedError: mapper.NewInvalidRequestError(mapper.InvalidMPRN),
+			expected:      nil,
+		},
+		{
+			desc: "should error because the postcode is not a valid UK postcode",
+			input: inputParams{
+				id: 1,
+				req: &lowribeckv1.GetAvailableSlotsPointOfSaleRequest{
+					Postcode:              "ZE 11",
+					Mpan:                  "2199996734008",
+					ElectricityTariffType: lowribeckv1.TariffType_TARIFF_TYPE_CREDIT,
+				},
+			},
+			expectedError: mapper.NewInvalidRequestError(mapper.InvalidPostcode),
+			expected:      nil,
+		},
 	}
 
 	assert := assert.New(t)
@@ -520,7 +586,7 @@ func TestMapBookingPointOfSaleRequest(t *testing.T) {
 			input: inputParams{
 				id: 1,
 				req: &lowribeckv1.CreateBookingPointOfSaleRequest{
-					Mpan:                  "mpan-1",
+					Mpan:                  "2199996734008",
 					Mprn:                  "",
 					ElectricityTariffType: lowribeckv1.TariffType_TARIFF_TYPE_CREDIT,
 					GasTariffType:         lowribeckv1.TariffType_TARIFF_TYPE_UNKNOWN,
@@ -558,7 +624,7 @@ func TestMapBookingPointOfSaleRequest(t *testing.T) {
 							DoubleDependentLocality: "ddl-1",
 							DependentLocality:       "dl-1",
 							PostTown:                "pt",
-							Postcode:                "ZE 11",
+							Postcode:                "ZE1 1AA",
 						},
 					},
 				},
@@ -577,8 +643,8 @@ func TestMapBookingPointOfSaleRequest(t *testing.T) {
 				DependantLocality:       "dl-1",
 				PostTown:                "pt",
 				County:                  "", // There is no County in the PAF format
-				PostCode:                "ZE 11",
-				Mpan:                    "mpan-1",
+				PostCode:                "ZE1 1AA",
+				Mpan:                    "2199996734008",
 				Mprn:                    "",
 				ElecJobTypeCode:         "crElec",
 				GasJobTypeCode:          "",
@@ -594,7 +660,7 @@ func TestMapBookingPointOfSaleRequest(t *testing.T) {
 			input: inputParams{
 				id: 1,
 				req: &lowribeckv1.CreateBookingPointOfSaleRequest{
-					Mpan:                  "mpan-1",
+					Mpan:                  "2199996734008",
 					Mprn:                  "",
 					ElectricityTariffType: lowribeckv1.TariffType_TARIFF_TYPE_PREPAYMENT,
 					GasTariffType:         lowribeckv1.TariffType_TARIFF_TYPE_UNKNOWN,
@@ -632,7 +698,7 @@ func TestMapBookingPointOfSaleRequest(t *testing.T) {
 							DoubleDependentLocality: "ddl-1",
 							DependentLocality:       "dl-1",
 							PostTown:                "pt",
-							Postcode:                "ZE 11",
+							Postcode:                "ZE1 1AA",
 						},
 					},
 				},
@@ -651,8 +717,8 @@ func TestMapBookingPointOfSaleRequest(t *testing.T) {
 				DependantLocality:       "dl-1",
 				PostTown:                "pt",
 				County:                  "", // There is no County in the PAF format
-				PostCode:                "ZE 11",
-				Mpan:                    "mpan-1",
+				PostCode:                "ZE1 1AA",
+				Mpan:                    "2199996734008",
 				Mprn:                    "",
 				ElecJobTypeCode:         "ppmElec",
 				GasJobTypeCode:          "",
@@ -668,8 +734,8 @@ func TestMapBookingPointOfSaleRequest(t *testing.T) {
 			input: inputParams{
 				id: 1,
 				req: &lowribeckv1.CreateBookingPointOfSaleRequest{
-					Mpan:                  "mpan-1",
-					Mprn:                  "mprn-1",
+					Mpan:                  "2199996734008",
+					Mprn:                  "2724968810",
 					ElectricityTariffType: lowribeckv1.TariffType_TARIFF_TYPE_CREDIT,
 					GasTariffType:         lowribeckv1.TariffType_TARIFF_TYPE_CREDIT,
 					Slot: &lowribeckv1.BookingSlot{
@@ -706,7 +772,7 @@ func TestMapBookingPointOfSaleRequest(t *testing.T) {
 							DoubleDependentLocality: "ddl-1",
 							DependentLocality:       "dl-1",
 							PostTown:                "pt",
-							Postcode:                "ZE 11",
+							Postcode:                "ZE1 1AA",
 						},
 					},
 				},
@@ -725,9 +791,9 @@ func TestMapBookingPointOfSaleRequest(t *testing.T) {
 				DependantLocality:       "dl-1",
 				PostTown:                "pt",
 				County:                  "", // There is no County in the PAF format
-				PostCode:                "ZE 11",
-				Mpan:                    "mpan-1",
-				Mprn:                    "mprn-1",
+				PostCode:                "ZE1 1AA",
+				Mpan:                    "2199996734008",
+				Mprn:                    "2724968810",
 				ElecJobTypeCode:         "crElec",
 				GasJobTypeCode:          "crGas",
 				Vulnerabilities:         "6",
@@ -742,8 +808,8 @@ func TestMapBookingPointOfSaleRequest(t *testing.T) {
 			input: inputParams{
 				id: 1,
 				req: &lowribeckv1.CreateBookingPointOfSaleRequest{
-					Mpan:                  "mpan-1",
-					Mprn:                  "mprn-1",
+					Mpan:                  "2199996734008",
+					Mprn:                  "2724968810",
 					ElectricityTariffType: lowribeckv1.TariffType_TARIFF_TYPE_CREDIT,
 					GasTariffType:         lowribeckv1.TariffType_TARIFF_TYPE_PREPAYMENT,
 					Slot: &lowribeckv1.BookingSlot{
@@ -780,7 +846,7 @@ func TestMapBookingPointOfSaleRequest(t *testing.T) {
 							DoubleDependentLocality: "ddl-1",
 							DependentLocality:       "dl-1",
 							PostTown:                "pt",
-							Postcode:                "ZE 11",
+							Postcode:                "ZE1 1AA",
 						},
 					},
 				},
@@ -799,9 +865,9 @@ func TestMapBookingPointOfSaleRequest(t *testing.T) {
 				DependantLocality:       "dl-1",
 				PostTown:                "pt",
 				County:                  "", // There is no County in the PAF format
-				PostCode:                "ZE 11",
-				Mpan:                    "mpan-1",
-				Mprn:                    "mprn-1",
+				PostCode:                "ZE1 1AA",
+				Mpan:                    "2199996734008",
+				Mprn:                    "2724968810",
 				ElecJobTypeCode:         "crElec",
 				GasJobTypeCode:          "ppmGas",
 				Vulnerabilities:         "6",
@@ -886,3 +952,79 @@ func Test_MapBookingPointOfSaleResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestMapCancelBookingResponse(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		lb            *lowribeck.CancelBookingResponse
+		expected      *lowribeckv1.CancelBookingResponse
+		expectedError error
+	}{
+		{
+			desc: "Success",
+			lb: &lowribeck.CancelBookingResponse{
+				ResponseCode:    "C01",
+				ResponseMessage: "Cancellation Confirmed",
+			},
+			expected: &lowribeckv1.CancelBookingResponse{
+				Success: true,
+			},
+		},
+		{
+			desc: "Invalid Reference ID",
+			lb: &lowribeck.CancelBookingResponse{
+				ResponseCode:    "C02",
+				ResponseMessage: "Invalid Reference ID",
+			},
+			expectedError: fmt.Errorf("invalid request [reference]"),
+		},
+		{
+			desc: "No jobs found",
+			lb: &lowribeck.CancelBookingResponse{
+				ResponseCode:    "C03",
+				ResponseMessage: "No jobs found",
+			},
+			expectedError: fmt.Errorf("no appointments found"),
+		},
+		{
+			desc: "Appointment already cancelled",
+			lb: &lowribeck.CancelBookingResponse{
+				ResponseCode:    "C03",
+				ResponseMessage: "Appointment already cancelled",
+			},
+			expectedError: fmt.Errorf("appointment already cancelled"),
+		},
+		{
+			desc: "Cancellation outside agreed time parameter",
+			lb: &lowribeck.CancelBookingResponse{
+				ResponseCode:    "C04",
+				ResponseMessage: "Cancellation request sent outside agreed time parameter",
+			},
+			expectedError: fmt.Errorf("appointment out of range"),
+		},
+		{
+			desc: "Unknown code",
+			lb: &lowribeck.CancelBookingResponse{
+				ResponseCode:    "C99",
+				ResponseMessage: "Something went wrong",
+			},
+			expectedError: fmt.Errorf("unknown error [Something went wrong]"),
+		},
+	}
+
+	assert := assert.New(t)
+	lbMapper := mapper.NewLowriBeckMapper("sendingSystem", "receivingSystem", "", "", "", "")
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(_ *testing.T) {
+			res, err := lbMapper.CancelBookingResponse(tc.lb)
+			if tc.expectedError == nil {
+				assert.NoError(err, tc.desc)
+				diff := cmp.Diff(tc.expected, res, protocmp.Transform(), cmpopts.IgnoreUnexported())
+				assert.Empty(diff, tc.desc)
+			} else {
+				assert.EqualError(err, tc.expectedError.Error(), tc.desc)
+			}
+		})
+	}
+}
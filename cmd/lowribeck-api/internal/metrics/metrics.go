@@ -15,6 +15,7 @@ const (
 	AppointmentNotFound           = "appointment_not_found"
 	AppointmentAlreadyExists      = "appointment_already_exists"
 	AppointmentOutOfRange         = "appointment_out_of_range"
+	AppointmentAlreadyCancelled   = "appointment_already_cancelled"
 	Internal                      = "internal"
 	LBStatus                      = "lb_status"
 	Unknown                       = "unknown"
@@ -34,8 +35,10 @@ const (
 // LBErrorsCount endpoint
 const (
 	GetAvailableSlots    = "get_available_slots"
+	GetAvailableSlotsPOS = "get_available_slots_point_of_sale"
 	CreateBooking        = "create_booking"
 	UpdateContactDetails = "update_contact_details"
+	CancelBooking        = "cancel_booking"
 )
 
 var LBAPIRunning = promauto.NewGauge(prometheus.GaugeOpts{
@@ -47,3 +50,13 @@ var LBResponseCount = promauto.NewCounterVec(prometheus.CounterOpts{
 	Name: "lb_responses_total",
 	Help: "the status code returned from each LB request",
 }, []string{"code", "endpoint"})
+
+var AvailabilityCacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "lb_availability_cache_requests_total",
+	Help: "the count of availability cache lookups by result (hit or miss)",
+}, []string{"endpoint", "result"})
+
+var AvailabilityCacheLatencySaved = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "lb_availability_cache_latency_saved_seconds_total",
+	Help: "the time the LB requests answered by the availability cache took when they were cached",
+}, []string{"endpoint"})
@@ -45,6 +45,10 @@ const (
 	electricityJobTypeCodePrepayment = "electricity-job-type-code-prepayment"
 	gasJobTypeCodeCredit             = "gas-job-type-code-credit" //nolint: gosec
 	gasJobTypeCodePrepayment         = "gas-job-type-code-prepayment"
+
+	// availability cache
+	availabilityCacheTTL  = "availability-cache-ttl"
+	availabilityCacheSize = "availability-cache-size"
 )
 
 var gitHash string // populated at compile time
@@ -106,6 +110,18 @@ func main() {
 						EnvVars:  []string{"GAS_JOB_TYPE_CODE_PREPAYMENT"},
 						Required: true,
 					},
+					&cli.DurationFlag{
+						Name:    availabilityCacheTTL,
+						Usage:   "How long the available slots returned by LowriBeck are cached, and so how stale the other replicas can serve them after a booking, 0 disables caching",
+						EnvVars: []string{"AVAILABILITY_CACHE_TTL"},
+						Value:   time.Minute,
+					},
+					&cli.IntFlag{
+						Name:    availabilityCacheSize,
+						Usage:   "The number of available slots responses cached",
+						EnvVars: []string{"AVAILABILITY_CACHE_SIZE"},
+						Value:   10000,
+					},
 				),
 				Before: app.Before,
 				Action: runServer,
@@ -173,6 +189,9 @@ func runServer(c *cli.Context) error {
 		c.String(gasJobTypeCodePrepayment))
 
 	lowribeckAPI := api.New(client, mapper, auth)
+	if ttl := c.Duration(availabilityCacheTTL); ttl > 0 {
+		lowribeckAPI = lowribeckAPI.WithAvailabilityCache(api.NewAvailabilityCache(ttl, c.Int(availabilityCacheSize)))
+	}
 	contracts.RegisterLowriBeckAPIServer(grpcServer, lowribeckAPI)
 
 	g.Go(func() error {
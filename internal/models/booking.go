@@ -41,3 +41,10 @@ type Booking struct {
 	BookingReference     string
 	BookingType          bookingv1.BookingType
 }
+
+// BookingStatusChange is an entry of the status history of a booking.
+type BookingStatusChange struct {
+	Status     bookingv1.BookingStatus
+	Reason     string
+	OccurredAt time.Time
+}
@@ -0,0 +1,11 @@
+package models
+
+import smart "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart/v1"
+
+// MeterpointEligibility is the smart booking eligibility of a pair of meterpoints,
+// with the reasons each fuel is not eligible.
+type MeterpointEligibility struct {
+	Eligible                     bool
+	ElectricityIneligibleReasons []smart.IneligibleReason
+	GasIneligibleReasons         []smart.IneligibleReason
+}
@@ -22,3 +22,9 @@ type OccupancyEligibility struct {
 
 	DeletedAt *time.Time
 }
+
+// EligibleOccupancy is an occupancy which is eligible for a smart booking, with the site it is at.
+type EligibleOccupancy struct {
+	Site        Site
+	Eligibility OccupancyEligibility
+}
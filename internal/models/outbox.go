@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// OutboxEvent is an event waiting in the booking-api outbox to be published to its sink.
+type OutboxEvent struct {
+	ID   int64
+	Sink string
+	// BookingID orders the events of a booking, it is empty for events which aren't about a booking
+	BookingID  string
+	Event      *anypb.Any
+	OccurredAt time.Time
+	CreatedAt  time.Time
+	// Attempts counts the attempts to publish the event, including the one it was claimed for
+	Attempts int
+}
+
+type OutboxStats struct {
+	Pending         int
+	DeadLettered    int
+	OldestPendingAt *time.Time
+}
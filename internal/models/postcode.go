@@ -0,0 +1,30 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/utilitywarehouse/energy-smart-booking/internal/validation"
+)
+
+// Postcode is a UK postcode in its canonical form: upper case, with a single space
+// between the outward and inward codes, e.g. "SW1A 1AA". Postcodes are stored and
+// matched in this form so that "SW1A 1AA" and "sw1a1aa" refer to the same place.
+type Postcode string
+
+// ParsePostcode returns the canonical form of a postcode.
+//
+// If the postcode is not a valid UK postcode the error is returned alongside the
+// postcode upper cased with its whitespace removed, so that callers which can't
+// reject it can still store and match it consistently.
+func ParsePostcode(raw string) (Postcode, error) {
+	postcode, err := validation.Postcode(raw)
+	if err != nil {
+		return Postcode(strings.ToUpper(strings.Join(strings.Fields(raw), ""))), err
+	}
+
+	return Postcode(postcode), nil
+}
+
+func (p Postcode) String() string {
+	return string(p)
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/validation"
+)
+
+func TestParsePostcode(t *testing.T) {
+	testCases := []struct {
+		description string
+		input       string
+		expected    Postcode
+		expectedErr error
+	}{
+		{description: "canonical", input: "SW1A 1AA", expected: "SW1A 1AA"},
+		{description: "lower case without space", input: "sw1a1aa", expected: "SW1A 1AA"},
+		{description: "extra whitespace", input: " sw1a  1aa ", expected: "SW1A 1AA"},
+		{description: "invalid", input: "post Code", expected: "POSTCODE", expectedErr: validation.ErrInvalidPostcode},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			actual, err := ParsePostcode(tc.input)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,372 @@
+package gateway
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var gatewayCacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "smart_booking_gateway_cache_requests_total",
+	Help: "The number of gateway cache lookups by call, tier answering them and result",
+}, []string{"call", "tier", "result"})
+
+// CacheStore is a key value store with expiring entries backing a read-through cache.
+// Get returns the remaining TTL of the entry with its value.
+type CacheStore interface {
+	Get(ctx context.Context, key string) ([]byte, time.Duration, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// CacheInvalidationBus broadcasts the keys invalidated by an instance to all the instances of a service,
+// so that they drop them from memory too.
+type CacheInvalidationBus interface {
+	Publish(ctx context.Context, keys ...string) error
+	Subscribe(ctx context.Context, onInvalidate func(keys []string)) error
+}
+
+// LRUCache is an in-memory CacheStore evicting the least recently used entries once full.
+type LRUCache struct {
+	size int
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+func NewLRUCache(size int) *LRUCache {
+	if size < 1 {
+		size = 1
+	}
+	return &LRUCache{
+		size:    size,
+		now:     time.Now,
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+func (c *LRUCache) Get(_ context.Context, key string) ([]byte, time.Duration, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, 0, false, nil
+	}
+	entry := elem.Value.(*lruEntry)
+	remaining := entry.expiresAt.Sub(c.now())
+	if remaining <= 0 {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return nil, 0, false, nil
+	}
+	c.order.MoveToFront(elem)
+
+	return entry.value, remaining, true, nil
+}
+
+func (c *LRUCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(ttl)
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*lruEntry)
+		entry.value, entry.expiresAt = value, expiresAt
+		c.order.MoveToFront(elem)
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+
+	return nil
+}
+
+func (c *LRUCache) Delete(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if elem, ok := c.entries[key]; ok {
+			c.order.Remove(elem)
+			delete(c.entries, key)
+		}
+	}
+
+	return nil
+}
+
+// Len returns the number of entries held, including the expired ones not evicted yet.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// RedisCache is a CacheStore shared by all the instances of a service. It's also a CacheInvalidationBus
+// broadcasting the invalidated keys through Redis pub/sub.
+type RedisCache struct {
+	r      *redis.Client
+	prefix string
+}
+
+func NewRedisCache(r *redis.Client, prefix string) *RedisCache {
+	return &RedisCache{r: r, prefix: prefix}
+}
+
+func (c *RedisCache) key(key string) string {
+	return fmt.Sprintf("%s:%s", c.prefix, key)
+}
+
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, time.Duration, bool, error) {
+	pipe := c.r.Pipeline()
+	get := pipe.Get(ctx, c.key(key))
+	ttl := pipe.PTTL(ctx, c.key(key))
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, 0, false, err
+	}
+
+	value, err := get.Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, 0, false, nil
+		}
+		return nil, 0, false, err
+	}
+	return value, ttl.Val(), true, nil
+}
+
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.r.Set(ctx, c.key(key), value, ttl).Err()
+}
+
+func (c *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	prefixed := make([]string, 0, len(keys))
+	for _, key := range keys {
+		prefixed = append(prefixed, c.key(key))
+	}
+	return c.r.Del(ctx, prefixed...).Err()
+}
+
+func (c *RedisCache) invalidationChannel() string {
+	return c.key("invalidations")
+}
+
+// Publish broadcasts the invalidated keys to the subscribed instances.
+func (c *RedisCache) Publish(ctx context.Context, keys ...string) error {
+	payload, err := json.Marshal(keys)
+	if err != nil {
+		return err
+	}
+	return c.r.Publish(ctx, c.invalidationChannel(), payload).Err()
+}
+
+// Subscribe calls onInvalidate with the keys invalidated by any instance until the context is cancelled.
+// The subscription is restored by the redis client after a connection failure.
+func (c *RedisCache) Subscribe(ctx context.Context, onInvalidate func(keys []string)) error {
+	pubsub := c.r.Subscribe(ctx, c.invalidationChannel())
+	defer pubsub.Close()
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			var keys []string
+			if err := json.Unmarshal([]byte(msg.Payload), &keys); err != nil {
+				slog.Warn("failed to decode gateway cache invalidation", "payload", msg.Payload, "error", err)
+				continue
+			}
+			onInvalidate(keys)
+		}
+	}
+}
+
+// ReadThroughCache looks entries up in memory first and then, if configured, in a shared store,
+// which also populates the memory. Errors of the shared store are logged and treated as misses
+// so that the cache never fails a call the dependency could answer.
+type ReadThroughCache struct {
+	local  CacheStore
+	shared CacheStore
+	bus    CacheInvalidationBus
+}
+
+// NewReadThroughCache creates a cache, the shared store is optional.
+func NewReadThroughCache(local, shared CacheStore) *ReadThroughCache {
+	return &ReadThroughCache{local: local, shared: shared}
+}
+
+// WithInvalidationBus broadcasts the invalidations to the other instances, without it only
+// the memory of the instance invalidating the keys is cleared.
+func (c *ReadThroughCache) WithInvalidationBus(bus CacheInvalidationBus) *ReadThroughCache {
+	c.bus = bus
+	return c
+}
+
+type cacheEntry struct {
+	NotFound bool            `json:"not_found,omitempty"`
+	Value    json.RawMessage `json:"value,omitempty"`
+}
+
+// Invalidate removes the keys from all the tiers and from the memory of the other instances.
+func (c *ReadThroughCache) Invalidate(ctx context.Context, keys ...string) error {
+	if err := c.local.Delete(ctx, keys...); err != nil {
+		return err
+	}
+	if c.shared != nil {
+		if err := c.shared.Delete(ctx, keys...); err != nil {
+			return err
+		}
+	}
+	if c.bus != nil {
+		return c.bus.Publish(ctx, keys...)
+	}
+	return nil
+}
+
+// WatchInvalidations removes from memory the keys invalidated by the other instances until
+// the context is cancelled. It returns straight away if there's no invalidation bus.
+func (c *ReadThroughCache) WatchInvalidations(ctx context.Context) error {
+	if c.bus == nil {
+		return nil
+	}
+	return c.bus.Subscribe(ctx, func(keys []string) {
+		if err := c.local.Delete(ctx, keys...); err != nil {
+			slog.Warn("failed to drop invalidated gateway cache entries", "keys", keys, "error", err)
+		}
+	})
+}
+
+func (c *ReadThroughCache) get(ctx context.Context, call, key string, ttl CacheTTL) (cacheEntry, bool) {
+	value, _, ok, _ := c.local.Get(ctx, key)
+	tier := "memory"
+
+	var remaining time.Duration
+	if !ok && c.shared != nil {
+		var err error
+		value, remaining, ok, err = c.shared.Get(ctx, key)
+		if err != nil {
+			slog.Warn("failed to read gateway cache", "call", call, "key", key, "error", err)
+		}
+		tier = "redis"
+	}
+	if !ok {
+		gatewayCacheRequests.WithLabelValues(call, "", "miss").Inc()
+		return cacheEntry{}, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(value, &entry); err != nil {
+		slog.Warn("failed to decode gateway cache entry", "call", call, "key", key, "error", err)
+		gatewayCacheRequests.WithLabelValues(call, "", "miss").Inc()
+		return cacheEntry{}, false
+	}
+
+	result, localTTL := "hit", ttl.Found
+	if entry.NotFound {
+		result, localTTL = "negative_hit", ttl.NotFound
+	}
+	if tier == "redis" {
+		// the entry is kept in memory until it expires in the shared store, the full TTL is only used
+		// if the remaining one is unknown
+		if remaining > 0 && remaining < localTTL {
+			localTTL = remaining
+		}
+		_ = c.local.Set(ctx, key, value, localTTL)
+	}
+	gatewayCacheRequests.WithLabelValues(call, tier, result).Inc()
+
+	return entry, true
+}
+
+func (c *ReadThroughCache) set(ctx context.Context, call, key string, entry cacheEntry, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	value, err := json.Marshal(entry)
+	if err != nil {
+		slog.Warn("failed to encode gateway cache entry", "call", call, "key", key, "error", err)
+		return
+	}
+
+	_ = c.local.Set(ctx, key, value, ttl)
+	if c.shared != nil {
+		if err := c.shared.Set(ctx, key, value, ttl); err != nil {
+			slog.Warn("failed to write gateway cache", "call", call, "key", key, "error", err)
+		}
+	}
+}
+
+// CacheTTL is how long the results of a call are cached. NotFound errors are cached
+// for NotFound, 0 disables the negative caching.
+type CacheTTL struct {
+	Found    time.Duration
+	NotFound time.Duration
+}
+
+func gatewayCacheKey(call, key string) string {
+	return fmt.Sprintf("%s:%s", call, key)
+}
+
+// readThrough returns the cached result of a call or fetches and caches it.
+func readThrough[T any](ctx context.Context, c *ReadThroughCache, call, key string, ttl CacheTTL, fetch func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+	cacheKey := gatewayCacheKey(call, key)
+
+	if entry, ok := c.get(ctx, call, cacheKey, ttl); ok {
+		if entry.NotFound {
+			return zero, status.Errorf(codes.NotFound, "%s %s not found (cached)", call, key)
+		}
+		var value T
+		if err := json.Unmarshal(entry.Value, &value); err == nil {
+			return value, nil
+		}
+	}
+
+	value, err := fetch(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			c.set(ctx, call, cacheKey, cacheEntry{NotFound: true}, ttl.NotFound)
+		}
+		return zero, err
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		slog.Warn("failed to encode gateway cache value", "call", call, "key", key, "error", err)
+		return value, nil
+	}
+	c.set(ctx, call, cacheKey, cacheEntry{Value: encoded}, ttl.Found)
+
+	return value, nil
+}
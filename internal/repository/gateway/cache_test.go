@@ -0,0 +1,253 @@
+package gateway_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/repository/gateway"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_LRUCache(t *testing.T) {
+	ctx := context.Background()
+	cache := gateway.NewLRUCache(2)
+
+	require.NoError(t, cache.Set(ctx, "a", []byte("1"), time.Hour))
+	require.NoError(t, cache.Set(ctx, "b", []byte("2"), time.Hour))
+
+	// reading a makes b the least recently used entry
+	value, ttl, ok, err := cache.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), value)
+	assert.LessOrEqual(t, ttl, time.Hour)
+	assert.Greater(t, ttl, 59*time.Minute)
+
+	require.NoError(t, cache.Set(ctx, "c", []byte("3"), time.Hour))
+	assert.Equal(t, 2, cache.Len())
+
+	_, _, ok, _ = cache.Get(ctx, "b")
+	assert.False(t, ok)
+	_, _, ok, _ = cache.Get(ctx, "c")
+	assert.True(t, ok)
+
+	require.NoError(t, cache.Delete(ctx, "a", "missing"))
+	_, _, ok, _ = cache.Get(ctx, "a")
+	assert.False(t, ok)
+
+	require.NoError(t, cache.Set(ctx, "d", []byte("4"), time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+	_, _, ok, _ = cache.Get(ctx, "d")
+	assert.False(t, ok)
+}
+
+type fakeGasGateway struct {
+	calls   int
+	details map[string]*models.GasMeterTechnicalDetails
+}
+
+func (g *fakeGasGateway) GetMPRNTechnicalDetails(_ context.Context, mprn string) (*models.GasMeterTechnicalDetails, error) {
+	g.calls++
+	details, ok := g.details[mprn]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "mprn %s not found", mprn)
+	}
+	return details, nil
+}
+
+func Test_CachedXOServeGateway(t *testing.T) {
+	ctx := context.Background()
+
+	fake := &fakeGasGateway{details: map[string]*models.GasMeterTechnicalDetails{
+		"mprn-1": {Capacity: 6},
+	}}
+	myGw := gateway.NewCachedXOServeGateway(fake,
+		gateway.NewReadThroughCache(gateway.NewLRUCache(10), nil),
+		gateway.CacheTTL{Found: time.Hour, NotFound: time.Hour},
+	)
+
+	for i := 0; i < 2; i++ {
+		details, err := myGw.GetMPRNTechnicalDetails(ctx, "mprn-1")
+		require.NoError(t, err)
+		assert.Equal(t, &models.GasMeterTechnicalDetails{Capacity: 6}, details)
+	}
+	assert.Equal(t, 1, fake.calls)
+
+	// NotFound is cached too
+	for i := 0; i < 2; i++ {
+		_, err := myGw.GetMPRNTechnicalDetails(ctx, "mprn-2")
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	}
+	assert.Equal(t, 2, fake.calls)
+
+	require.NoError(t, myGw.InvalidateMPRN(ctx, "mprn-1"))
+	_, err := myGw.GetMPRNTechnicalDetails(ctx, "mprn-1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, fake.calls)
+}
+
+func Test_CachedXOServeGateway_NoNegativeCaching(t *testing.T) {
+	ctx := context.Background()
+
+	fake := &fakeGasGateway{}
+	myGw := gateway.NewCachedXOServeGateway(fake,
+		gateway.NewReadThroughCache(gateway.NewLRUCache(10), nil),
+		gateway.CacheTTL{Found: time.Hour},
+	)
+
+	for i := 0; i < 2; i++ {
+		_, err := myGw.GetMPRNTechnicalDetails(ctx, "mprn-1")
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	}
+	assert.Equal(t, 2, fake.calls)
+}
+
+type fakeElectricityGateway struct {
+	calls int
+}
+
+func (g *fakeElectricityGateway) GetMPANTechnicalDetails(_ context.Context, _ string) (*models.ElectricityMeterTechnicalDetails, error) {
+	g.calls++
+	return &models.ElectricityMeterTechnicalDetails{
+		SettlementStandardConfiguration: "0123",
+		Meters: []models.ElectricityMeter{{
+			InstalledAt: time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC),
+		}},
+	}, nil
+}
+
+func (g *fakeElectricityGateway) HasRelatedMPAN(_ context.Context, _ string) (bool, error) {
+	g.calls++
+	return true, nil
+}
+
+func Test_CachedEcoesGateway_SharedStore(t *testing.T) {
+	ctx := context.Background()
+
+	shared := gateway.NewLRUCache(10)
+	ttls := gateway.EcoesCacheTTLs{
+		TechnicalDetails: gateway.CacheTTL{Found: time.Hour},
+		RelatedMPANs:     gateway.CacheTTL{Found: time.Hour},
+	}
+
+	fake := &fakeElectricityGateway{}
+	instance1 := gateway.NewCachedEcoesGateway(fake, gateway.NewReadThroughCache(gateway.NewLRUCache(10), shared), ttls)
+	instance2 := gateway.NewCachedEcoesGateway(fake, gateway.NewReadThroughCache(gateway.NewLRUCache(10), shared), ttls)
+
+	expected, err := instance1.GetMPANTechnicalDetails(ctx, "mpan-1")
+	require.NoError(t, err)
+	related, err := instance1.HasRelatedMPAN(ctx, "mpan-1")
+	require.NoError(t, err)
+	assert.True(t, related)
+
+	// the second instance reads the results cached by the first one
+	actual, err := instance2.GetMPANTechnicalDetails(ctx, "mpan-1")
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+	related, err = instance2.HasRelatedMPAN(ctx, "mpan-1")
+	require.NoError(t, err)
+	assert.True(t, related)
+	assert.Equal(t, 2, fake.calls)
+
+	require.NoError(t, instance2.InvalidateMPAN(ctx, "mpan-1"))
+	_, err = instance2.GetMPANTechnicalDetails(ctx, "mpan-1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, fake.calls)
+}
+
+type fakeInvalidationBus struct {
+	mu          sync.Mutex
+	subscribers []func(keys []string)
+}
+
+func (b *fakeInvalidationBus) Publish(_ context.Context, keys ...string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, onInvalidate := range b.subscribers {
+		onInvalidate(keys)
+	}
+	return nil
+}
+
+func (b *fakeInvalidationBus) Subscribe(ctx context.Context, onInvalidate func(keys []string)) error {
+	b.mu.Lock()
+	b.subscribers = append(b.subscribers, onInvalidate)
+	b.mu.Unlock()
+
+	<-ctx.Done()
+	return nil
+}
+
+func (b *fakeInvalidationBus) subscribed() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.subscribers)
+}
+
+func Test_CachedEcoesGateway_InvalidationBus(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	shared := gateway.NewLRUCache(10)
+	bus := &fakeInvalidationBus{}
+	ttls := gateway.EcoesCacheTTLs{
+		TechnicalDetails: gateway.CacheTTL{Found: time.Hour},
+	}
+
+	cache1 := gateway.NewReadThroughCache(gateway.NewLRUCache(10), shared).WithInvalidationBus(bus)
+	cache2 := gateway.NewReadThroughCache(gateway.NewLRUCache(10), shared).WithInvalidationBus(bus)
+	go func() { _ = cache1.WatchInvalidations(ctx) }()
+	go func() { _ = cache2.WatchInvalidations(ctx) }()
+	require.Eventually(t, func() bool { return bus.subscribed() == 2 }, time.Second, time.Millisecond)
+
+	fake := &fakeElectricityGateway{}
+	instance1 := gateway.NewCachedEcoesGateway(fake, cache1, ttls)
+	instance2 := gateway.NewCachedEcoesGateway(fake, cache2, ttls)
+
+	_, err := instance1.GetMPANTechnicalDetails(ctx, "mpan-1")
+	require.NoError(t, err)
+	_, err = instance2.GetMPANTechnicalDetails(ctx, "mpan-1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, fake.calls)
+
+	// the invalidation by the second instance clears the memory of the first one too
+	require.NoError(t, instance2.InvalidateMPAN(ctx, "mpan-1"))
+	_, err = instance1.GetMPANTechnicalDetails(ctx, "mpan-1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, fake.calls)
+}
+
+func Test_CachedXOServeGateway_SharedStoreRemainingTTL(t *testing.T) {
+	ctx := context.Background()
+
+	shared := gateway.NewLRUCache(10)
+	ttl := gateway.CacheTTL{Found: 100 * time.Millisecond}
+
+	fake := &fakeGasGateway{details: map[string]*models.GasMeterTechnicalDetails{
+		"mprn-1": {Capacity: 6},
+	}}
+	instance1 := gateway.NewCachedXOServeGateway(fake, gateway.NewReadThroughCache(gateway.NewLRUCache(10), shared), ttl)
+	instance2 := gateway.NewCachedXOServeGateway(fake, gateway.NewReadThroughCache(gateway.NewLRUCache(10), shared), ttl)
+
+	_, err := instance1.GetMPRNTechnicalDetails(ctx, "mprn-1")
+	require.NoError(t, err)
+
+	time.Sleep(60 * time.Millisecond)
+	_, err = instance2.GetMPRNTechnicalDetails(ctx, "mprn-1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, fake.calls)
+
+	// the entry copied from the shared store expires with it rather than after the full TTL
+	time.Sleep(60 * time.Millisecond)
+	_, err = instance2.GetMPRNTechnicalDetails(ctx, "mprn-1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, fake.calls)
+}
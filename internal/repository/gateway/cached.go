@@ -0,0 +1,81 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
+)
+
+const (
+	ecoesTechnicalDetailsCall   = "ecoes_technical_details"
+	ecoesRelatedMPANsCall       = "ecoes_related_mpans"
+	xoserveTechnicalDetailsCall = "xoserve_technical_details"
+)
+
+type MPANTechnicalDetailsGateway interface {
+	GetMPANTechnicalDetails(ctx context.Context, mpan string) (*models.ElectricityMeterTechnicalDetails, error)
+	HasRelatedMPAN(ctx context.Context, mpan string) (bool, error)
+}
+
+type MPRNTechnicalDetailsGateway interface {
+	GetMPRNTechnicalDetails(ctx context.Context, mprn string) (*models.GasMeterTechnicalDetails, error)
+}
+
+// EcoesCacheTTLs are how long the results of each ECOES call are cached.
+type EcoesCacheTTLs struct {
+	TechnicalDetails CacheTTL
+	RelatedMPANs     CacheTTL
+}
+
+// CachedEcoesGateway caches the ECOES lookups by MPAN.
+type CachedEcoesGateway struct {
+	gw    MPANTechnicalDetailsGateway
+	cache *ReadThroughCache
+	ttls  EcoesCacheTTLs
+}
+
+func NewCachedEcoesGateway(gw MPANTechnicalDetailsGateway, cache *ReadThroughCache, ttls EcoesCacheTTLs) *CachedEcoesGateway {
+	return &CachedEcoesGateway{gw: gw, cache: cache, ttls: ttls}
+}
+
+func (c *CachedEcoesGateway) GetMPANTechnicalDetails(ctx context.Context, mpan string) (*models.ElectricityMeterTechnicalDetails, error) {
+	return readThrough(ctx, c.cache, ecoesTechnicalDetailsCall, mpan, c.ttls.TechnicalDetails, func(ctx context.Context) (*models.ElectricityMeterTechnicalDetails, error) {
+		return c.gw.GetMPANTechnicalDetails(ctx, mpan)
+	})
+}
+
+func (c *CachedEcoesGateway) HasRelatedMPAN(ctx context.Context, mpan string) (bool, error) {
+	return readThrough(ctx, c.cache, ecoesRelatedMPANsCall, mpan, c.ttls.RelatedMPANs, func(ctx context.Context) (bool, error) {
+		return c.gw.HasRelatedMPAN(ctx, mpan)
+	})
+}
+
+// InvalidateMPAN removes the cached ECOES results of an MPAN.
+func (c *CachedEcoesGateway) InvalidateMPAN(ctx context.Context, mpan string) error {
+	return c.cache.Invalidate(ctx,
+		gatewayCacheKey(ecoesTechnicalDetailsCall, mpan),
+		gatewayCacheKey(ecoesRelatedMPANsCall, mpan),
+	)
+}
+
+// CachedXOServeGateway caches the Xoserve lookups by MPRN.
+type CachedXOServeGateway struct {
+	gw    MPRNTechnicalDetailsGateway
+	cache *ReadThroughCache
+	ttl   CacheTTL
+}
+
+func NewCachedXOServeGateway(gw MPRNTechnicalDetailsGateway, cache *ReadThroughCache, ttl CacheTTL) *CachedXOServeGateway {
+	return &CachedXOServeGateway{gw: gw, cache: cache, ttl: ttl}
+}
+
+func (c *CachedXOServeGateway) GetMPRNTechnicalDetails(ctx context.Context, mprn string) (*models.GasMeterTechnicalDetails, error) {
+	return readThrough(ctx, c.cache, xoserveTechnicalDetailsCall, mprn, c.ttl, func(ctx context.Context) (*models.GasMeterTechnicalDetails, error) {
+		return c.gw.GetMPRNTechnicalDetails(ctx, mprn)
+	})
+}
+
+// InvalidateMPRN removes the cached Xoserve results of an MPRN.
+func (c *CachedXOServeGateway) InvalidateMPRN(ctx context.Context, mprn string) error {
+	return c.cache.Invalidate(ctx, gatewayCacheKey(xoserveTechnicalDetailsCall, mprn))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	eligibilityv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/eligibility/v1"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
 	"google.golang.org/grpc"
 )
 
@@ -21,7 +22,7 @@ func NewEligibilityGateway(mai MachineAuthInjector, client EligibilityClient) *E
 	return &EligibilityGateway{mai, client}
 }
 
-func (gw *EligibilityGateway) GetMeterpointEligibility(ctx context.Context, mpan, mprn, postcode string) (bool, error) {
+func (gw *EligibilityGateway) GetMeterpointEligibility(ctx context.Context, mpan, mprn, postcode string) (models.MeterpointEligibility, error) {
 
 	result, err := gw.client.GetMeterpointEligibility(gw.mai.ToCtx(ctx), &eligibilityv1.GetMeterpointEligibilityRequest{
 		Mpan:     mpan,
@@ -29,10 +30,14 @@ func (gw *EligibilityGateway) GetMeterpointEligibility(ctx context.Context, mpan
 		Postcode: postcode,
 	})
 	if err != nil {
-		return false, fmt.Errorf("failed to get meterpoint eligibilty, %w", err)
+		return models.MeterpointEligibility{}, fmt.Errorf("failed to get meterpoint eligibilty, %w", err)
 	}
 
-	return result.GetEligible(), nil
+	return models.MeterpointEligibility{
+		Eligible:                     result.GetEligible(),
+		ElectricityIneligibleReasons: result.GetElectricityIneligibleReasons(),
+		GasIneligibleReasons:         result.GetGasIneligibleReasons(),
+	}, nil
 }
 
 func toStr(s string) *string {
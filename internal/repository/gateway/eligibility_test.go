@@ -9,7 +9,9 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	smart "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart/v1"
 	eligibilityv1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/smart_booking/eligibility/v1"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
 	"github.com/utilitywarehouse/energy-smart-booking/internal/repository/gateway"
 	mock_gateways "github.com/utilitywarehouse/energy-smart-booking/internal/repository/gateway/mocks"
 )
@@ -32,10 +34,14 @@ func Test_Eligibility_GetMeterpointEligibility(t *testing.T) {
 		Mprn:     toStr("120301230"),
 		Postcode: "E2 1ZZ",
 	}).Return(&eligibilityv1.GetMeterpointEligibilityResponse{
-		Eligible: true,
+		Eligible:             false,
+		GasIneligibleReasons: []smart.IneligibleReason{smart.IneligibleReason_INELIGIBLE_REASON_METER_LARGE_CAPACITY},
 	}, nil)
 
-	actual := true
+	actual := models.MeterpointEligibility{
+		Eligible:             false,
+		GasIneligibleReasons: []smart.IneligibleReason{smart.IneligibleReason_INELIGIBLE_REASON_METER_LARGE_CAPACITY},
+	}
 
 	expected, err := myGw.GetMeterpointEligibility(ctx, "10301031", "120301230", "E2 1ZZ")
 	if err != nil {
@@ -25,4 +25,6 @@ type LowriBeckClient interface {
 	CreateBooking(ctx context.Context, in *lowribeckv1.CreateBookingRequest, opts ...grpc.CallOption) (*lowribeckv1.CreateBookingResponse, error)
 	GetAvailableSlotsPointOfSale(ctx context.Context, in *lowribeckv1.GetAvailableSlotsPointOfSaleRequest, opts ...grpc.CallOption) (*lowribeckv1.GetAvailableSlotsPointOfSaleResponse, error)
 	CreateBookingPointOfSale(ctx context.Context, in *lowribeckv1.CreateBookingPointOfSaleRequest, opts ...grpc.CallOption) (*lowribeckv1.CreateBookingPointOfSaleResponse, error)
+	CancelBooking(ctx context.Context, in *lowribeckv1.CancelBookingRequest, opts ...grpc.CallOption) (*lowribeckv1.CancelBookingResponse, error)
+	UpdateContactDetails(ctx context.Context, in *lowribeckv1.UpdateContactDetailsRequest, opts ...grpc.CallOption) (*lowribeckv1.UpdateContactDetailsResponse, error)
 }
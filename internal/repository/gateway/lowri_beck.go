@@ -29,6 +29,7 @@ var (
 	ErrUnhandledErrorCode     = errors.New("error code not handled")
 	ErrAlreadyExists          = errors.New("already exists")
 	ErrOutOfRange             = errors.New("out of range")
+	ErrFailedPrecondition     = errors.New("failed precondition")
 )
 
 type LowriBeckGateway struct {
@@ -48,6 +49,14 @@ type CreateBookingResponse struct {
 	Success bool
 }
 
+type CancelBookingResponse struct {
+	Success bool
+}
+
+type UpdateContactDetailsResponse struct {
+	Success bool
+}
+
 type CreateBookingPointOfSaleResponse struct {
 	Success     bool
 	ReferenceID string
@@ -252,6 +261,66 @@ func (g LowriBeckGateway) CreateBookingPointOfSale(ctx context.Context, mpan, mp
 	}, nil
 }
 
+func (g LowriBeckGateway) CancelBooking(ctx context.Context, reference, reason string) (_ CancelBookingResponse, err error) {
+	ctx, span := tracing.Start(ctx, "BookingAPI.LowriBeckGateway.CancelBooking",
+		trace.WithAttributes(attribute.String("lowribeck.reference", reference)),
+	)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	cancelResponse, err := g.client.CancelBooking(g.mai.ToCtx(ctx), &lowribeckv1.CancelBookingRequest{
+		Reference: reference,
+		Reason:    reason,
+	})
+	if err != nil {
+		return CancelBookingResponse{Success: false}, mapCancelBookingError(err)
+	}
+
+	span.AddEvent("response", trace.WithAttributes(attribute.Bool("resp", cancelResponse.Success)))
+	return CancelBookingResponse{
+		Success: cancelResponse.Success,
+	}, nil
+}
+
+func (g LowriBeckGateway) UpdateContactDetails(ctx context.Context, reference string, contactDetails models.AccountDetails, vulnerabilities []lowribeckv1.Vulnerability, other string) (_ UpdateContactDetailsResponse, err error) {
+	ctx, span := tracing.Start(ctx, "BookingAPI.LowriBeckGateway.UpdateContactDetails",
+		trace.WithAttributes(attribute.String("lowribeck.reference", reference)),
+	)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	req := &lowribeckv1.UpdateContactDetailsRequest{
+		Reference: reference,
+		VulnerabilityDetails: &lowribeckv1.VulnerabilityDetails{
+			Vulnerabilities: vulnerabilities,
+			Other:           other,
+		},
+		ContactDetails: &lowribeckv1.ContactDetails{
+			Title:     contactDetails.Title,
+			FirstName: contactDetails.FirstName,
+			LastName:  contactDetails.LastName,
+			Phone:     contactDetails.Mobile,
+		},
+	}
+
+	reqAttr := helpers.CreateSpanAttribute(req, "UpdateContactDetailsRequest", span)
+	span.AddEvent("request", trace.WithAttributes(reqAttr))
+
+	updateResponse, err := g.client.UpdateContactDetails(g.mai.ToCtx(ctx), req)
+	if err != nil {
+		return UpdateContactDetailsResponse{Success: false}, mapUpdateContactDetailsError(err)
+	}
+
+	span.AddEvent("response", trace.WithAttributes(attribute.Bool("resp", updateResponse.Success)))
+	return UpdateContactDetailsResponse{
+		Success: updateResponse.Success,
+	}, nil
+}
+
 func mapAvailableSlotsError(err error) error {
 	slog.Error("failed to get available slotes", "error", ErrInternal, "error", err)
 
@@ -405,3 +474,68 @@ func mapCreateBookingPointOfSaleError(err error) error {
 		return ErrUnhandledErrorCode
 	}
 }
+
+func mapCancelBookingError(err error) error {
+
+	switch status.Convert(err).Code() {
+	case codes.Internal:
+		return ErrInternal
+	case codes.InvalidArgument:
+
+		details := status.Convert(err).Details()
+
+		for _, detail := range details {
+
+			switch x := detail.(type) {
+			case *lowribeckv1.InvalidParameterResponse:
+				slog.Debug("found details in invalid argument error code", "parameters", x.GetParameters().String())
+
+				switch x.GetParameters() {
+				case lowribeckv1.Parameters_PARAMETERS_REFERENCE,
+					lowribeckv1.Parameters_PARAMETERS_SITE:
+					return ErrInternalBadParameters
+				}
+			}
+		}
+		return ErrInvalidArgument
+	case codes.FailedPrecondition:
+		return ErrFailedPrecondition
+	case codes.OutOfRange:
+		return ErrOutOfRange
+	case codes.NotFound:
+		return ErrNotFound
+	default:
+		return ErrUnhandledErrorCode
+	}
+}
+
+func mapUpdateContactDetailsError(err error) error {
+
+	switch status.Convert(err).Code() {
+	case codes.Internal:
+		return ErrInternal
+	case codes.InvalidArgument:
+
+		details := status.Convert(err).Details()
+
+		for _, detail := range details {
+
+			switch x := detail.(type) {
+			case *lowribeckv1.InvalidParameterResponse:
+				slog.Debug("found details in invalid argument error code", "parameters", x.GetParameters().String())
+
+				switch x.GetParameters() {
+				case lowribeckv1.Parameters_PARAMETERS_REFERENCE:
+					return ErrInternalBadParameters
+				}
+			}
+		}
+		return ErrInvalidArgument
+	case codes.FailedPrecondition:
+		return ErrFailedPrecondition
+	case codes.NotFound:
+		return ErrNotFound
+	default:
+		return ErrUnhandledErrorCode
+	}
+}
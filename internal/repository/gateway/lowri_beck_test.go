@@ -485,6 +485,281 @@ func Test_GetCreateBooking_HasErrors(t *testing.T) {
 	}
 }
 
+func Test_CancelBooking(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	defer ctrl.Finish()
+
+	lbC := mock_gateways.NewMockLowriBeckClient(ctrl)
+
+	ctx := context.Background()
+	mai := fakeMachineAuthInjector{}
+	mai.ctx = ctx
+
+	myGw := gateway.NewLowriBeckGateway(mai, lbC)
+
+	lbC.EXPECT().CancelBooking(ctx, &lowribeckv1.CancelBookingRequest{
+		Reference: "booking-reference-1",
+		Reason:    "customer request",
+	}).Return(&lowribeckv1.CancelBookingResponse{
+		Success: true,
+	}, nil)
+
+	actual := gateway.CancelBookingResponse{
+		Success: true,
+	}
+
+	expected, err := myGw.CancelBooking(ctx, "booking-reference-1", "customer request")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(expected, actual) {
+		t.Fatalf("expected: %+v, actual: %+v", expected, actual)
+	}
+}
+
+func Test_CancelBooking_HasErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	defer ctrl.Finish()
+
+	lbC := mock_gateways.NewMockLowriBeckClient(ctrl)
+	mai := fakeMachineAuthInjector{}
+	mai.ctx = ctx
+
+	myGw := gateway.NewLowriBeckGateway(mai, lbC)
+
+	type testCases struct {
+		description string
+		setup       func(lbC *mock_gateways.MockLowriBeckClient)
+		outputErr   error
+	}
+
+	lbCancelBookingRequest := &lowribeckv1.CancelBookingRequest{
+		Reference: "booking-reference-1",
+		Reason:    "customer request",
+	}
+
+	tcs := []testCases{
+		{
+			description: "Cancel booking returns internal error status code",
+			setup: func(lbC *mock_gateways.MockLowriBeckClient) {
+				lbC.EXPECT().CancelBooking(ctx, lbCancelBookingRequest).Return(nil, status.New(codes.Internal, "errOops").Err())
+			},
+			outputErr: gateway.ErrInternal,
+		},
+		{
+			description: "Cancel booking returns invalid argument status code",
+			setup: func(lbC *mock_gateways.MockLowriBeckClient) {
+				lbC.EXPECT().CancelBooking(ctx, lbCancelBookingRequest).Return(nil, status.New(codes.InvalidArgument, "errOops").Err())
+			},
+			outputErr: gateway.ErrInvalidArgument,
+		},
+		{
+			description: "Cancel booking returns invalid argument status code with reference details",
+			setup: func(lbC *mock_gateways.MockLowriBeckClient) {
+				errorStatus, err := status.New(codes.InvalidArgument, "errOops").WithDetails(&lowribeckv1.InvalidParameterResponse{
+					Parameters: lowribeckv1.Parameters_PARAMETERS_REFERENCE,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				lbC.EXPECT().CancelBooking(ctx, lbCancelBookingRequest).Return(nil, errorStatus.Err())
+			},
+			outputErr: gateway.ErrInternalBadParameters,
+		},
+		{
+			description: "Cancel booking returns failed precondition status code",
+			setup: func(lbC *mock_gateways.MockLowriBeckClient) {
+				lbC.EXPECT().CancelBooking(ctx, lbCancelBookingRequest).Return(nil, status.New(codes.FailedPrecondition, "errOops").Err())
+			},
+			outputErr: gateway.ErrFailedPrecondition,
+		},
+		{
+			description: "Cancel booking returns out of range status code",
+			setup: func(lbC *mock_gateways.MockLowriBeckClient) {
+				lbC.EXPECT().CancelBooking(ctx, lbCancelBookingRequest).Return(nil, status.New(codes.OutOfRange, "errOops").Err())
+			},
+			outputErr: gateway.ErrOutOfRange,
+		},
+		{
+			description: "Cancel booking returns not found status code",
+			setup: func(lbC *mock_gateways.MockLowriBeckClient) {
+				lbC.EXPECT().CancelBooking(ctx, lbCancelBookingRequest).Return(nil, status.New(codes.NotFound, "errOops").Err())
+			},
+			outputErr: gateway.ErrNotFound,
+		},
+		{
+			description: "Cancel booking returns unhandled status code",
+			setup: func(lbC *mock_gateways.MockLowriBeckClient) {
+				lbC.EXPECT().CancelBooking(ctx, lbCancelBookingRequest).Return(nil, status.New(codes.Unavailable, "errOops").Err())
+			},
+			outputErr: gateway.ErrUnhandledErrorCode,
+		},
+	}
+
+	actual := gateway.CancelBookingResponse{
+		Success: false,
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.setup(lbC)
+
+			expected, err := myGw.CancelBooking(ctx, "booking-reference-1", "customer request")
+
+			if diff := cmp.Diff(err.Error(), tc.outputErr.Error()); diff != "" {
+				t.Fatal(diff)
+			}
+
+			if !cmp.Equal(expected, actual) {
+				t.Fatalf("expected: %+v, actual: %+v", expected, actual)
+			}
+		})
+	}
+}
+
+func Test_UpdateContactDetails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	defer ctrl.Finish()
+
+	lbC := mock_gateways.NewMockLowriBeckClient(ctrl)
+
+	ctx := context.Background()
+	mai := fakeMachineAuthInjector{}
+	mai.ctx = ctx
+
+	myGw := gateway.NewLowriBeckGateway(mai, lbC)
+
+	lbC.EXPECT().UpdateContactDetails(ctx, &lowribeckv1.UpdateContactDetailsRequest{
+		Reference: "booking-reference-1",
+		VulnerabilityDetails: &lowribeckv1.VulnerabilityDetails{
+			Vulnerabilities: []lowribeckv1.Vulnerability{
+				lowribeckv1.Vulnerability_VULNERABILITY_FOREIGN_LANGUAGE_ONLY,
+			},
+			Other: "Bad Knee",
+		},
+		ContactDetails: &lowribeckv1.ContactDetails{
+			Title:     "Mr",
+			FirstName: "John",
+			LastName:  "Doe",
+			Phone:     "555-0145",
+		},
+	}).Return(&lowribeckv1.UpdateContactDetailsResponse{
+		Success: true,
+	}, nil)
+
+	actual := gateway.UpdateContactDetailsResponse{
+		Success: true,
+	}
+
+	expected, err := myGw.UpdateContactDetails(ctx, "booking-reference-1", models.AccountDetails{
+		Title:     "Mr",
+		FirstName: "John",
+		LastName:  "Doe",
+		Mobile:    "555-0145",
+	}, []lowribeckv1.Vulnerability{
+		lowribeckv1.Vulnerability_VULNERABILITY_FOREIGN_LANGUAGE_ONLY,
+	}, "Bad Knee")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(expected, actual) {
+		t.Fatalf("expected: %+v, actual: %+v", expected, actual)
+	}
+}
+
+func Test_UpdateContactDetails_HasErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	defer ctrl.Finish()
+
+	lbC := mock_gateways.NewMockLowriBeckClient(ctrl)
+	mai := fakeMachineAuthInjector{}
+	mai.ctx = ctx
+
+	myGw := gateway.NewLowriBeckGateway(mai, lbC)
+
+	type testCases struct {
+		description string
+		setup       func(lbC *mock_gateways.MockLowriBeckClient)
+		outputErr   error
+	}
+
+	tcs := []testCases{
+		{
+			description: "Update contact details returns internal error status code",
+			setup: func(lbC *mock_gateways.MockLowriBeckClient) {
+				lbC.EXPECT().UpdateContactDetails(ctx, gomock.Any()).Return(nil, status.New(codes.Internal, "errOops").Err())
+			},
+			outputErr: gateway.ErrInternal,
+		},
+		{
+			description: "Update contact details returns invalid argument status code",
+			setup: func(lbC *mock_gateways.MockLowriBeckClient) {
+				lbC.EXPECT().UpdateContactDetails(ctx, gomock.Any()).Return(nil, status.New(codes.InvalidArgument, "errOops").Err())
+			},
+			outputErr: gateway.ErrInvalidArgument,
+		},
+		{
+			description: "Update contact details returns invalid argument status code with reference details",
+			setup: func(lbC *mock_gateways.MockLowriBeckClient) {
+				errorStatus, err := status.New(codes.InvalidArgument, "errOops").WithDetails(&lowribeckv1.InvalidParameterResponse{
+					Parameters: lowribeckv1.Parameters_PARAMETERS_REFERENCE,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				lbC.EXPECT().UpdateContactDetails(ctx, gomock.Any()).Return(nil, errorStatus.Err())
+			},
+			outputErr: gateway.ErrInternalBadParameters,
+		},
+		{
+			description: "Update contact details returns not found status code",
+			setup: func(lbC *mock_gateways.MockLowriBeckClient) {
+				lbC.EXPECT().UpdateContactDetails(ctx, gomock.Any()).Return(nil, status.New(codes.NotFound, "errOops").Err())
+			},
+			outputErr: gateway.ErrNotFound,
+		},
+		{
+			description: "Update contact details returns unhandled status code",
+			setup: func(lbC *mock_gateways.MockLowriBeckClient) {
+				lbC.EXPECT().UpdateContactDetails(ctx, gomock.Any()).Return(nil, status.New(codes.Unavailable, "errOops").Err())
+			},
+			outputErr: gateway.ErrUnhandledErrorCode,
+		},
+	}
+
+	actual := gateway.UpdateContactDetailsResponse{
+		Success: false,
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.setup(lbC)
+
+			expected, err := myGw.UpdateContactDetails(ctx, "booking-reference-1", models.AccountDetails{}, nil, "")
+
+			if diff := cmp.Diff(err.Error(), tc.outputErr.Error()); diff != "" {
+				t.Fatal(diff)
+			}
+
+			if !cmp.Equal(expected, actual) {
+				t.Fatalf("expected: %+v, actual: %+v", expected, actual)
+			}
+		})
+	}
+}
+
 // Point Of Sale Journey
 func Test_GetAvailableSlotsPointOfSale(t *testing.T) {
 	ctrl := gomock.NewController(t)
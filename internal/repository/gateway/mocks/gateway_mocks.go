@@ -160,6 +160,26 @@ func (m *MockLowriBeckClient) EXPECT() *MockLowriBeckClientMockRecorder {
 	return m.recorder
 }
 
+// CancelBooking mocks base method.
+func (m *MockLowriBeckClient) CancelBooking(ctx context.Context, in *lowribeckv1.CancelBookingRequest, opts ...grpc.CallOption) (*lowribeckv1.CancelBookingResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CancelBooking", varargs...)
+	ret0, _ := ret[0].(*lowribeckv1.CancelBookingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelBooking indicates an expected call of CancelBooking.
+func (mr *MockLowriBeckClientMockRecorder) CancelBooking(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelBooking", reflect.TypeOf((*MockLowriBeckClient)(nil).CancelBooking), varargs...)
+}
+
 // CreateBooking mocks base method.
 func (m *MockLowriBeckClient) CreateBooking(ctx context.Context, in *lowribeckv1.CreateBookingRequest, opts ...grpc.CallOption) (*lowribeckv1.CreateBookingResponse, error) {
 	m.ctrl.T.Helper()
@@ -239,3 +259,23 @@ func (mr *MockLowriBeckClientMockRecorder) GetAvailableSlotsPointOfSale(ctx, in
 	varargs := append([]interface{}{ctx, in}, opts...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailableSlotsPointOfSale", reflect.TypeOf((*MockLowriBeckClient)(nil).GetAvailableSlotsPointOfSale), varargs...)
 }
+
+// UpdateContactDetails mocks base method.
+func (m *MockLowriBeckClient) UpdateContactDetails(ctx context.Context, in *lowribeckv1.UpdateContactDetailsRequest, opts ...grpc.CallOption) (*lowribeckv1.UpdateContactDetailsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateContactDetails", varargs...)
+	ret0, _ := ret[0].(*lowribeckv1.UpdateContactDetailsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateContactDetails indicates an expected call of UpdateContactDetails.
+func (mr *MockLowriBeckClientMockRecorder) UpdateContactDetails(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContactDetails", reflect.TypeOf((*MockLowriBeckClient)(nil).UpdateContactDetails), varargs...)
+}
@@ -0,0 +1,65 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/utilitywarehouse/energy-smart-booking/internal/models"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/resilience"
+)
+
+// ResilientEcoesGateway retries the ECOES calls failing with transient errors and
+// stops calling ECOES while its circuit breaker is open.
+type ResilientEcoesGateway struct {
+	gw      *EcoesGateway
+	retry   resilience.RetryPolicy
+	breaker *resilience.CircuitBreaker
+}
+
+func NewResilientEcoesGateway(gw *EcoesGateway, retry resilience.RetryPolicy, breaker *resilience.CircuitBreaker) *ResilientEcoesGateway {
+	return &ResilientEcoesGateway{gw: gw, retry: retry, breaker: breaker}
+}
+
+func (r *ResilientEcoesGateway) GetMPANTechnicalDetails(ctx context.Context, mpan string) (details *models.ElectricityMeterTechnicalDetails, err error) {
+	err = r.breaker.Execute(ctx, func(ctx context.Context) error {
+		return r.retry.Do(ctx, func(ctx context.Context) error {
+			details, err = r.gw.GetMPANTechnicalDetails(ctx, mpan)
+			return err
+		})
+	})
+
+	return details, err
+}
+
+func (r *ResilientEcoesGateway) HasRelatedMPAN(ctx context.Context, mpan string) (related bool, err error) {
+	err = r.breaker.Execute(ctx, func(ctx context.Context) error {
+		return r.retry.Do(ctx, func(ctx context.Context) error {
+			related, err = r.gw.HasRelatedMPAN(ctx, mpan)
+			return err
+		})
+	})
+
+	return related, err
+}
+
+// ResilientXOServeGateway retries the Xoserve calls failing with transient errors and
+// stops calling Xoserve while its circuit breaker is open.
+type ResilientXOServeGateway struct {
+	gw      *XOServeGateway
+	retry   resilience.RetryPolicy
+	breaker *resilience.CircuitBreaker
+}
+
+func NewResilientXOServeGateway(gw *XOServeGateway, retry resilience.RetryPolicy, breaker *resilience.CircuitBreaker) *ResilientXOServeGateway {
+	return &ResilientXOServeGateway{gw: gw, retry: retry, breaker: breaker}
+}
+
+func (r *ResilientXOServeGateway) GetMPRNTechnicalDetails(ctx context.Context, mprn string) (details *models.GasMeterTechnicalDetails, err error) {
+	err = r.breaker.Execute(ctx, func(ctx context.Context) error {
+		return r.retry.Do(ctx, func(ctx context.Context) error {
+			details, err = r.gw.GetMPRNTechnicalDetails(ctx, mprn)
+			return err
+		})
+	})
+
+	return details, err
+}
@@ -0,0 +1,76 @@
+package gateway_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/utilitywarehouse/energy-contracts/pkg/generated/platform"
+	ecoesv2 "github.com/utilitywarehouse/energy-contracts/pkg/generated/third_party/ecoes/v2"
+	xoservev1 "github.com/utilitywarehouse/energy-contracts/pkg/generated/third_party/xoserve/v1"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/repository/gateway"
+	mock_gateways "github.com/utilitywarehouse/energy-smart-booking/internal/repository/gateway/mocks"
+	"github.com/utilitywarehouse/energy-smart-booking/internal/resilience"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_ResilientXOServeGateway_RetriesTransientErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mXOServe := mock_gateways.NewMockXOServeClient(ctrl)
+	myGw := gateway.NewResilientXOServeGateway(
+		gateway.NewXOServeGateway(mXOServe),
+		resilience.RetryPolicy{MaxRetries: 2, InitialBackoff: time.Millisecond},
+		resilience.NewCircuitBreaker("xoserve-retry-test", 5, time.Minute),
+	)
+
+	request := &xoservev1.SearchByMPRNRequest{Mprn: "mprn-1"}
+	gomock.InOrder(
+		mXOServe.EXPECT().GetSwitchDataByMPRN(ctx, request).Return(nil, status.Error(codes.Unavailable, "unavailable")),
+		mXOServe.EXPECT().GetSwitchDataByMPRN(ctx, request).Return(&xoservev1.TechnicalDetailsResponse{
+			Meter: &xoservev1.MeterDetails{
+				MeterType:     platform.MeterTypeGas_METER_TYPE_GAS_COIN,
+				MeterCapacity: float32(6),
+			},
+		}, nil),
+	)
+
+	details, err := myGw.GetMPRNTechnicalDetails(ctx, "mprn-1")
+	require.NoError(t, err)
+	assert.Equal(t, float32(6), details.Capacity)
+}
+
+func Test_ResilientEcoesGateway_OpensCircuit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mEcoes := mock_gateways.NewMockEcoesClient(ctrl)
+	breaker := resilience.NewCircuitBreaker("ecoes-breaker-test", 2, time.Minute)
+	myGw := gateway.NewResilientEcoesGateway(gateway.NewEcoesGateway(mEcoes), resilience.RetryPolicy{}, breaker)
+
+	// ECOES is only called until the circuit opens
+	mEcoes.EXPECT().GetTechnicalDetailsByMPAN(ctx, &ecoesv2.GetTechnicalDetailsByMPANRequest{Mpan: "mpan-1"}).
+		Return(nil, status.Error(codes.DeadlineExceeded, "timeout")).Times(2)
+
+	for i := 0; i < 2; i++ {
+		_, err := myGw.GetMPANTechnicalDetails(ctx, "mpan-1")
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	}
+
+	_, err := myGw.GetMPANTechnicalDetails(ctx, "mpan-1")
+	assert.True(t, errors.Is(err, resilience.ErrCircuitOpen))
+	assert.Equal(t, resilience.CircuitOpen, breaker.State())
+
+	_, err = myGw.HasRelatedMPAN(ctx, "mpan-1")
+	assert.True(t, errors.Is(err, resilience.ErrCircuitOpen))
+}
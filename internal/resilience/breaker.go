@@ -0,0 +1,161 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/utilitywarehouse/go-operational/op"
+)
+
+// ErrCircuitOpen is returned without calling the dependency while the circuit is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type CircuitState int
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitBreaker stops calling a dependency after a number of consecutive transient failures.
+// Once open, calls fail straight away until the open duration elapses, then a single trial
+// call is let through: the circuit closes if it succeeds and opens again if it fails.
+type CircuitBreaker struct {
+	name             string
+	failureThreshold int
+	openDuration     time.Duration
+	now              func() time.Time
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+// NewCircuitBreaker creates a closed circuit breaker, the name is used to label its metrics.
+func NewCircuitBreaker(name string, failureThreshold int, openDuration time.Duration) *CircuitBreaker {
+	if failureThreshold < 1 {
+		failureThreshold = 1
+	}
+	b := &CircuitBreaker{
+		name:             name,
+		failureThreshold: failureThreshold,
+		openDuration:     openDuration,
+		now:              time.Now,
+	}
+	circuitStateGauge.WithLabelValues(name).Set(float64(CircuitClosed))
+
+	return b
+}
+
+// Execute calls fn unless the circuit is open. Only transient errors count as failures,
+// errors such as NotFound mean the dependency is working.
+func (b *CircuitBreaker) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !b.allow() {
+		circuitRejectionsCounter.WithLabelValues(b.name).Inc()
+		return ErrCircuitOpen
+	}
+
+	err := fn(ctx)
+	b.record(err != nil && IsTransient(err))
+
+	return err
+}
+
+// State returns the current state of the circuit.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+// NewHealthCheck reports the dependency as degraded while the circuit is not closed.
+func (b *CircuitBreaker) NewHealthCheck() func(*op.CheckResponse) {
+	return func(cr *op.CheckResponse) {
+		state := b.State()
+		if state != CircuitClosed {
+			cr.Degraded(fmt.Sprintf("%s circuit breaker is %s", b.name, state), fmt.Sprintf("Check the %s dependency", b.name))
+			return
+		}
+
+		cr.Healthy(fmt.Sprintf("%s circuit breaker is closed", b.name))
+	}
+}
+
+func (b *CircuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if b.now().Sub(b.openedAt) < b.openDuration {
+			return false
+		}
+		b.setState(CircuitHalfOpen)
+		b.trial = true
+		return true
+	case CircuitHalfOpen:
+		// only the trial call is let through until its result is known
+		if b.trial {
+			return false
+		}
+		b.trial = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (b *CircuitBreaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitHalfOpen {
+		b.trial = false
+		if failed {
+			b.open()
+		} else {
+			b.failures = 0
+			b.setState(CircuitClosed)
+		}
+		return
+	}
+
+	if !failed {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == CircuitClosed && b.failures >= b.failureThreshold {
+		b.open()
+	}
+}
+
+func (b *CircuitBreaker) open() {
+	b.openedAt = b.now()
+	b.failures = 0
+	b.setState(CircuitOpen)
+}
+
+func (b *CircuitBreaker) setState(state CircuitState) {
+	b.state = state
+	circuitStateGauge.WithLabelValues(b.name).Set(float64(state))
+}
@@ -0,0 +1,76 @@
+package resilience
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker("test", 2, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	unavailable := func(context.Context) error { return status.Error(codes.Unavailable, "unavailable") }
+	notFound := func(context.Context) error { return status.Error(codes.NotFound, "not found") }
+	ok := func(context.Context) error { return nil }
+
+	// errors which are not transient don't count as failures
+	assert.Error(t, breaker.Execute(ctx, notFound))
+	assert.Error(t, breaker.Execute(ctx, notFound))
+	assert.Equal(t, CircuitClosed, breaker.State())
+
+	// a success resets the consecutive failures
+	assert.Error(t, breaker.Execute(ctx, unavailable))
+	assert.NoError(t, breaker.Execute(ctx, ok))
+	assert.Error(t, breaker.Execute(ctx, unavailable))
+	assert.Equal(t, CircuitClosed, breaker.State())
+
+	assert.Error(t, breaker.Execute(ctx, unavailable))
+	assert.Equal(t, CircuitOpen, breaker.State())
+
+	called := false
+	err := breaker.Execute(ctx, func(context.Context) error {
+		called = true
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.False(t, called)
+
+	// the trial call fails and opens the circuit again
+	now = now.Add(time.Minute)
+	assert.Error(t, breaker.Execute(ctx, unavailable))
+	assert.Equal(t, CircuitOpen, breaker.State())
+	assert.ErrorIs(t, breaker.Execute(ctx, ok), ErrCircuitOpen)
+
+	// the trial call succeeds and closes the circuit
+	now = now.Add(time.Minute)
+	assert.NoError(t, breaker.Execute(ctx, ok))
+	assert.Equal(t, CircuitClosed, breaker.State())
+}
+
+func TestCircuitBreakerSingleTrial(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker("test-trial", 1, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	assert.Error(t, breaker.Execute(ctx, func(context.Context) error { return status.Error(codes.Unavailable, "unavailable") }))
+	now = now.Add(time.Minute)
+
+	err := breaker.Execute(ctx, func(ctx context.Context) error {
+		// other calls are rejected while the trial call is in flight
+		assert.Equal(t, CircuitHalfOpen, breaker.State())
+		assert.ErrorIs(t, breaker.Execute(ctx, func(context.Context) error { return nil }), ErrCircuitOpen)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, CircuitClosed, breaker.State())
+}
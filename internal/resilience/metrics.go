@@ -0,0 +1,23 @@
+package resilience
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	retriesCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "smart_booking_third_party_retries_total",
+		Help: "The number of retries of third party calls after a transient error",
+	})
+
+	circuitStateGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "smart_booking_circuit_breaker_state",
+		Help: "The state of the circuit breakers, 0 closed, 1 open and 2 half open",
+	}, []string{"name"})
+
+	circuitRejectionsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "smart_booking_circuit_breaker_rejections_total",
+		Help: "The number of calls rejected by open circuit breakers",
+	}, []string{"name"})
+)
@@ -0,0 +1,68 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy retries calls failing with a transient error, waiting an exponentially
+// increasing, jittered backoff between attempts.
+type RetryPolicy struct {
+	// MaxRetries is the number of attempts after the first one, 0 disables retries.
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// IsTransient returns true if the error has a gRPC code which is worth retrying.
+func IsTransient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+// Do calls fn until it succeeds, fails with an error that is not transient, the retries are exhausted
+// or the context is done. The last error is returned.
+func (p RetryPolicy) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	backoff := p.InitialBackoff
+
+	for attempt := 0; ; attempt++ {
+		err := fn(ctx)
+		if err == nil || !IsTransient(err) || attempt >= p.MaxRetries {
+			return err
+		}
+
+		retriesCounter.Inc()
+
+		timer := time.NewTimer(jitter(backoff))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+			backoff = p.MaxBackoff
+		}
+	}
+}
+
+// jitter returns a random duration between half and the whole backoff, so that clients
+// failing at the same time don't retry at the same time.
+func jitter(backoff time.Duration) time.Duration {
+	if backoff <= 0 {
+		return 0
+	}
+	half := backoff / 2
+	return half + rand.N(half+1)
+}
@@ -0,0 +1,83 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+	testCases := []struct {
+		description      string
+		errs             []error
+		expectedErr      error
+		expectedAttempts int
+	}{
+		{
+			description:      "succeeds straight away",
+			errs:             []error{nil},
+			expectedAttempts: 1,
+		},
+		{
+			description:      "retries transient errors until it succeeds",
+			errs:             []error{status.Error(codes.Unavailable, "unavailable"), status.Error(codes.DeadlineExceeded, "timeout"), nil},
+			expectedAttempts: 3,
+		},
+		{
+			description:      "gives up once the retries are exhausted",
+			errs:             []error{status.Error(codes.Unavailable, "1"), status.Error(codes.Unavailable, "2"), status.Error(codes.Unavailable, "3")},
+			expectedErr:      status.Error(codes.Unavailable, "3"),
+			expectedAttempts: 3,
+		},
+		{
+			description:      "does not retry errors which are not transient",
+			errs:             []error{status.Error(codes.NotFound, "not found")},
+			expectedErr:      status.Error(codes.NotFound, "not found"),
+			expectedAttempts: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			attempts := 0
+			err := policy.Do(context.Background(), func(context.Context) error {
+				err := tc.errs[attempts]
+				attempts++
+				return err
+			})
+
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedAttempts, attempts)
+		})
+	}
+}
+
+func TestRetryPolicyContextDone(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 5, InitialBackoff: time.Hour}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	attempts := 0
+	err := policy.Do(ctx, func(context.Context) error {
+		attempts++
+		return status.Error(codes.Unavailable, "unavailable")
+	})
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, 1, attempts)
+}
+
+func TestIsTransient(t *testing.T) {
+	assert.True(t, IsTransient(status.Error(codes.Unavailable, "unavailable")))
+	assert.True(t, IsTransient(errors.Join(errors.New("failed to get technical details"), status.Error(codes.ResourceExhausted, "slow down"))))
+	assert.False(t, IsTransient(status.Error(codes.InvalidArgument, "invalid mpan")))
+	assert.False(t, IsTransient(errors.New("not a gRPC error")))
+}
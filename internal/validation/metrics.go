@@ -0,0 +1,13 @@
+package validation
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// PostcodeParseFailures counts the postcodes received from a source, e.g. a topic,
+// which are not valid UK postcodes.
+var PostcodeParseFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "smart_booking_postcode_parse_failures_total",
+	Help: "the total number of postcodes which failed to parse, by source",
+}, []string{"source"})
@@ -0,0 +1,97 @@
+// Package validation holds the checks shared by the services for the identifiers
+// customers and agents type in: MPANs, MPRNs and UK postcodes.
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrInvalidMPAN     = errors.New("invalid mpan")
+	ErrInvalidMPRN     = errors.New("invalid mprn")
+	ErrInvalidPostcode = errors.New("invalid postcode")
+)
+
+// mpanPrimes are the weights applied to the first 12 digits of the MPAN core.
+var mpanPrimes = [12]int{3, 5, 7, 13, 17, 19, 23, 29, 31, 37, 41, 43}
+
+// postcodeRegex matches a UK postcode with the whitespace removed, the inward code
+// is always a digit followed by two letters.
+var postcodeRegex = regexp.MustCompile(`^(GIR0AA|[A-Z]{1,2}[0-9][A-Z0-9]?[0-9][A-Z]{2})$`)
+
+// MPAN validates the 13 digit MPAN core, ignoring any whitespace, and returns it
+// without whitespace.
+//
+// The last digit is a check digit: each of the first 12 digits is multiplied by
+// its prime weight and the sum, modulo 11 then modulo 10, must match it.
+func MPAN(mpan string) (string, error) {
+	mpan = stripWhitespace(mpan)
+	if len(mpan) != 13 || !isDigits(mpan) {
+		return "", fmt.Errorf("%w: an mpan core must be 13 digits", ErrInvalidMPAN)
+	}
+
+	sum := 0
+	for i, weight := range mpanPrimes {
+		sum += digit(mpan[i]) * weight
+	}
+	if sum%11%10 != digit(mpan[12]) {
+		return "", fmt.Errorf("%w: check digit mismatch", ErrInvalidMPAN)
+	}
+
+	return mpan, nil
+}
+
+// MPRN validates a 6 to 10 digit MPRN, ignoring any whitespace, and returns it
+// without whitespace.
+//
+// The last two digits are the check digits: each of the preceding digits is
+// multiplied by its position counting from the right and the sum, modulo 11,
+// must match them.
+func MPRN(mprn string) (string, error) {
+	mprn = stripWhitespace(mprn)
+	if len(mprn) < 6 || len(mprn) > 10 || !isDigits(mprn) {
+		return "", fmt.Errorf("%w: an mprn must be between 6 and 10 digits", ErrInvalidMPRN)
+	}
+
+	body := mprn[:len(mprn)-2]
+	sum := 0
+	for i := range body {
+		sum += digit(body[i]) * (len(body) - i)
+	}
+	if sum%11 != digit(mprn[len(mprn)-2])*10+digit(mprn[len(mprn)-1]) {
+		return "", fmt.Errorf("%w: check digits mismatch", ErrInvalidMPRN)
+	}
+
+	return mprn, nil
+}
+
+// Postcode validates a UK postcode and returns it normalised: upper case, with a
+// single space between the outward and inward codes, e.g. "e2 1zz" becomes "E2 1ZZ".
+func Postcode(postcode string) (string, error) {
+	postcode = strings.ToUpper(stripWhitespace(postcode))
+	if !postcodeRegex.MatchString(postcode) {
+		return "", ErrInvalidPostcode
+	}
+
+	return postcode[:len(postcode)-3] + " " + postcode[len(postcode)-3:], nil
+}
+
+func stripWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
+
+func isDigits(s string) bool {
+	for i := range s {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func digit(b byte) int {
+	return int(b - '0')
+}
@@ -0,0 +1,93 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMPAN(t *testing.T) {
+	testCases := []struct {
+		description string
+		input       string
+		expected    string
+		expectedErr error
+	}{
+		{description: "valid", input: "2199996734008", expected: "2199996734008"},
+		{description: "valid with whitespace", input: " 21 9999 6734 008 ", expected: "2199996734008"},
+		{description: "wrong check digit", input: "2199996734009", expectedErr: ErrInvalidMPAN},
+		{description: "too short", input: "219999673400", expectedErr: ErrInvalidMPAN},
+		{description: "not digits", input: "mpan-1", expectedErr: ErrInvalidMPAN},
+		{description: "empty", input: "", expectedErr: ErrInvalidMPAN},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			actual, err := MPAN(tc.input)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if actual != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMPRN(t *testing.T) {
+	testCases := []struct {
+		description string
+		input       string
+		expected    string
+		expectedErr error
+	}{
+		{description: "valid", input: "2724968810", expected: "2724968810"},
+		{description: "valid with leading zero check digit", input: "1234502", expected: "1234502"},
+		{description: "valid with whitespace", input: "2724 968 810", expected: "2724968810"},
+		{description: "wrong check digits", input: "2724968811", expectedErr: ErrInvalidMPRN},
+		{description: "too long", input: "27249688100", expectedErr: ErrInvalidMPRN},
+		{description: "too short", input: "12345", expectedErr: ErrInvalidMPRN},
+		{description: "not digits", input: "mprn-1", expectedErr: ErrInvalidMPRN},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			actual, err := MPRN(tc.input)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if actual != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPostcode(t *testing.T) {
+	testCases := []struct {
+		description string
+		input       string
+		expected    string
+		expectedErr error
+	}{
+		{description: "already normalised", input: "E2 1ZZ", expected: "E2 1ZZ"},
+		{description: "lower case without space", input: "e21zz", expected: "E2 1ZZ"},
+		{description: "extra whitespace", input: "  SW1A   1AA ", expected: "SW1A 1AA"},
+		{description: "long outward code", input: "ec1a1bb", expected: "EC1A 1BB"},
+		{description: "girobank", input: "gir 0aa", expected: "GIR 0AA"},
+		{description: "incomplete", input: "E2 1Z", expectedErr: ErrInvalidPostcode},
+		{description: "not a postcode", input: "postcode", expectedErr: ErrInvalidPostcode},
+		{description: "empty", input: "", expectedErr: ErrInvalidPostcode},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			actual, err := Postcode(tc.input)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if actual != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}